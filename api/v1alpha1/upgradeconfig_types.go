@@ -4,6 +4,7 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -15,6 +16,10 @@ const (
 	OSD UpgradeType = "OSD"
 	// ARO is a type of upgrade
 	ARO UpgradeType = "ARO"
+	// HCP is a type of upgrade for clusters with a hosted control plane
+	HCP UpgradeType = "HCP"
+	// Standard is a type of upgrade for self-managed clusters
+	Standard UpgradeType = "Standard"
 )
 
 // UpgradeConfigSpec defines the desired state of UpgradeConfig and upgrade window and freeze window
@@ -25,16 +30,38 @@ type UpgradeConfigSpec struct {
 	// Specify the upgrade start time
 	UpgradeAt string `json:"upgradeAt"`
 
+	// Specify recurring windows during which the upgrade may start. When set, the upgrade starts
+	// at the first time on or after upgradeAt that falls within one of the windows.
+	// +kubebuilder:validation:Optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
 	// +kubebuilder:validation:Minimum:=0
 	// The maximum grace period granted to a node whose drain is blocked by a Pod Disruption Budget, before that drain is forced. Measured in minutes. The minimum accepted value is 0 and in this case it will trigger force drain after the expectedNodeDrainTime lapsed.
 	PDBForceDrainTimeout int32 `json:"PDBForceDrainTimeout"`
 
-	// +kubebuilder:validation:Enum={"OSD","ARO"}
+	// +kubebuilder:validation:Enum={"OSD","ARO","HCP","Standard"}
 	// Type indicates the ClusterUpgrader implementation to use to perform an upgrade of the cluster
 	Type UpgradeType `json:"type"`
 
 	// Specify if scaling up an extra node for capacity reservation before upgrade starts is needed
 	CapacityReservation bool `json:"capacityReservation,omitempty"`
+
+	// Specify if the upgrade should be paused. While paused, no further upgrade steps are run and the
+	// worker MachineConfigPool is paused so that no more nodes are upgraded. Clearing the field resumes
+	// the upgrade from the last incomplete step.
+	// +kubebuilder:validation:Optional
+	Paused bool `json:"paused,omitempty"`
+
+	// Specify if the upgrade should be cancelled. An upgrade can only be cancelled before it has commenced
+	// the ClusterVersion update, after which the field has no effect. Cancelling removes any extra compute
+	// capacity and maintenance windows created for the upgrade.
+	// +kubebuilder:validation:Optional
+	Cancel bool `json:"cancel,omitempty"`
+
+	// Specify if the upgrade should only be rehearsed. In dry-run mode the upgrade never starts; instead
+	// the read-only checks of the upgrade are run and their outcome is reported in the status.
+	// +kubebuilder:validation:Optional
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
 // UpgradeConfigStatus defines the observed state of UpgradeConfig
@@ -43,6 +70,111 @@ type UpgradeConfigStatus struct {
 	// This record history of every upgrade
 	// +kubebuilder:validation:Optional
 	History UpgradeHistories `json:"history,omitempty"`
+
+	// Conditions summarise the state of the current upgrade
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// RehearsalReport is the outcome of the most recent dry-run of the upgrade
+	// +kubebuilder:validation:Optional
+	RehearsalReport *RehearsalReport `json:"rehearsalReport,omitempty"`
+
+	// HealthCheckReports holds the outcome of the most recent health check run at each stage of
+	// the upgrade
+	// +kubebuilder:validation:Optional
+	HealthCheckReports HealthCheckReports `json:"healthCheckReports,omitempty"`
+}
+
+// RehearsalReport describes the outcome of rehearsing an upgrade in dry-run mode
+type RehearsalReport struct {
+	// Version the upgrade was rehearsed for
+	Version string `json:"version"`
+
+	// Time the rehearsal was run
+	RehearsalTime metav1.Time `json:"rehearsalTime"`
+
+	// Ready is true when every check passed, so nothing is known to block the upgrade
+	Ready bool `json:"ready"`
+
+	// Checks holds the outcome of each check run by the rehearsal
+	// +kubebuilder:validation:Optional
+	Checks []RehearsalCheck `json:"checks,omitempty"`
+}
+
+// RehearsalCheck is the outcome of a check run by an upgrade rehearsal
+type RehearsalCheck struct {
+	// Name of the check
+	Name string `json:"name"`
+
+	// Passed is true when the check would not block the upgrade
+	Passed bool `json:"passed"`
+
+	// Human readable message describing the outcome of the check
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+}
+
+// HealthCheckStage is a stage of the upgrade at which health checks are run
+type HealthCheckStage string
+
+const (
+	// HealthCheckStagePre is the health check run before the upgrade commences
+	HealthCheckStagePre HealthCheckStage = "Pre"
+	// HealthCheckStagePost is the health check run once the workers are upgraded
+	HealthCheckStagePost HealthCheckStage = "Post"
+)
+
+// HealthCheckReports is a slice of HealthCheckReport
+type HealthCheckReports []HealthCheckReport
+
+// HealthCheckReport describes the outcome of a run of the health checks of the cluster
+type HealthCheckReport struct {
+	// Stage of the upgrade the health checks were run at
+	// +kubebuilder:validation:Enum={"Pre","Post"}
+	Stage HealthCheckStage `json:"stage"`
+
+	// Version the upgrade is to
+	Version string `json:"version"`
+
+	// Time the health checks started
+	StartTime metav1.Time `json:"startTime"`
+
+	// Time the health checks completed
+	CompleteTime metav1.Time `json:"completeTime"`
+
+	// Passed is true when no check that blocks the upgrade failed
+	Passed bool `json:"passed"`
+
+	// Checks holds the outcome of each health check, in the order they were run
+	// +kubebuilder:validation:Optional
+	Checks []HealthCheckResult `json:"checks,omitempty"`
+}
+
+// HealthCheckResult is the outcome of a single health check
+type HealthCheckResult struct {
+	// Name of the check
+	Name string `json:"name"`
+
+	// Severity of the check: Block checks hold up the upgrade when they fail, Warn checks don't
+	// +kubebuilder:validation:Enum={"Block","Warn"}
+	Severity string `json:"severity"`
+
+	// Passed is true when the check passed
+	Passed bool `json:"passed"`
+
+	// Human readable message describing why the check failed
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+
+	// FailedObjects names the objects that failed the check, such as firing alerts, degraded
+	// operators or cordoned nodes
+	// +kubebuilder:validation:Optional
+	FailedObjects []string `json:"failedObjects,omitempty"`
+
+	// Time the check was run
+	CheckTime metav1.Time `json:"checkTime"`
 }
 
 // UpgradeHistories is a slice of UpgradeHistory
@@ -56,7 +188,7 @@ type UpgradeHistory struct {
 	//Version preceding this upgrade
 	PrecedingVersion string `json:"precedingVersion,omitempty"`
 
-	// +kubebuilder:validation:Enum={"New","Pending","Upgrading","Upgraded", "Failed"}
+	// +kubebuilder:validation:Enum={"New","Pending","Upgrading","Upgraded", "Failed", "Cancelled"}
 	// This describe the status of the upgrade process
 	Phase UpgradePhase `json:"phase"`
 
@@ -71,6 +203,105 @@ type UpgradeHistory struct {
 	WorkerStartTime *metav1.Time `json:"workerStartTime,omitempty"`
 
 	WorkerCompleteTime *metav1.Time `json:"workerCompleteTime,omitempty"`
+
+	// WorkerPools is the upgrade progress of each worker MachineConfigPool
+	// +kubebuilder:validation:Optional
+	WorkerPools []WorkerPoolStatus `json:"workerPools,omitempty"`
+
+	// Soak is the progress of the soak period the cluster must stay healthy for after the upgrade
+	// +kubebuilder:validation:Optional
+	Soak *SoakStatus `json:"soak,omitempty"`
+
+	// Nodes is the drain and upgrade progress of each worker node that has been cordoned
+	// +kubebuilder:validation:Optional
+	Nodes []NodeUpgradeStatus `json:"nodes,omitempty"`
+}
+
+// SoakStatus is the progress of the post-upgrade soak period
+type SoakStatus struct {
+	// Time since which the cluster has been continuously healthy. It is unset while the cluster
+	// is unhealthy.
+	// +kubebuilder:validation:Optional
+	HealthySince *metav1.Time `json:"healthySince,omitempty"`
+	// Number of times the cluster became unhealthy during the soak period, restarting it
+	// +kubebuilder:validation:Optional
+	Regressions int `json:"regressions,omitempty"`
+	// Time the cluster most recently became unhealthy during the soak period
+	// +kubebuilder:validation:Optional
+	LastRegressionTime *metav1.Time `json:"lastRegressionTime,omitempty"`
+	// Health checks that failed when the cluster most recently became unhealthy
+	// +kubebuilder:validation:Optional
+	LastRegression string `json:"lastRegression,omitempty"`
+	// Time the soak period completed
+	// +kubebuilder:validation:Optional
+	CompleteTime *metav1.Time `json:"completeTime,omitempty"`
+}
+
+// WorkerPoolStatus is the upgrade progress of a worker MachineConfigPool
+type WorkerPoolStatus struct {
+	// Name of the MachineConfigPool
+	Name string `json:"name"`
+	// Number of machines in the pool
+	MachineCount int32 `json:"machineCount"`
+	// Number of machines in the pool that have been updated
+	UpdatedMachineCount int32 `json:"updatedMachineCount"`
+	// Time at which the pool started upgrading
+	// +kubebuilder:validation:Optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// Time at which the pool completed upgrading
+	// +kubebuilder:validation:Optional
+	CompleteTime *metav1.Time `json:"completeTime,omitempty"`
+}
+
+// NodeUpgradeStatus is the drain and upgrade progress of a worker node
+type NodeUpgradeStatus struct {
+	// Name of the node
+	Name string `json:"name"`
+	// MachineConfigPool of the node
+	// +kubebuilder:validation:Optional
+	Pool string `json:"pool,omitempty"`
+	// Time at which the node was cordoned to be drained
+	// +kubebuilder:validation:Optional
+	CordonTime *metav1.Time `json:"cordonTime,omitempty"`
+	// Drain strategies that have been executed on the node
+	// +kubebuilder:validation:Optional
+	DrainStrategies []NodeDrainStrategyStatus `json:"drainStrategies,omitempty"`
+	// What the drain is waiting on, such as the pods still holding the node's volumes, truncated
+	// to 256 characters
+	// +kubebuilder:validation:Optional
+	DrainPending string `json:"drainPending,omitempty"`
+	// Whether the node failed to drain in time
+	// +kubebuilder:validation:Optional
+	DrainFailed bool `json:"drainFailed,omitempty"`
+	// State of the machine config daemon of the node
+	// +kubebuilder:validation:Optional
+	MachineConfigDaemonState string `json:"machineConfigDaemonState,omitempty"`
+	// Whether the machine config daemon is upgrading the node
+	// +kubebuilder:validation:Optional
+	Upgrading bool `json:"upgrading,omitempty"`
+	// Boot ID of the node when it was cordoned, by which its reboot is detected
+	// +kubebuilder:validation:Optional
+	BootID string `json:"bootID,omitempty"`
+	// Time at which the node was observed to have rebooted
+	// +kubebuilder:validation:Optional
+	RebootTime *metav1.Time `json:"rebootTime,omitempty"`
+	// Time at which the node became ready after rebooting
+	// +kubebuilder:validation:Optional
+	ReadyTime *metav1.Time `json:"readyTime,omitempty"`
+}
+
+// NodeDrainStrategyStatus describes a drain strategy executed on a node
+type NodeDrainStrategyStatus struct {
+	// Name of the drain strategy
+	Name string `json:"name"`
+	// Time at which the strategy was last executed
+	LastExecutionTime metav1.Time `json:"lastExecutionTime"`
+	// What the strategy did when it was last executed, truncated to 256 characters
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+	// Number of pods, or volume attachments, that the strategy acted on when it was last executed
+	// +kubebuilder:validation:Optional
+	Count int `json:"count,omitempty"`
 }
 
 // UpgradeConditionType is a Go string type.
@@ -100,27 +331,46 @@ type UpgradeCondition struct {
 	// Human readable message indicating details about last transition.
 	// +kubebuilder:validation:Optional
 	Message string `json:"message,omitempty"`
+	// Number of failed attempts of the step this condition describes.
+	// +kubebuilder:validation:Optional
+	Attempts int `json:"attempts,omitempty"`
+	// Error returned by the most recent failed attempt of the step.
+	// +kubebuilder:validation:Optional
+	LastError string `json:"lastError,omitempty"`
+	// Time of the most recent failed attempt of the step.
+	// +kubebuilder:validation:Optional
+	LastAttemptTime *metav1.Time `json:"lastAttemptTime,omitempty"`
 }
 
 const (
 	// SendStartedNotification is an UpgradeConditionType
 	SendStartedNotification UpgradeConditionType = "StartedNotificationSent"
+	// UpgradeDelayedCheck is an UpgradeConditionType
+	UpgradeDelayedCheck UpgradeConditionType = "UpgradeDelayChecked"
 	// UpgradePreHealthCheck is an UpgradeConditionType
 	UpgradePreHealthCheck UpgradeConditionType = "ClusterHealthyBeforeUpgrade"
 	// ExtDepAvailabilityCheck is an UpgradeConditionType
 	ExtDepAvailabilityCheck UpgradeConditionType = "ExternalDependenciesAvailable"
 	// UpgradeScaleUpExtraNodes is an UpgradeConditionType
 	UpgradeScaleUpExtraNodes UpgradeConditionType = "ComputeCapacityReserved"
+	// PreUpgradeHooks is an UpgradeConditionType
+	PreUpgradeHooks UpgradeConditionType = "PreUpgradeHooksCompleted"
 	// ControlPlaneMaintWindow is an UpgradeConditionType
 	ControlPlaneMaintWindow UpgradeConditionType = "ControlPlaneMaintenanceWindowCreated"
 	// CommenceUpgrade is an UpgradeConditionType
 	CommenceUpgrade UpgradeConditionType = "UpgradeCommenced"
 	// ControlPlaneUpgraded is an UpgradeConditionType
 	ControlPlaneUpgraded UpgradeConditionType = "ControlPlaneUpgraded"
+	// ControlPlaneUpgradedHooks is an UpgradeConditionType
+	ControlPlaneUpgradedHooks UpgradeConditionType = "ControlPlaneUpgradedHooksCompleted"
 	// RemoveControlPlaneMaintWindow is an UpgradeConditionType
 	RemoveControlPlaneMaintWindow UpgradeConditionType = "ControlPlaneMaintenanceWindowRemoved"
+	// CommenceNodePoolsUpgrade is an UpgradeConditionType
+	CommenceNodePoolsUpgrade UpgradeConditionType = "NodePoolsUpgradeCommenced"
 	// WorkersMaintWindow is an UpgradeConditionType
 	WorkersMaintWindow UpgradeConditionType = "WorkersMaintenanceWindowCreated"
+	// CanaryWorkersUpgraded is an UpgradeConditionType
+	CanaryWorkersUpgraded UpgradeConditionType = "CanaryWorkerNodesUpgraded"
 	// AllWorkerNodesUpgraded is an UpgradeConditionType
 	AllWorkerNodesUpgraded UpgradeConditionType = "WorkerNodesUpgraded"
 	// RemoveExtraScaledNodes is an UpgradeConditionType
@@ -131,10 +381,25 @@ const (
 	PostClusterHealthCheck UpgradeConditionType = "ClusterHealthyAfterUpgrade"
 	// PostUpgradeProcedures is an UpgradeConditionType
 	PostUpgradeProcedures UpgradeConditionType = "PostUpgradeTasksCompleted"
+	// PostUpgradeHooks is an UpgradeConditionType
+	PostUpgradeHooks UpgradeConditionType = "PostUpgradeHooksCompleted"
 	// SendCompletedNotification is an UpgradeConditionType
 	SendCompletedNotification UpgradeConditionType = "CompletedNotificationSent"
 	// IsClusterUpgradable is an UpgradeConditionType
 	IsClusterUpgradable UpgradeConditionType = "IsClusterUpgradable"
+	// FreezePeriodEnded is an UpgradeConditionType
+	FreezePeriodEnded UpgradeConditionType = "FreezePeriodEnded"
+)
+
+const (
+	// UpgradeConfigReady is a status condition type indicating the desired upgrade has completed
+	UpgradeConfigReady = "Ready"
+	// UpgradeConfigProgressing is a status condition type indicating the upgrade is being carried out
+	UpgradeConfigProgressing = "Progressing"
+	// UpgradeConfigDegraded is a status condition type indicating the upgrade has encountered an error
+	UpgradeConfigDegraded = "Degraded"
+	// UpgradeConfigBlocked is a status condition type indicating the upgrade is prevented from progressing
+	UpgradeConfigBlocked = "Blocked"
 )
 
 // UpgradePhase is a Go string type.
@@ -151,6 +416,8 @@ const (
 	UpgradePhaseUpgraded UpgradePhase = "Upgraded"
 	// UpgradePhaseFailed defines a failed upgrade.
 	UpgradePhaseFailed UpgradePhase = "Failed"
+	// UpgradePhaseCancelled defines an upgrade that was cancelled before it commenced.
+	UpgradePhaseCancelled UpgradePhase = "Cancelled"
 	// UpgradePhaseUnknown defines an unknown upgrade state.
 	UpgradePhaseUnknown UpgradePhase = "Unknown"
 )
@@ -162,10 +429,10 @@ const (
 // +kubebuilder:resource:path=upgradeconfigs,scope=Namespaced,shortName=upgrade
 // +kubebuilder:printcolumn:name="desired_version",type="string",JSONPath=".spec.desired.version"
 // +kubebuilder:printcolumn:name="phase",type="string",JSONPath=".status.history[0].phase"
-// +kubebuilder:printcolumn:name="stage",type="string",JSONPath=".status.history[0].conditions[0].type"
-// +kubebuilder:printcolumn:name="status",type="string",JSONPath=".status.history[0].conditions[0].status"
-// +kubebuilder:printcolumn:name="reason",type="string",JSONPath=".status.history[0].conditions[0].reason"
-// +kubebuilder:printcolumn:name="message",type="string",JSONPath=".status.history[0].conditions[0].message"
+// +kubebuilder:printcolumn:name="ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="progressing",type="string",JSONPath=".status.conditions[?(@.type==\"Progressing\")].status"
+// +kubebuilder:printcolumn:name="reason",type="string",JSONPath=".status.conditions[?(@.type==\"Progressing\")].reason"
+// +kubebuilder:printcolumn:name="message",type="string",JSONPath=".status.conditions[?(@.type==\"Progressing\")].message"
 type UpgradeConfig struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -179,6 +446,27 @@ func (uc *UpgradeConfig) GetPDBDrainTimeoutDuration() time.Duration {
 	return time.Duration(uc.Spec.PDBForceDrainTimeout) * time.Minute
 }
 
+// SetStatusConditions sets the top-level status conditions of the UpgradeConfig so that only
+// the supplied condition types are true. Each condition is given the supplied reason and
+// message, and records the generation of the UpgradeConfig it was observed at.
+func (uc *UpgradeConfig) SetStatusConditions(reason string, message string, trueTypes ...string) {
+	for _, t := range []string{UpgradeConfigReady, UpgradeConfigProgressing, UpgradeConfigDegraded, UpgradeConfigBlocked} {
+		status := metav1.ConditionFalse
+		for _, tt := range trueTypes {
+			if t == tt {
+				status = metav1.ConditionTrue
+			}
+		}
+		meta.SetStatusCondition(&uc.Status.Conditions, metav1.Condition{
+			Type:               t,
+			Status:             status,
+			ObservedGeneration: uc.Generation,
+			Reason:             reason,
+			Message:            message,
+		})
+	}
+}
+
 // GetHealthCheckDuration returns the duration to perform HealthCheck in hours
 func (uc *UpgradeConfig) GetHealthCheckDuration() time.Duration {
 	return time.Duration(time.Hour * 2)
@@ -193,6 +481,27 @@ type UpgradeConfigList struct {
 	Items           []UpgradeConfig `json:"items"`
 }
 
+// Weekday is a day of the week on which a maintenance window opens
+// +kubebuilder:validation:Enum={"Monday","Tuesday","Wednesday","Thursday","Friday","Saturday","Sunday"}
+type Weekday string
+
+// MaintenanceWindow represents a recurring period of time during which an upgrade may start
+type MaintenanceWindow struct {
+	// Days of the week on which the window opens
+	// +kubebuilder:validation:MinItems=1
+	Days []Weekday `json:"days"`
+	// Time of day at which the window opens, in 24-hour HH:MM format
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	StartTime string `json:"startTime"`
+	// Time of day at which the window closes, in 24-hour HH:MM format. A window that closes
+	// at or before its start time closes on the following day.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	EndTime string `json:"endTime"`
+	// IANA time zone the window is expressed in, such as "Europe/Berlin". Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
 // Update represents a release go gonna upgraded to
 type Update struct {
 	// Version of openshift release
@@ -348,6 +657,28 @@ func (histories *UpgradeHistories) SetHistory(history UpgradeHistory) {
 	}
 	*histories = append([]UpgradeHistory{history}, *histories...)
 }
+
+// GetReport returns the health check report of the given stage
+func (reports HealthCheckReports) GetReport(stage HealthCheckStage) *HealthCheckReport {
+	for i := range reports {
+		if reports[i].Stage == stage {
+			return &reports[i]
+		}
+	}
+	return nil
+}
+
+// SetReport replaces the health check report of the report's stage
+func (reports *HealthCheckReports) SetReport(report HealthCheckReport) {
+	for i, r := range *reports {
+		if r.Stage == report.Stage {
+			(*reports)[i] = report
+			return
+		}
+	}
+	*reports = append(*reports, report)
+}
+
 func init() {
 	SchemeBuilder.Register(&UpgradeConfig{}, &UpgradeConfigList{})
 }
@@ -5,6 +5,7 @@
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -29,6 +30,205 @@ func (in Conditions) DeepCopy() Conditions {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheckReport) DeepCopyInto(out *HealthCheckReport) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.CompleteTime.DeepCopyInto(&out.CompleteTime)
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]HealthCheckResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckReport.
+func (in *HealthCheckReport) DeepCopy() *HealthCheckReport {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheckReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in HealthCheckReports) DeepCopyInto(out *HealthCheckReports) {
+	{
+		in := &in
+		*out = make(HealthCheckReports, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckReports.
+func (in HealthCheckReports) DeepCopy() HealthCheckReports {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheckReports)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheckResult) DeepCopyInto(out *HealthCheckResult) {
+	*out = *in
+	if in.FailedObjects != nil {
+		in, out := &in.FailedObjects, &out.FailedObjects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.CheckTime.DeepCopyInto(&out.CheckTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckResult.
+func (in *HealthCheckResult) DeepCopy() *HealthCheckResult {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheckResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]Weekday, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeDrainStrategyStatus) DeepCopyInto(out *NodeDrainStrategyStatus) {
+	*out = *in
+	in.LastExecutionTime.DeepCopyInto(&out.LastExecutionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDrainStrategyStatus.
+func (in *NodeDrainStrategyStatus) DeepCopy() *NodeDrainStrategyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeDrainStrategyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeUpgradeStatus) DeepCopyInto(out *NodeUpgradeStatus) {
+	*out = *in
+	if in.CordonTime != nil {
+		in, out := &in.CordonTime, &out.CordonTime
+		*out = (*in).DeepCopy()
+	}
+	if in.DrainStrategies != nil {
+		in, out := &in.DrainStrategies, &out.DrainStrategies
+		*out = make([]NodeDrainStrategyStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RebootTime != nil {
+		in, out := &in.RebootTime, &out.RebootTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ReadyTime != nil {
+		in, out := &in.ReadyTime, &out.ReadyTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeUpgradeStatus.
+func (in *NodeUpgradeStatus) DeepCopy() *NodeUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RehearsalCheck) DeepCopyInto(out *RehearsalCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RehearsalCheck.
+func (in *RehearsalCheck) DeepCopy() *RehearsalCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(RehearsalCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RehearsalReport) DeepCopyInto(out *RehearsalReport) {
+	*out = *in
+	in.RehearsalTime.DeepCopyInto(&out.RehearsalTime)
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]RehearsalCheck, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RehearsalReport.
+func (in *RehearsalReport) DeepCopy() *RehearsalReport {
+	if in == nil {
+		return nil
+	}
+	out := new(RehearsalReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SoakStatus) DeepCopyInto(out *SoakStatus) {
+	*out = *in
+	if in.HealthySince != nil {
+		in, out := &in.HealthySince, &out.HealthySince
+		*out = (*in).DeepCopy()
+	}
+	if in.LastRegressionTime != nil {
+		in, out := &in.LastRegressionTime, &out.LastRegressionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompleteTime != nil {
+		in, out := &in.CompleteTime, &out.CompleteTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SoakStatus.
+func (in *SoakStatus) DeepCopy() *SoakStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SoakStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Update) DeepCopyInto(out *Update) {
 	*out = *in
@@ -59,7 +259,7 @@ func (in *UpgradeConfig) DeepCopyInto(out *UpgradeConfig) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -117,6 +317,13 @@ func (in *UpgradeConfigList) DeepCopyObject() runtime.Object {
 func (in *UpgradeConfigSpec) DeepCopyInto(out *UpgradeConfigSpec) {
 	*out = *in
 	out.Desired = in.Desired
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeConfigSpec.
@@ -139,6 +346,25 @@ func (in *UpgradeConfigStatus) DeepCopyInto(out *UpgradeConfigStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RehearsalReport != nil {
+		in, out := &in.RehearsalReport, &out.RehearsalReport
+		*out = new(RehearsalReport)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HealthCheckReports != nil {
+		in, out := &in.HealthCheckReports, &out.HealthCheckReports
+		*out = make(HealthCheckReports, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeConfigStatus.
@@ -198,6 +424,25 @@ func (in *UpgradeHistory) DeepCopyInto(out *UpgradeHistory) {
 		in, out := &in.WorkerCompleteTime, &out.WorkerCompleteTime
 		*out = (*in).DeepCopy()
 	}
+	if in.WorkerPools != nil {
+		in, out := &in.WorkerPools, &out.WorkerPools
+		*out = make([]WorkerPoolStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Soak != nil {
+		in, out := &in.Soak, &out.Soak
+		*out = new(SoakStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]NodeUpgradeStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeHistory.
@@ -209,3 +454,26 @@ func (in *UpgradeHistory) DeepCopy() *UpgradeHistory {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerPoolStatus) DeepCopyInto(out *WorkerPoolStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompleteTime != nil {
+		in, out := &in.CompleteTime, &out.CompleteTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerPoolStatus.
+func (in *WorkerPoolStatus) DeepCopy() *WorkerPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -2,6 +2,7 @@ package machineconfigpool
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	machineconfigapi "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
@@ -63,17 +64,13 @@ func (r *ReconcileMachineConfigPool) Reconcile(ctx context.Context, request reco
 	if uc.Status.History != nil {
 		history := uc.Status.History.GetHistory(uc.Spec.Desired.Version)
 		if history != nil && history.Phase == upgradev1alpha1.UpgradePhaseUpgrading {
-			if instance.Status.UpdatedMachineCount == 0 {
-				if history.WorkerStartTime == nil {
-					history.WorkerStartTime = &metav1.Time{Time: time.Now()}
-				}
+			pools := &machineconfigapi.MachineConfigPoolList{}
+			err = r.Client.List(context.TODO(), pools)
+			if err != nil {
+				return reconcile.Result{}, err
 			}
 
-			if instance.Status.MachineCount == instance.Status.UpdatedMachineCount {
-				if history.WorkerStartTime != nil && history.WorkerCompleteTime == nil {
-					history.WorkerCompleteTime = &metav1.Time{Time: time.Now()}
-				}
-			}
+			recordWorkerPools(history, pools.Items, time.Now())
 			uc.Status.History.SetHistory(*history)
 			err = r.Client.Status().Update(context.TODO(), uc)
 			if err != nil {
@@ -85,6 +82,49 @@ func (r *ReconcileMachineConfigPool) Reconcile(ctx context.Context, request reco
 	return reconcile.Result{}, nil
 }
 
+// recordWorkerPools records the upgrade progress of each worker pool in the history, along with
+// the times at which the workers as a whole started and completed upgrading
+func recordWorkerPools(history *upgradev1alpha1.UpgradeHistory, pools []machineconfigapi.MachineConfigPool, now time.Time) {
+	allUpdated := true
+	for _, pool := range pools {
+		if !isWorkerPool(pool.Name) {
+			continue
+		}
+
+		var status *upgradev1alpha1.WorkerPoolStatus
+		for i := range history.WorkerPools {
+			if history.WorkerPools[i].Name == pool.Name {
+				status = &history.WorkerPools[i]
+			}
+		}
+		if status == nil {
+			history.WorkerPools = append(history.WorkerPools, upgradev1alpha1.WorkerPoolStatus{Name: pool.Name})
+			status = &history.WorkerPools[len(history.WorkerPools)-1]
+		}
+		status.MachineCount = pool.Status.MachineCount
+		status.UpdatedMachineCount = pool.Status.UpdatedMachineCount
+
+		// A pool that is held paused has not started upgrading, even if its machines are outdated
+		updated := pool.Status.MachineCount == pool.Status.UpdatedMachineCount
+		if !updated && !pool.Spec.Paused && status.StartTime == nil {
+			status.StartTime = &metav1.Time{Time: now}
+		}
+		if updated && status.StartTime != nil && status.CompleteTime == nil {
+			status.CompleteTime = &metav1.Time{Time: now}
+		}
+		allUpdated = allUpdated && updated
+
+		if status.StartTime != nil && history.WorkerStartTime == nil {
+			history.WorkerStartTime = &metav1.Time{Time: now}
+		}
+	}
+	sort.Slice(history.WorkerPools, func(i, j int) bool { return history.WorkerPools[i].Name < history.WorkerPools[j].Name })
+
+	if allUpdated && history.WorkerStartTime != nil && history.WorkerCompleteTime == nil {
+		history.WorkerCompleteTime = &metav1.Time{Time: now}
+	}
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *ReconcileMachineConfigPool) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
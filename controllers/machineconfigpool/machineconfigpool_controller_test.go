@@ -0,0 +1,91 @@
+package machineconfigpool
+
+import (
+	"time"
+
+	machineconfigapi "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+)
+
+var _ = Describe("MachineConfigPoolController", func() {
+	var (
+		history *upgradev1alpha1.UpgradeHistory
+		now     time.Time
+
+		newPool = func(name string, machines int32, updated int32, paused bool) machineconfigapi.MachineConfigPool {
+			return machineconfigapi.MachineConfigPool{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec:       machineconfigapi.MachineConfigPoolSpec{Paused: paused},
+				Status:     machineconfigapi.MachineConfigPoolStatus{MachineCount: machines, UpdatedMachineCount: updated},
+			}
+		}
+	)
+
+	BeforeEach(func() {
+		history = &upgradev1alpha1.UpgradeHistory{Version: "4.14.1", Phase: upgradev1alpha1.UpgradePhaseUpgrading}
+		now = time.Now()
+	})
+
+	Context("When filtering MachineConfigPool events", func() {
+		It("reconciles custom worker pools", func() {
+			pool := newPool("infra", 0, 0, false)
+			Expect(isWorkerPredicate().Update(event.UpdateEvent{ObjectNew: &pool})).To(BeTrue())
+		})
+		It("ignores the master pool", func() {
+			pool := newPool("master", 0, 0, false)
+			Expect(isWorkerPredicate().Update(event.UpdateEvent{ObjectNew: &pool})).To(BeFalse())
+		})
+	})
+
+	Context("When recording the progress of the worker pools", func() {
+		It("records the progress of each worker pool", func() {
+			recordWorkerPools(history, []machineconfigapi.MachineConfigPool{
+				newPool("worker", 3, 1, false),
+				newPool("master", 3, 0, false),
+				newPool("infra", 2, 0, true),
+			}, now)
+			Expect(history.WorkerPools).To(HaveLen(2))
+			Expect(history.WorkerPools[0].Name).To(Equal("infra"))
+			Expect(history.WorkerPools[0].StartTime).To(BeNil())
+			Expect(history.WorkerPools[1].Name).To(Equal("worker"))
+			Expect(history.WorkerPools[1].UpdatedMachineCount).To(Equal(int32(1)))
+			Expect(history.WorkerPools[1].StartTime).NotTo(BeNil())
+			Expect(history.WorkerStartTime).NotTo(BeNil())
+			Expect(history.WorkerCompleteTime).To(BeNil())
+		})
+		It("only records the workers as complete once every pool has upgraded", func() {
+			recordWorkerPools(history, []machineconfigapi.MachineConfigPool{
+				newPool("worker", 3, 1, false),
+				newPool("infra", 2, 0, true),
+			}, now)
+			recordWorkerPools(history, []machineconfigapi.MachineConfigPool{
+				newPool("worker", 3, 3, false),
+				newPool("infra", 2, 0, false),
+			}, now)
+			Expect(history.WorkerPools[1].CompleteTime).NotTo(BeNil())
+			Expect(history.WorkerPools[0].StartTime).NotTo(BeNil())
+			Expect(history.WorkerCompleteTime).To(BeNil())
+
+			recordWorkerPools(history, []machineconfigapi.MachineConfigPool{
+				newPool("worker", 3, 3, false),
+				newPool("infra", 2, 2, false),
+			}, now)
+			Expect(history.WorkerPools[0].CompleteTime).NotTo(BeNil())
+			Expect(history.WorkerCompleteTime).NotTo(BeNil())
+		})
+		It("does not record pools that have not started as complete", func() {
+			recordWorkerPools(history, []machineconfigapi.MachineConfigPool{
+				newPool("worker", 3, 3, false),
+			}, now)
+			Expect(history.WorkerPools[0].CompleteTime).To(BeNil())
+			Expect(history.WorkerStartTime).To(BeNil())
+			Expect(history.WorkerCompleteTime).To(BeNil())
+		})
+	})
+})
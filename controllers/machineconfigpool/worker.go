@@ -5,6 +5,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	machineconfigapi "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
+	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
 )
 
 func isWorkerPredicate() predicate.Predicate {
@@ -29,6 +30,8 @@ func isWorkerPredicate() predicate.Predicate {
 	}
 }
 
+// isWorkerPool returns whether the named pool is a worker pool, which is any pool other than
+// the master pool, such as the worker, infra or custom pools
 func isWorkerPool(name string) bool {
-	return name == "worker"
+	return name != machinery.MasterNodeType
 }
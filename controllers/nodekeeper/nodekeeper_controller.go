@@ -14,6 +14,7 @@ import (
 	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
 	"github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -56,6 +57,11 @@ func (r *ReconcileNodeKeeper) Reconcile(ctx context.Context, request reconcile.R
 		return reconcile.Result{}, err
 	}
 
+	// The nodes of a hosted control plane cluster are drained by the controller of their NodePool
+	if uc.Spec.Type == upgradev1alpha1.HCP {
+		return reconcile.Result{}, nil
+	}
+
 	upgradeResult, err := r.Machinery.IsUpgrading(r.Client, "worker")
 	if err != nil {
 		return reconcile.Result{}, err
@@ -84,9 +90,20 @@ func (r *ReconcileNodeKeeper) Reconcile(ctx context.Context, request reconcile.R
 	}
 	if !result.IsCordoned {
 		metricsClient.ResetMetricNodeDrainFailed(node.Name)
+		// A node that was cordoned is followed until it is ready again after rebooting
+		if status := getNodeStatus(history, node.Name, false); status != nil {
+			recorded := status.DeepCopy()
+			recordNodeProgress(status, node, result, r.Machinery.IsNodeUpgrading(node), time.Now())
+			return reconcile.Result{}, r.updateNodeStatus(uc, history, recorded, status)
+		}
 		return reconcile.Result{}, nil
 	}
 
+	status := getNodeStatus(history, node.Name, true)
+	recorded := status.DeepCopy()
+	isUpgrading := r.Machinery.IsNodeUpgrading(node)
+	recordNodeProgress(status, node, result, isUpgrading, time.Now())
+
 	target := config.CMTarget{}
 	cmTarget, err := target.NewCMTarget()
 	if err != nil {
@@ -119,19 +136,31 @@ func (r *ReconcileNodeKeeper) Reconcile(ctx context.Context, request reconcile.R
 		if err != nil {
 			return reconcile.Result{}, err
 		}
+		recordDrainResults(status, res, hasFailed, time.Now())
 		if hasFailed {
 			reqLogger.Info(fmt.Sprintf("Node drain timed out %s. Alerting.", node.Name))
 			// Set metric only for the node going through upgrade
-			if r.Machinery.IsNodeUpgrading(node) {
+			if isUpgrading {
 				metricsClient.UpdateMetricNodeDrainFailed(node.Name)
 			}
-			return reconcile.Result{RequeueAfter: time.Minute * 1}, nil
+			return reconcile.Result{RequeueAfter: time.Minute * 1}, r.updateNodeStatus(uc, history, recorded, status)
 		} else {
 			metricsClient.ResetMetricNodeDrainFailed(node.Name)
 		}
 	}
 
-	return reconcile.Result{RequeueAfter: time.Minute * 1}, nil
+	return reconcile.Result{RequeueAfter: time.Minute * 1}, r.updateNodeStatus(uc, history, recorded, status)
+}
+
+// updateNodeStatus records the progress of the node in the UpgradeConfig status, if it has changed,
+// and removes the node progress of earlier upgrades
+func (r *ReconcileNodeKeeper) updateNodeStatus(uc *upgradev1alpha1.UpgradeConfig, history *upgradev1alpha1.UpgradeHistory, recorded, status *upgradev1alpha1.NodeUpgradeStatus) error {
+	pruned := pruneNodeStatuses(uc.Status.History, history.Version)
+	if !pruned && equality.Semantic.DeepEqual(recorded, status) {
+		return nil
+	}
+	uc.Status.History.SetHistory(*history)
+	return r.Client.Status().Update(context.TODO(), uc)
 }
 
 // SetupWithManager sets up the controller with the Manager.
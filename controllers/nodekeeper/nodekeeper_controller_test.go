@@ -2,12 +2,16 @@ package nodekeeper
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
@@ -31,6 +35,7 @@ var _ = Describe("NodeKeeperController", func() {
 		reconciler                      *ReconcileNodeKeeper
 		mockCtrl                        *gomock.Controller
 		mockKubeClient                  *mocks.MockClient
+		mockStatusWriter                *mocks.MockStatusWriter
 		mockConfigManagerBuilder        *configMocks.MockConfigManagerBuilder
 		mockConfigManager               *configMocks.MockConfigManager
 		mockMachineryClient             *mockMachinery.MockMachinery
@@ -48,6 +53,7 @@ var _ = Describe("NodeKeeperController", func() {
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		mockStatusWriter = mocks.NewMockStatusWriter(mockCtrl)
 		mockConfigManagerBuilder = configMocks.NewMockConfigManagerBuilder(mockCtrl)
 		mockConfigManager = configMocks.NewMockConfigManager(mockCtrl)
 		mockMachineryClient = mockMachinery.NewMockMachinery(mockCtrl)
@@ -110,6 +116,18 @@ var _ = Describe("NodeKeeperController", func() {
 				_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: testNodeName})
 				Expect(err).NotTo(HaveOccurred())
 			})
+			It("should not check node if the cluster has a hosted control plane", func() {
+				uc := *testStructs.NewUpgradeConfigBuilder().WithNamespacedName(upgradeConfigName).WithPhase(upgradev1alpha1.UpgradePhaseUpgrading).GetUpgradeConfig()
+				uc.Spec.Type = upgradev1alpha1.HCP
+				gomock.InOrder(
+					mockUpgradeConfigManagerBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUpgradeConfigManager, nil),
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), gomock.Any()).Times(0),
+					mockKubeClient.EXPECT().Get(gomock.Any(), testNodeName, gomock.Any()).Times(0),
+				)
+				_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: testNodeName})
+				Expect(err).NotTo(HaveOccurred())
+			})
 		})
 
 		Context("Drain strategy enablement", func() {
@@ -132,8 +150,11 @@ var _ = Describe("NodeKeeperController", func() {
 					mockKubeClient.EXPECT().Get(gomock.Any(), testNodeName, gomock.Any()).Times(1),
 					mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: true, AddedAt: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)}}),
 					mockMetricsBuilder.EXPECT().NewClient(gomock.Any()).Return(mockMetricsClient, nil),
+					mockMachineryClient.EXPECT().IsNodeUpgrading(gomock.Any()).Return(true),
 					mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
 					mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, config),
+					mockKubeClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()),
 				)
 				result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: testNodeName})
 				Expect(err).NotTo(HaveOccurred())
@@ -161,14 +182,16 @@ var _ = Describe("NodeKeeperController", func() {
 					mockKubeClient.EXPECT().Get(gomock.Any(), testNodeName, gomock.Any()).Times(1),
 					mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: true, AddedAt: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)}}),
 					mockMetricsBuilder.EXPECT().NewClient(gomock.Any()).Return(mockMetricsClient, nil),
+					mockMachineryClient.EXPECT().IsNodeUpgrading(gomock.Any()).Return(true),
 					mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
 					mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, config),
 					mockDrainStrategyBuilder.EXPECT().NewNodeDrainStrategy(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockDrainStrategy, nil),
 					mockDrainStrategy.EXPECT().Execute(gomock.Any(), gomock.Any()).Return([]*drain.DrainStrategyResult{}, nil),
 					mockDrainStrategy.EXPECT().HasFailed(gomock.Any(), gomock.Any()).Return(true, nil),
-					mockMachineryClient.EXPECT().IsNodeUpgrading(gomock.Any()).Return(true),
 					mockMetricsClient.EXPECT().UpdateMetricNodeDrainFailed(gomock.Any()).Times(1),
 					mockMetricsClient.EXPECT().ResetMetricNodeDrainFailed(gomock.Any()).Times(0),
+					mockKubeClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()),
 				)
 				result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: testNodeName})
 				Expect(err).NotTo(HaveOccurred())
@@ -192,5 +215,230 @@ var _ = Describe("NodeKeeperController", func() {
 				Expect(result.RequeueAfter).To(BeZero())
 			})
 		})
+
+		Context("Recording node progress", func() {
+			var (
+				uc   upgradev1alpha1.UpgradeConfig
+				node corev1.Node
+			)
+			BeforeEach(func() {
+				uc = *testStructs.NewUpgradeConfigBuilder().WithNamespacedName(upgradeConfigName).WithPhase(upgradev1alpha1.UpgradePhaseUpgrading).GetUpgradeConfig()
+				config = nodeKeeperConfig{
+					NodeDrain: drain.NodeDrain{
+						Timeout:               5,
+						ExpectedNodeDrainTime: 8,
+					},
+				}
+				node = corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: testNodeName.Name,
+						Annotations: map[string]string{
+							"machineconfiguration.openshift.io/desiredConfig": "rendered-infra-0123abcd",
+							"machineconfiguration.openshift.io/state":         "Working",
+						},
+					},
+					Status: corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{BootID: "boot-1"}},
+				}
+			})
+			It("records the drain strategies executed on a cordoned node", func() {
+				cordonTime := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+				var updated *upgradev1alpha1.UpgradeConfig
+				gomock.InOrder(
+					mockUpgradeConfigManagerBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUpgradeConfigManager, nil),
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{IsUpgrading: true}, nil),
+					mockKubeClient.EXPECT().Get(gomock.Any(), testNodeName, gomock.Any()).SetArg(2, node),
+					mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: true, AddedAt: &cordonTime}),
+					mockMetricsBuilder.EXPECT().NewClient(gomock.Any()).Return(mockMetricsClient, nil),
+					mockMachineryClient.EXPECT().IsNodeUpgrading(gomock.Any()).Return(true),
+					mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+					mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, config),
+					mockDrainStrategyBuilder.EXPECT().NewNodeDrainStrategy(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockDrainStrategy, nil),
+					mockDrainStrategy.EXPECT().Execute(gomock.Any(), gomock.Any()).Return([]*drain.DrainStrategyResult{
+						{Name: "EVICT", Message: "Pod(s) p1 have been evicted", HasExecuted: true, Count: 1},
+						{Name: "VOLUME-DETACH", Message: "Drain strategy VOLUME-DETACH is waiting for VolumeAttachment va1 held by pod(s) ns/p2", IsPending: true},
+					}, nil),
+					mockDrainStrategy.EXPECT().HasFailed(gomock.Any(), gomock.Any()).Return(false, nil),
+					mockMetricsClient.EXPECT().ResetMetricNodeDrainFailed(testNodeName.Name),
+					mockKubeClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+							updated = obj.(*upgradev1alpha1.UpgradeConfig)
+							return nil
+						}),
+				)
+				_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: testNodeName})
+				Expect(err).NotTo(HaveOccurred())
+				history := updated.Status.History.GetHistory(uc.Spec.Desired.Version)
+				Expect(history.Nodes).To(HaveLen(1))
+				status := history.Nodes[0]
+				Expect(status.Name).To(Equal(testNodeName.Name))
+				Expect(status.Pool).To(Equal("infra"))
+				Expect(status.CordonTime).To(Equal(&cordonTime))
+				Expect(status.MachineConfigDaemonState).To(Equal("Working"))
+				Expect(status.Upgrading).To(BeTrue())
+				Expect(status.BootID).To(Equal("boot-1"))
+				Expect(status.DrainFailed).To(BeFalse())
+				Expect(status.DrainStrategies).To(HaveLen(1))
+				Expect(status.DrainStrategies[0].Name).To(Equal("EVICT"))
+				Expect(status.DrainStrategies[0].Message).To(Equal("Pod(s) p1 have been evicted"))
+				Expect(status.DrainStrategies[0].Count).To(Equal(1))
+				Expect(status.DrainPending).To(Equal("Drain strategy VOLUME-DETACH is waiting for VolumeAttachment va1 held by pod(s) ns/p2"))
+			})
+			It("caps the message recorded for a drain strategy", func() {
+				cordonTime := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+				pods := make([]string, 100)
+				for i := range pods {
+					pods[i] = fmt.Sprintf("pod-%d", i)
+				}
+				message := fmt.Sprintf("Pod(s) %s have been evicted", strings.Join(pods, ","))
+				var updated *upgradev1alpha1.UpgradeConfig
+				gomock.InOrder(
+					mockUpgradeConfigManagerBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUpgradeConfigManager, nil),
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{IsUpgrading: true}, nil),
+					mockKubeClient.EXPECT().Get(gomock.Any(), testNodeName, gomock.Any()).SetArg(2, node),
+					mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: true, AddedAt: &cordonTime}),
+					mockMetricsBuilder.EXPECT().NewClient(gomock.Any()).Return(mockMetricsClient, nil),
+					mockMachineryClient.EXPECT().IsNodeUpgrading(gomock.Any()).Return(true),
+					mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+					mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, config),
+					mockDrainStrategyBuilder.EXPECT().NewNodeDrainStrategy(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockDrainStrategy, nil),
+					mockDrainStrategy.EXPECT().Execute(gomock.Any(), gomock.Any()).Return([]*drain.DrainStrategyResult{{Name: "EVICT", Message: message, HasExecuted: true, Count: len(pods)}}, nil),
+					mockDrainStrategy.EXPECT().HasFailed(gomock.Any(), gomock.Any()).Return(false, nil),
+					mockMetricsClient.EXPECT().ResetMetricNodeDrainFailed(testNodeName.Name),
+					mockKubeClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+							updated = obj.(*upgradev1alpha1.UpgradeConfig)
+							return nil
+						}),
+				)
+				_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: testNodeName})
+				Expect(err).NotTo(HaveOccurred())
+				strategy := updated.Status.History.GetHistory(uc.Spec.Desired.Version).Nodes[0].DrainStrategies[0]
+				Expect(strategy.Message).To(HaveLen(maxDrainMessageLength))
+				Expect(strategy.Message).To(HavePrefix("Pod(s) pod-0,pod-1,"))
+				Expect(strategy.Message).To(HaveSuffix("..."))
+				Expect(strategy.Count).To(Equal(100))
+			})
+			It("does not update the status when a drain strategy's result is unchanged", func() {
+				cordonTime := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+				history := uc.Status.History.GetHistory(uc.Spec.Desired.Version)
+				history.Nodes = []upgradev1alpha1.NodeUpgradeStatus{{
+					Name:                     testNodeName.Name,
+					Pool:                     "infra",
+					CordonTime:               &cordonTime,
+					MachineConfigDaemonState: "Working",
+					Upgrading:                true,
+					BootID:                   "boot-1",
+					DrainStrategies: []upgradev1alpha1.NodeDrainStrategyStatus{{
+						Name:              "EVICT",
+						LastExecutionTime: metav1.NewTime(time.Now().Add(-5 * time.Minute)),
+						Message:           "Pod(s) p1 have been evicted",
+						Count:             1,
+					}},
+				}}
+				uc.Status.History.SetHistory(*history)
+				gomock.InOrder(
+					mockUpgradeConfigManagerBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUpgradeConfigManager, nil),
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{IsUpgrading: true}, nil),
+					mockKubeClient.EXPECT().Get(gomock.Any(), testNodeName, gomock.Any()).SetArg(2, node),
+					mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: true, AddedAt: &cordonTime}),
+					mockMetricsBuilder.EXPECT().NewClient(gomock.Any()).Return(mockMetricsClient, nil),
+					mockMachineryClient.EXPECT().IsNodeUpgrading(gomock.Any()).Return(true),
+					mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+					mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, config),
+					mockDrainStrategyBuilder.EXPECT().NewNodeDrainStrategy(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockDrainStrategy, nil),
+					mockDrainStrategy.EXPECT().Execute(gomock.Any(), gomock.Any()).Return([]*drain.DrainStrategyResult{{Name: "EVICT", Message: "Pod(s) p1 have been evicted", HasExecuted: true, Count: 1}}, nil),
+					mockDrainStrategy.EXPECT().HasFailed(gomock.Any(), gomock.Any()).Return(false, nil),
+					mockMetricsClient.EXPECT().ResetMetricNodeDrainFailed(testNodeName.Name),
+					mockKubeClient.EXPECT().Status().Times(0),
+				)
+				_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: testNodeName})
+				Expect(err).NotTo(HaveOccurred())
+			})
+			It("removes the node progress of earlier upgrades", func() {
+				cordonTime := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+				uc.Status.History = append(uc.Status.History, upgradev1alpha1.UpgradeHistory{
+					Version: "4.3.0",
+					Phase:   upgradev1alpha1.UpgradePhaseUpgraded,
+					Nodes:   []upgradev1alpha1.NodeUpgradeStatus{{Name: testNodeName.Name, BootID: "boot-0"}},
+				})
+				var updated *upgradev1alpha1.UpgradeConfig
+				gomock.InOrder(
+					mockUpgradeConfigManagerBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUpgradeConfigManager, nil),
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{IsUpgrading: true}, nil),
+					mockKubeClient.EXPECT().Get(gomock.Any(), testNodeName, gomock.Any()).SetArg(2, node),
+					mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: true, AddedAt: &cordonTime}),
+					mockMetricsBuilder.EXPECT().NewClient(gomock.Any()).Return(mockMetricsClient, nil),
+					mockMachineryClient.EXPECT().IsNodeUpgrading(gomock.Any()).Return(true),
+					mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+					mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, config),
+					mockDrainStrategyBuilder.EXPECT().NewNodeDrainStrategy(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockDrainStrategy, nil),
+					mockDrainStrategy.EXPECT().Execute(gomock.Any(), gomock.Any()).Return([]*drain.DrainStrategyResult{}, nil),
+					mockDrainStrategy.EXPECT().HasFailed(gomock.Any(), gomock.Any()).Return(false, nil),
+					mockMetricsClient.EXPECT().ResetMetricNodeDrainFailed(testNodeName.Name),
+					mockKubeClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+							updated = obj.(*upgradev1alpha1.UpgradeConfig)
+							return nil
+						}),
+				)
+				_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: testNodeName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(updated.Status.History.GetHistory("4.3.0").Nodes).To(BeEmpty())
+				Expect(updated.Status.History.GetHistory(uc.Spec.Desired.Version).Nodes).To(HaveLen(1))
+			})
+			It("records when a node that was cordoned reboots and becomes ready", func() {
+				history := uc.Status.History.GetHistory(uc.Spec.Desired.Version)
+				history.Nodes = []upgradev1alpha1.NodeUpgradeStatus{{Name: testNodeName.Name, BootID: "boot-1"}}
+				uc.Status.History.SetHistory(*history)
+				readyTime := metav1.NewTime(time.Now().Add(time.Minute).Truncate(time.Second))
+				node.Status.NodeInfo.BootID = "boot-2"
+				node.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue, LastTransitionTime: readyTime}}
+				var updated *upgradev1alpha1.UpgradeConfig
+				gomock.InOrder(
+					mockUpgradeConfigManagerBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUpgradeConfigManager, nil),
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{IsUpgrading: true}, nil),
+					mockKubeClient.EXPECT().Get(gomock.Any(), testNodeName, gomock.Any()).SetArg(2, node),
+					mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: false}),
+					mockMetricsBuilder.EXPECT().NewClient(gomock.Any()).Return(mockMetricsClient, nil),
+					mockMetricsClient.EXPECT().ResetMetricNodeDrainFailed(testNodeName.Name),
+					mockMachineryClient.EXPECT().IsNodeUpgrading(gomock.Any()).Return(false),
+					mockKubeClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+							updated = obj.(*upgradev1alpha1.UpgradeConfig)
+							return nil
+						}),
+				)
+				result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: testNodeName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeZero())
+				status := updated.Status.History.GetHistory(uc.Spec.Desired.Version).Nodes[0]
+				Expect(status.RebootTime).NotTo(BeNil())
+				Expect(status.ReadyTime).To(Equal(&readyTime))
+				Expect(status.BootID).To(Equal("boot-1"))
+			})
+			It("does not record nodes that were never cordoned", func() {
+				gomock.InOrder(
+					mockUpgradeConfigManagerBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUpgradeConfigManager, nil),
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{IsUpgrading: true}, nil),
+					mockKubeClient.EXPECT().Get(gomock.Any(), testNodeName, gomock.Any()).SetArg(2, node),
+					mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: false}),
+					mockMetricsBuilder.EXPECT().NewClient(gomock.Any()).Return(mockMetricsClient, nil),
+					mockMetricsClient.EXPECT().ResetMetricNodeDrainFailed(testNodeName.Name),
+					mockKubeClient.EXPECT().Status().Times(0),
+				)
+				_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: testNodeName})
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
 	})
 })
@@ -0,0 +1,137 @@
+package nodekeeper
+
+import (
+	"strings"
+	"time"
+
+	mcoconst "github.com/openshift/machine-config-operator/pkg/daemon/constants"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/drain"
+	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
+)
+
+const (
+	// renderedConfigPrefix prefixes the name of the MachineConfig rendered for a MachineConfigPool
+	renderedConfigPrefix = "rendered-"
+	// maxDrainMessageLength caps the message recorded for a drain strategy, which can name every
+	// pod the strategy acted on
+	maxDrainMessageLength = 256
+)
+
+// getNodeStatus returns the progress of the named node in the history, adding it to the history
+// if it is not recorded and create is set
+func getNodeStatus(history *upgradev1alpha1.UpgradeHistory, name string, create bool) *upgradev1alpha1.NodeUpgradeStatus {
+	for i := range history.Nodes {
+		if history.Nodes[i].Name == name {
+			return &history.Nodes[i]
+		}
+	}
+	if !create {
+		return nil
+	}
+	history.Nodes = append(history.Nodes, upgradev1alpha1.NodeUpgradeStatus{Name: name})
+	return &history.Nodes[len(history.Nodes)-1]
+}
+
+// recordNodeProgress records the pool, cordon time and machine config daemon state of the node,
+// along with the times at which it was observed to reboot and become ready again
+func recordNodeProgress(status *upgradev1alpha1.NodeUpgradeStatus, node *corev1.Node, cordoned *machinery.IsCordonedResult, upgrading bool, now time.Time) {
+	if pool := nodePool(node); pool != "" {
+		status.Pool = pool
+	}
+	if cordoned.IsCordoned && cordoned.AddedAt != nil {
+		status.CordonTime = cordoned.AddedAt
+	}
+	status.MachineConfigDaemonState = node.Annotations[mcoconst.MachineConfigDaemonStateAnnotationKey]
+	status.Upgrading = upgrading
+
+	bootID := node.Status.NodeInfo.BootID
+	if status.BootID == "" {
+		status.BootID = bootID
+	} else if bootID != "" && bootID != status.BootID && status.RebootTime == nil {
+		status.RebootTime = &metav1.Time{Time: now}
+	}
+	if status.RebootTime != nil && status.ReadyTime == nil {
+		for _, c := range node.Status.Conditions {
+			if c.Type == corev1.NodeReady && c.Status == corev1.ConditionTrue {
+				readyTime := c.LastTransitionTime
+				if readyTime.Before(status.RebootTime) {
+					readyTime = *status.RebootTime
+				}
+				status.ReadyTime = &readyTime
+			}
+		}
+	}
+}
+
+// recordDrainResults records the drain strategies executed on the node, what the drain is waiting
+// on, and whether it failed. A strategy's execution time is only updated when its result changes,
+// so that the status is not rewritten each time a strategy is retried with the same result.
+func recordDrainResults(status *upgradev1alpha1.NodeUpgradeStatus, results []*drain.DrainStrategyResult, hasFailed bool, now time.Time) {
+	var pending []string
+	for _, r := range results {
+		if r.IsPending {
+			pending = append(pending, r.Message)
+			continue
+		}
+		var strategy *upgradev1alpha1.NodeDrainStrategyStatus
+		for i := range status.DrainStrategies {
+			if status.DrainStrategies[i].Name == r.Name {
+				strategy = &status.DrainStrategies[i]
+			}
+		}
+		if strategy == nil {
+			status.DrainStrategies = append(status.DrainStrategies, upgradev1alpha1.NodeDrainStrategyStatus{Name: r.Name})
+			strategy = &status.DrainStrategies[len(status.DrainStrategies)-1]
+		}
+		message := truncateMessage(r.Message, maxDrainMessageLength)
+		if !strategy.LastExecutionTime.IsZero() && strategy.Message == message && strategy.Count == r.Count {
+			continue
+		}
+		strategy.LastExecutionTime = metav1.Time{Time: now}
+		strategy.Message = message
+		strategy.Count = r.Count
+	}
+	status.DrainPending = truncateMessage(strings.Join(pending, "; "), maxDrainMessageLength)
+	status.DrainFailed = hasFailed
+}
+
+// truncateMessage returns the message cut to at most max characters, marking where it was cut
+func truncateMessage(message string, max int) string {
+	const ellipsis = "..."
+	if len(message) <= max {
+		return message
+	}
+	return message[:max-len(ellipsis)] + ellipsis
+}
+
+// pruneNodeStatuses removes the node progress from the history of upgrades other than the given
+// version, as it is only of use while the upgrade is in progress. Returns whether any was removed.
+func pruneNodeStatuses(histories upgradev1alpha1.UpgradeHistories, version string) bool {
+	pruned := false
+	for i := range histories {
+		if histories[i].Version != version && len(histories[i].Nodes) > 0 {
+			histories[i].Nodes = nil
+			pruned = true
+		}
+	}
+	return pruned
+}
+
+// nodePool returns the MachineConfigPool of the node, from the name of the MachineConfig rendered
+// for the pool that the machine config daemon is applying to it
+func nodePool(node *corev1.Node) string {
+	config := node.Annotations[mcoconst.DesiredMachineConfigAnnotationKey]
+	if !strings.HasPrefix(config, renderedConfigPrefix) {
+		return ""
+	}
+	config = strings.TrimPrefix(config, renderedConfigPrefix)
+	i := strings.LastIndex(config, "-")
+	if i <= 0 {
+		return ""
+	}
+	return config[:i]
+}
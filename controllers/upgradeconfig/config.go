@@ -3,10 +3,13 @@ package upgradeconfig
 import (
 	"fmt"
 	"time"
+
+	"github.com/openshift/managed-upgrade-operator/pkg/scheduler"
 )
 
 type config struct {
-	UpgradeWindow upgradeWindow `yaml:"upgradeWindow"`
+	UpgradeWindow upgradeWindow            `yaml:"upgradeWindow"`
+	FreezePeriods []scheduler.FreezePeriod `yaml:"freezePeriods"`
 }
 
 type upgradeWindow struct {
@@ -21,6 +24,9 @@ func (cfg *config) IsValid() error {
 	if cfg.UpgradeWindow.DelayTrigger < 0 {
 		return fmt.Errorf("config upgrade window delay trigger is invalid")
 	}
+	if err := scheduler.ValidateFreezePeriods(cfg.FreezePeriods); err != nil {
+		return fmt.Errorf("config freeze period is invalid: %v", err)
+	}
 	return nil
 }
 
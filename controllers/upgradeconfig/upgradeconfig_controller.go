@@ -3,7 +3,7 @@ package upgradeconfig
 import (
 	"context"
 	"fmt"
-	"regexp"
+	"strings"
 	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -18,10 +18,12 @@ import (
 	"github.com/openshift/managed-upgrade-operator/pkg/configmanager"
 	"github.com/openshift/managed-upgrade-operator/pkg/eventmanager"
 	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
 	"github.com/openshift/managed-upgrade-operator/pkg/scheduler"
 	ucmgr "github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager"
 	cub "github.com/openshift/managed-upgrade-operator/pkg/upgraders"
 	"github.com/openshift/managed-upgrade-operator/pkg/validation"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -144,6 +146,19 @@ func (r *ReconcileUpgradeConfig) Reconcile(ctx context.Context, request reconcil
 	status := history.Phase
 	reqLogger.Info("Current cluster status", "status", status)
 
+	// An upgrade can be cancelled up until the point the ClusterVersion has been patched
+	if instance.Spec.Cancel && isCancellable(status) {
+		commenced, err := cvClient.HasUpgradeCommenced(instance)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("could not tell if cluster was upgrading: %v", err)
+		}
+		if !commenced {
+			reqLogger.Info("UpgradeConfig is marked for cancellation, cancelling upgrade.")
+			return r.cancelUpgrade(upgrader, instance, reqLogger)
+		}
+		reqLogger.Info("UpgradeConfig is marked for cancellation but the upgrade has already commenced, ignoring.")
+	}
+
 	switch status {
 
 	// "New" UpgradePhase is when an upgrade is scheduled.
@@ -157,7 +172,9 @@ func (r *ReconcileUpgradeConfig) Reconcile(ctx context.Context, request reconcil
 		schedulerResult := r.Scheduler.IsReadyToUpgrade(instance, cfg.GetUpgradeWindowTimeOutDuration())
 		timeToUpgrade := schedulerResult.TimeUntilUpgrade.Minutes()
 		healthCheckDuration := instance.GetHealthCheckDuration().Minutes()
-		if time.Duration(timeToUpgrade) > time.Duration(healthCheckDuration) {
+		if instance.Spec.DryRun {
+			reqLogger.Info("Skipping pre healthcheck as the upgrade is a dry-run")
+		} else if time.Duration(timeToUpgrade) > time.Duration(healthCheckDuration) {
 			reqLogger.Info("Running Pre-Health Check for upgrade")
 			// We do not block the upgrade from going to pending state deliberately
 			// since we want to notify and give a heads up regarding pre healthcheck
@@ -171,8 +188,13 @@ func (r *ReconcileUpgradeConfig) Reconcile(ctx context.Context, request reconcil
 			reqLogger.Info("Skipping pre healthcheck")
 		}
 
+		scheduledFor := instance.Spec.UpgradeAt
+		if !schedulerResult.NextEligibleStart.IsZero() {
+			scheduledFor = schedulerResult.NextEligibleStart.UTC().Format(time.RFC3339)
+		}
 		history.Phase = upgradev1alpha1.UpgradePhasePending
 		instance.Status.History.SetHistory(*history)
+		instance.SetStatusConditions("UpgradeScheduled", fmt.Sprintf("Upgrade to version %s is scheduled for %s", instance.Spec.Desired.Version, scheduledFor))
 		err = r.Client.Status().Update(context.TODO(), instance)
 		if err != nil {
 			return reconcile.Result{}, err
@@ -201,6 +223,9 @@ func (r *ReconcileUpgradeConfig) Reconcile(ctx context.Context, request reconcil
 
 		// Validate UpgradeConfig instance
 		validatorResult, err := validator.IsValidUpgradeConfig(r.Client, instance, clusterVersion, reqLogger)
+		if instance.Spec.DryRun {
+			return r.rehearseUpgrade(upgrader, instance, validatorResult, err, reqLogger)
+		}
 		if !validatorResult.IsValid || err != nil {
 			reqLogger.Info(fmt.Sprintf("An error occurred while validating UpgradeConfig: %v", validatorResult.Message))
 			metricsClient.UpdateMetricValidationFailed(instance.Name)
@@ -214,9 +239,19 @@ func (r *ReconcileUpgradeConfig) Reconcile(ctx context.Context, request reconcil
 		}
 		reqLogger.Info("UpgradeConfig validated and confirmed for upgrade.")
 
+		if instance.Spec.Paused {
+			reqLogger.Info("UpgradeConfig is paused, the upgrade will not commence until it is resumed.")
+			return reconcile.Result{}, nil
+		}
+
 		reqLogger.Info(fmt.Sprintf("Checking if cluster can commence %s upgrade.", instance.Spec.Type))
 		schedulerResult := r.Scheduler.IsReadyToUpgrade(instance, cfg.GetUpgradeWindowTimeOutDuration())
 		if schedulerResult.IsReady {
+			freezeResult := r.Scheduler.IsFrozen(cfg.FreezePeriods, time.Now())
+			if freezeResult.IsFrozen {
+				return r.delayUpgradeForFreeze(eventClient, instance, history, freezeResult, reqLogger)
+			}
+
 			ucMgr, err := r.UcMgrBuilder.NewManager(r.Client)
 			if err != nil {
 				return reconcile.Result{}, err
@@ -242,6 +277,16 @@ func (r *ReconcileUpgradeConfig) Reconcile(ctx context.Context, request reconcil
 				return reconcile.Result{}, nil
 			}
 
+			// Record the end of any freeze period the upgrade was held for
+			if history.Conditions.IsFalseFor(upgradev1alpha1.FreezePeriodEnded) {
+				history.Conditions.SetCondition(upgradev1alpha1.UpgradeCondition{
+					Type:    upgradev1alpha1.FreezePeriodEnded,
+					Status:  corev1.ConditionTrue,
+					Reason:  "Freeze period ended",
+					Message: "The upgrade is no longer held by a freeze period",
+				})
+			}
+
 			now := time.Now()
 			history.Phase = upgradev1alpha1.UpgradePhaseUpgrading
 			history.StartTime = &metav1.Time{Time: now}
@@ -275,6 +320,9 @@ func (r *ReconcileUpgradeConfig) Reconcile(ctx context.Context, request reconcil
 
 	case upgradev1alpha1.UpgradePhaseUpgrading:
 		reqLogger.Info("Cluster detected as already upgrading.")
+		if instance.Spec.Paused {
+			reqLogger.Info("UpgradeConfig is paused, no further upgrade steps will be run until it is resumed.")
+		}
 		return r.upgradeCluster(upgrader, instance, reqLogger)
 	case upgradev1alpha1.UpgradePhaseUpgraded:
 		reqLogger.Info("Cluster is already upgraded")
@@ -283,6 +331,9 @@ func (r *ReconcileUpgradeConfig) Reconcile(ctx context.Context, request reconcil
 	case upgradev1alpha1.UpgradePhaseFailed:
 		reqLogger.Info("Cluster has failed to upgrade")
 		return reconcile.Result{}, nil
+	case upgradev1alpha1.UpgradePhaseCancelled:
+		reqLogger.Info("Cluster upgrade has been cancelled")
+		return reconcile.Result{}, nil
 	default:
 		reqLogger.Info("Unknown status")
 	}
@@ -301,6 +352,9 @@ func (r *ReconcileUpgradeConfig) upgradeCluster(upgrader cub.ClusterUpgrader, uc
 	if phase == upgradev1alpha1.UpgradePhaseUpgraded {
 		history.CompleteTime = &metav1.Time{Time: time.Now()}
 	}
+	if phase == upgradev1alpha1.UpgradePhaseFailed {
+		uc.SetStatusConditions("UpgradeFailed", fmt.Sprintf("Upgrade to version %s has failed", uc.Spec.Desired.Version), upgradev1alpha1.UpgradeConfigDegraded)
+	}
 	uc.Status.History.SetHistory(*history)
 	err = r.Client.Status().Update(context.TODO(), uc)
 	me = multierror.Append(err, me)
@@ -308,38 +362,140 @@ func (r *ReconcileUpgradeConfig) upgradeCluster(upgrader cub.ClusterUpgrader, uc
 	return reconcile.Result{RequeueAfter: 1 * time.Minute}, me.ErrorOrNil()
 }
 
-// reportUpgradeMetrics updates prometheus with statistics from the latest upgrade
-func reportUpgradeMetrics(metricsClient metrics.Metrics, name string, precedingVersion string, version string, upgradeStart time.Time, upgradeEnd time.Time) error {
-	upgradeAlerts, err := metricsClient.AlertsFromUpgrade(upgradeStart, upgradeEnd)
+// rehearseUpgrade runs the read-only checks of a dry-run upgrade, including the outcome of its
+// validation, and records them in the status as a rehearsal report. The upgrade is never started.
+func (r *ReconcileUpgradeConfig) rehearseUpgrade(upgrader cub.ClusterUpgrader, instance *upgradev1alpha1.UpgradeConfig, validatorResult validation.ValidatorResult, validationErr error, logger logr.Logger) (reconcile.Result, error) {
+	logger.Info("UpgradeConfig is a dry-run, rehearsing the upgrade without starting it.")
+
+	validationCheck := upgradev1alpha1.RehearsalCheck{
+		Name:    "Validation",
+		Passed:  validatorResult.IsValid && validatorResult.IsAvailableUpdate && validationErr == nil,
+		Message: validatorResult.Message,
+	}
+	if validationErr != nil {
+		validationCheck.Message = validationErr.Error()
+	}
+	if validationCheck.Passed && validationCheck.Message == "" {
+		validationCheck.Message = "UpgradeConfig is valid for an available update"
+	}
+
+	checks, err := upgrader.Rehearse(context.TODO(), instance, logger)
 	if err != nil {
-		return err
+		return reconcile.Result{}, err
 	}
+	checks = append([]upgradev1alpha1.RehearsalCheck{validationCheck}, checks...)
 
-	minorUpgrade, err := getMinorUpgrade(precedingVersion, version)
+	report := &upgradev1alpha1.RehearsalReport{
+		Version:       instance.Spec.Desired.Version,
+		RehearsalTime: metav1.Now(),
+		Ready:         true,
+		Checks:        checks,
+	}
+	failed := []string{}
+	for _, c := range checks {
+		if !c.Passed {
+			report.Ready = false
+			failed = append(failed, c.Name)
+		}
+	}
+	instance.Status.RehearsalReport = report
+
+	if report.Ready {
+		instance.SetStatusConditions("UpgradeRehearsed",
+			fmt.Sprintf("Rehearsal of the upgrade to version %s found nothing blocking it", instance.Spec.Desired.Version))
+	} else {
+		instance.SetStatusConditions("UpgradeRehearsed",
+			fmt.Sprintf("Rehearsal of the upgrade to version %s found it would be blocked by: %s", instance.Spec.Desired.Version, strings.Join(failed, ", ")),
+			upgradev1alpha1.UpgradeConfigBlocked)
+	}
+	err = r.Client.Status().Update(context.TODO(), instance)
 	if err != nil {
-		return fmt.Errorf("failed to figure out if it is a minor upgrade: %v", err)
+		return reconcile.Result{}, err
 	}
 
-	metricsClient.UpdateMetricUpgradeResult(name, precedingVersion, version, minorUpgrade, upgradeAlerts)
-	return nil
+	return reconcile.Result{}, nil
 }
 
-func getMinorUpgrade(precedingVersion, version string) (string, error) {
-	minorRegex, err := regexp.Compile(`[0-9]+\.([0-9]+)\..*`)
+// delayUpgradeForFreeze holds a pending upgrade while a freeze period is in effect, recording the
+// freeze in status and raising a frozen notification
+func (r *ReconcileUpgradeConfig) delayUpgradeForFreeze(eventClient eventmanager.EventManager, instance *upgradev1alpha1.UpgradeConfig, history *upgradev1alpha1.UpgradeHistory, freeze scheduler.FreezeResult, logger logr.Logger) (reconcile.Result, error) {
+	message := fmt.Sprintf("Upgrades are frozen until %s: %s", freeze.Until.UTC().Format(time.RFC3339), freeze.Reason)
+	logger.Info(message)
+
+	history.Conditions.SetCondition(upgradev1alpha1.UpgradeCondition{
+		Type:    upgradev1alpha1.FreezePeriodEnded,
+		Status:  corev1.ConditionFalse,
+		Reason:  "Freeze period in effect",
+		Message: message,
+	})
+	instance.Status.History.SetHistory(*history)
+	instance.SetStatusConditions("UpgradeFrozen", message, upgradev1alpha1.UpgradeConfigBlocked)
+	err := r.Client.Status().Update(context.TODO(), instance)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// The frozen notification describes the freeze from the status, so must follow its update.
+	// It is notified apart from delays so that a delay once the freeze ends is still notified.
+	err = eventClient.Notify(notifier.MuoStateFrozen)
 	if err != nil {
-		return "unknown", fmt.Errorf("failed to compile regex: %v", err)
+		return reconcile.Result{}, err
+	}
+
+	// If the freeze ends before the next reconcile, reconcile at that point
+	untilThaw := time.Until(freeze.Until)
+	if untilThaw > 0 && untilThaw < time.Duration(muocfg.SyncPeriodDefault) {
+		return reconcile.Result{RequeueAfter: untilThaw}, nil
+	}
+	return reconcile.Result{}, nil
+}
+
+func (r *ReconcileUpgradeConfig) cancelUpgrade(upgrader cub.ClusterUpgrader, uc *upgradev1alpha1.UpgradeConfig, logger logr.Logger) (reconcile.Result, error) {
+	me := &multierror.Error{}
+
+	phase, err := upgrader.CancelUpgrade(context.TODO(), uc, logger)
+	me = multierror.Append(err, me)
+
+	history := uc.Status.History.GetHistory(uc.Spec.Desired.Version)
+	history.Phase = phase
+	if phase == upgradev1alpha1.UpgradePhaseCancelled {
+		history.CompleteTime = &metav1.Time{Time: time.Now()}
+		uc.SetStatusConditions("UpgradeCancelled", fmt.Sprintf("Upgrade to version %s was cancelled", uc.Spec.Desired.Version))
+	}
+	uc.Status.History.SetHistory(*history)
+	err = r.Client.Status().Update(context.TODO(), uc)
+	me = multierror.Append(err, me)
+
+	if phase == upgradev1alpha1.UpgradePhaseCancelled {
+		return reconcile.Result{}, me.ErrorOrNil()
 	}
-	versionMinorRes := minorRegex.FindStringSubmatch(version)
-	precedingVersionMinorRes := minorRegex.FindStringSubmatch(precedingVersion)
-	if len(versionMinorRes) < 2 || len(precedingVersionMinorRes) < 2 {
-		return "unknown", nil
+	return reconcile.Result{RequeueAfter: 1 * time.Minute}, me.ErrorOrNil()
+}
+
+// isCancellable returns true if an upgrade in the supplied phase has not yet finished
+func isCancellable(phase upgradev1alpha1.UpgradePhase) bool {
+	switch phase {
+	case upgradev1alpha1.UpgradePhaseNew, upgradev1alpha1.UpgradePhasePending, upgradev1alpha1.UpgradePhaseUpgrading:
+		return true
+	default:
+		return false
 	}
+}
 
-	if versionMinorRes[1] != precedingVersionMinorRes[1] {
-		return "y", nil
+// reportUpgradeMetrics updates prometheus with statistics from the latest upgrade
+func reportUpgradeMetrics(metricsClient metrics.Metrics, name string, precedingVersion string, version string, upgradeStart time.Time, upgradeEnd time.Time) error {
+	upgradeAlerts, err := metricsClient.AlertsFromUpgrade(upgradeStart, upgradeEnd)
+	if err != nil {
+		return err
 	}
 
-	return "z", nil
+	minorUpgrade, err := cv.GetMinorUpgrade(precedingVersion, version)
+	if err != nil {
+		return fmt.Errorf("failed to figure out if it is a minor upgrade: %v", err)
+	}
+
+	metricsClient.UpdateMetricUpgradeResult(name, precedingVersion, version, minorUpgrade, upgradeAlerts)
+	return nil
 }
 
 // ManagedUpgradePredicate is used for managing predicates of the UpgradeConfig
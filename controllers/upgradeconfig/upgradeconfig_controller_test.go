@@ -8,6 +8,7 @@ import (
 
 	configv1 "github.com/openshift/api/config/v1"
 	"go.uber.org/mock/gomock"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -19,6 +20,7 @@ import (
 	configMocks "github.com/openshift/managed-upgrade-operator/pkg/configmanager/mocks"
 	emMocks "github.com/openshift/managed-upgrade-operator/pkg/eventmanager/mocks"
 	mockMetrics "github.com/openshift/managed-upgrade-operator/pkg/metrics/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
 	"github.com/openshift/managed-upgrade-operator/pkg/scheduler"
 	schedulerMocks "github.com/openshift/managed-upgrade-operator/pkg/scheduler/mocks"
 	ucMgrMocks "github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager/mocks"
@@ -303,11 +305,13 @@ var _ = Describe("UpgradeConfigController", func() {
 				})
 				Context("When the upgrade time is less than the healthcheck duration", func() {
 					sr := scheduler.SchedulerResult{
-						IsReady:          false,
-						IsBreached:       false,
-						TimeUntilUpgrade: 1 * time.Hour,
+						IsReady:           false,
+						IsBreached:        false,
+						TimeUntilUpgrade:  1 * time.Hour,
+						NextEligibleStart: time.Date(2024, time.January, 2, 1, 0, 0, 0, time.UTC),
 					}
 					It("Should skip prehealth check and move to pending phase", func() {
+						matcher := testStructs.NewUpgradeConfigMatcher()
 						gomock.InOrder(
 							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
 							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
@@ -317,12 +321,17 @@ var _ = Describe("UpgradeConfigController", func() {
 							mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
 							mockScheduler.EXPECT().IsReadyToUpgrade(gomock.Any(), gomock.Any()).Return(sr),
 							mockKubeClient.EXPECT().Status().Return(mockUpdater),
-							mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()),
+							mockUpdater.EXPECT().Update(gomock.Any(), matcher),
 						)
 						result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: upgradeConfigName})
 						Expect(err).NotTo(HaveOccurred())
 						Expect(upgradeConfig.Status.History.GetHistory("a version").Phase == upgradev1alpha1.UpgradePhasePending).To(BeTrue())
 						Expect(result.RequeueAfter).To(Equal(time.Minute * 1))
+						progressing := meta.FindStatusCondition(matcher.ActualUpgradeConfig.Status.Conditions, upgradev1alpha1.UpgradeConfigProgressing)
+						Expect(progressing).NotTo(BeNil())
+						Expect(progressing.Status).To(Equal(metav1.ConditionFalse))
+						Expect(progressing.Reason).To(Equal("UpgradeScheduled"))
+						Expect(progressing.Message).To(ContainSubstring("2024-01-02T01:00:00Z"))
 					})
 				})
 				Context("When a cluster upgrade client can't be built", func() {
@@ -414,6 +423,95 @@ var _ = Describe("UpgradeConfigController", func() {
 					})
 				})
 
+				Context("When the UpgradeConfig is a dry-run", func() {
+					var matcher *testStructs.UpgradeConfigMatcher
+					BeforeEach(func() {
+						upgradeConfig.Spec.DryRun = true
+						matcher = testStructs.NewUpgradeConfigMatcher()
+					})
+					expectRehearsal := func(validatorResult validation.ValidatorResult, checks []upgradev1alpha1.RehearsalCheck) {
+						gomock.InOrder(
+							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+							mockCVClientBuilder.EXPECT().New(gomock.Any()).Return(mockCVClient),
+							mockCVClient.EXPECT().GetClusterVersion().Return(testClusterVersion, nil),
+							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+							mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+							mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, cfg),
+							mockValidationBuilder.EXPECT().NewClient(mockConfigManager).Return(mockValidator, nil),
+							mockValidator.EXPECT().IsValidUpgradeConfig(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(validatorResult, nil),
+							mockClusterUpgrader.EXPECT().Rehearse(gomock.Any(), gomock.Any(), gomock.Any()).Return(checks, nil),
+							mockKubeClient.EXPECT().Status().Return(mockUpdater),
+							mockUpdater.EXPECT().Update(gomock.Any(), matcher),
+						)
+						mockMetricsClient.EXPECT().UpdateMetricValidationSucceeded(gomock.Any()).Times(0)
+						mockMetricsClient.EXPECT().UpdateMetricValidationFailed(gomock.Any()).Times(0)
+						mockScheduler.EXPECT().IsReadyToUpgrade(gomock.Any(), gomock.Any()).Times(0)
+						mockClusterUpgrader.EXPECT().UpgradeCluster(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+					}
+
+					It("reports a rehearsal that found nothing blocking the upgrade", func() {
+						expectRehearsal(validation.ValidatorResult{IsValid: true, IsAvailableUpdate: true},
+							[]upgradev1alpha1.RehearsalCheck{{Name: "CriticalAlerts", Passed: true}})
+						_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: upgradeConfigName})
+						Expect(err).NotTo(HaveOccurred())
+						report := matcher.ActualUpgradeConfig.Status.RehearsalReport
+						Expect(report).NotTo(BeNil())
+						Expect(report.Version).To(Equal(version))
+						Expect(report.Ready).To(BeTrue())
+						Expect(report.Checks).To(HaveLen(2))
+						Expect(report.Checks[0].Name).To(Equal("Validation"))
+						Expect(matcher.ActualUpgradeConfig.Status.History.GetHistory(version).Phase).To(Equal(upgradev1alpha1.UpgradePhasePending))
+						Expect(meta.IsStatusConditionTrue(matcher.ActualUpgradeConfig.Status.Conditions, upgradev1alpha1.UpgradeConfigBlocked)).To(BeFalse())
+					})
+
+					It("reports the checks that would block the upgrade", func() {
+						expectRehearsal(validation.ValidatorResult{IsValid: true, IsAvailableUpdate: true},
+							[]upgradev1alpha1.RehearsalCheck{{Name: "CriticalAlerts", Passed: false, Message: "critical alert(s) firing: KubeAPIDown"}})
+						_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: upgradeConfigName})
+						Expect(err).NotTo(HaveOccurred())
+						report := matcher.ActualUpgradeConfig.Status.RehearsalReport
+						Expect(report.Ready).To(BeFalse())
+						blocked := meta.FindStatusCondition(matcher.ActualUpgradeConfig.Status.Conditions, upgradev1alpha1.UpgradeConfigBlocked)
+						Expect(blocked.Status).To(Equal(metav1.ConditionTrue))
+						Expect(blocked.Message).To(ContainSubstring("CriticalAlerts"))
+					})
+
+					It("reports a failed validation", func() {
+						expectRehearsal(validation.ValidatorResult{IsValid: false, Message: "version is not available"}, []upgradev1alpha1.RehearsalCheck{})
+						_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: upgradeConfigName})
+						Expect(err).NotTo(HaveOccurred())
+						report := matcher.ActualUpgradeConfig.Status.RehearsalReport
+						Expect(report.Ready).To(BeFalse())
+						Expect(report.Checks[0]).To(Equal(upgradev1alpha1.RehearsalCheck{Name: "Validation", Passed: false, Message: "version is not available"}))
+					})
+				})
+
+				Context("When the UpgradeConfig is paused", func() {
+					BeforeEach(func() {
+						upgradeConfig.Spec.Paused = true
+					})
+					It("should not commence the upgrade", func() {
+						gomock.InOrder(
+							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+							mockCVClientBuilder.EXPECT().New(gomock.Any()).Return(mockCVClient),
+							mockCVClient.EXPECT().GetClusterVersion().Return(testClusterVersion, nil),
+							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+							mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+							mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, cfg),
+							mockValidationBuilder.EXPECT().NewClient(mockConfigManager).Return(mockValidator, nil),
+							mockValidator.EXPECT().IsValidUpgradeConfig(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(validation.ValidatorResult{IsValid: true, IsAvailableUpdate: true}, nil),
+							mockMetricsClient.EXPECT().UpdateMetricValidationSucceeded(gomock.Any()),
+						)
+						mockScheduler.EXPECT().IsReadyToUpgrade(gomock.Any(), gomock.Any()).Times(0)
+						mockClusterUpgrader.EXPECT().UpgradeCluster(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+						result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: upgradeConfigName})
+						Expect(err).NotTo(HaveOccurred())
+						Expect(result.RequeueAfter).To(BeZero())
+					})
+				})
+
 				Context("When the cluster is ready to upgrade", func() {
 					It("The configuration configmap must exist", func() {
 						gomock.InOrder(
@@ -442,6 +540,7 @@ var _ = Describe("UpgradeConfigController", func() {
 							mockValidator.EXPECT().IsValidUpgradeConfig(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(validation.ValidatorResult{IsValid: true, IsAvailableUpdate: true}, nil),
 							mockMetricsClient.EXPECT().UpdateMetricValidationSucceeded(gomock.Any()),
 							mockScheduler.EXPECT().IsReadyToUpgrade(gomock.Any(), gomock.Any()).Return(scheduler.SchedulerResult{IsReady: true}),
+							mockScheduler.EXPECT().IsFrozen(gomock.Any(), gomock.Any()).Return(scheduler.FreezeResult{}),
 							mockUCMgrBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUCMgr, nil),
 							mockUCMgr.EXPECT().Refresh().Return(false, nil),
 							mockKubeClient.EXPECT().Status().Return(mockUpdater),
@@ -467,6 +566,7 @@ var _ = Describe("UpgradeConfigController", func() {
 							mockValidator.EXPECT().IsValidUpgradeConfig(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(validation.ValidatorResult{IsValid: true, IsAvailableUpdate: true}, nil),
 							mockMetricsClient.EXPECT().UpdateMetricValidationSucceeded(gomock.Any()),
 							mockScheduler.EXPECT().IsReadyToUpgrade(gomock.Any(), gomock.Any()).Return(scheduler.SchedulerResult{IsReady: true}),
+							mockScheduler.EXPECT().IsFrozen(gomock.Any(), gomock.Any()).Return(scheduler.FreezeResult{}),
 							mockUCMgrBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUCMgr, nil),
 							mockUCMgr.EXPECT().Refresh().Return(true, nil),
 							mockKubeClient.EXPECT().Status().Return(mockUpdater),
@@ -490,6 +590,7 @@ var _ = Describe("UpgradeConfigController", func() {
 							mockValidator.EXPECT().IsValidUpgradeConfig(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(validation.ValidatorResult{IsValid: true, IsAvailableUpdate: true}, nil),
 							mockMetricsClient.EXPECT().UpdateMetricValidationSucceeded(gomock.Any()),
 							mockScheduler.EXPECT().IsReadyToUpgrade(gomock.Any(), gomock.Any()).Return(scheduler.SchedulerResult{IsReady: true}),
+							mockScheduler.EXPECT().IsFrozen(gomock.Any(), gomock.Any()).Return(scheduler.FreezeResult{}),
 							mockUCMgrBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUCMgr, nil),
 							mockUCMgr.EXPECT().Refresh().Return(false, nil),
 							mockKubeClient.EXPECT().Status().Return(mockUpdater),
@@ -505,6 +606,43 @@ var _ = Describe("UpgradeConfigController", func() {
 						Expect(upgradeConfig.Status.History.GetHistory("a version").Phase == upgradev1alpha1.UpgradePhaseUpgraded).To(BeTrue())
 					})
 
+					Context("When a freeze period is in effect", func() {
+						It("holds the upgrade, records the freeze and sends a delayed notification", func() {
+							fr := scheduler.FreezeResult{IsFrozen: true, Reason: "Holiday change freeze", Until: time.Now().Add(2 * time.Minute)}
+							matcher := testStructs.NewUpgradeConfigMatcher()
+							gomock.InOrder(
+								mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+								mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+								mockCVClientBuilder.EXPECT().New(gomock.Any()).Return(mockCVClient),
+								mockCVClient.EXPECT().GetClusterVersion().Return(testClusterVersion, nil),
+								mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+								mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+								mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, cfg),
+								mockValidationBuilder.EXPECT().NewClient(mockConfigManager).Return(mockValidator, nil),
+								mockValidator.EXPECT().IsValidUpgradeConfig(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(validation.ValidatorResult{IsValid: true, IsAvailableUpdate: true}, nil),
+								mockMetricsClient.EXPECT().UpdateMetricValidationSucceeded(gomock.Any()),
+								mockScheduler.EXPECT().IsReadyToUpgrade(gomock.Any(), gomock.Any()).Return(scheduler.SchedulerResult{IsReady: true}),
+								mockScheduler.EXPECT().IsFrozen(gomock.Any(), gomock.Any()).Return(fr),
+								mockKubeClient.EXPECT().Status().Return(mockUpdater),
+								mockUpdater.EXPECT().Update(gomock.Any(), matcher),
+								mockEMClient.EXPECT().Notify(notifier.MuoStateFrozen).Return(nil),
+							)
+							mockUCMgrBuilder.EXPECT().NewManager(gomock.Any()).Times(0)
+							mockClusterUpgrader.EXPECT().UpgradeCluster(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+							result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: upgradeConfigName})
+							Expect(err).NotTo(HaveOccurred())
+							Expect(result.RequeueAfter).To(BeNumerically("~", 2*time.Minute, 5*time.Second))
+							history := matcher.ActualUpgradeConfig.Status.History.GetHistory("a version")
+							Expect(history.Phase).To(Equal(upgradev1alpha1.UpgradePhasePending))
+							Expect(history.Conditions.IsFalseFor(upgradev1alpha1.FreezePeriodEnded)).To(BeTrue())
+							blocked := meta.FindStatusCondition(matcher.ActualUpgradeConfig.Status.Conditions, upgradev1alpha1.UpgradeConfigBlocked)
+							Expect(blocked).NotTo(BeNil())
+							Expect(blocked.Status).To(Equal(metav1.ConditionTrue))
+							Expect(blocked.Reason).To(Equal("UpgradeFrozen"))
+							Expect(blocked.Message).To(ContainSubstring("Holiday change freeze"))
+						})
+					})
+
 					Context("When a cluster upgrade client can be built", func() {
 						It("Invokes the upgrader", func() {
 							gomock.InOrder(
@@ -519,6 +657,7 @@ var _ = Describe("UpgradeConfigController", func() {
 								mockValidator.EXPECT().IsValidUpgradeConfig(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(validation.ValidatorResult{IsValid: true, IsAvailableUpdate: true}, nil),
 								mockMetricsClient.EXPECT().UpdateMetricValidationSucceeded(gomock.Any()),
 								mockScheduler.EXPECT().IsReadyToUpgrade(gomock.Any(), gomock.Any()).Return(scheduler.SchedulerResult{IsReady: true}),
+								mockScheduler.EXPECT().IsFrozen(gomock.Any(), gomock.Any()).Return(scheduler.FreezeResult{}),
 								mockUCMgrBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUCMgr, nil),
 								mockUCMgr.EXPECT().Refresh().Return(false, nil),
 								mockKubeClient.EXPECT().Status().Return(mockUpdater),
@@ -549,6 +688,7 @@ var _ = Describe("UpgradeConfigController", func() {
 								mockValidator.EXPECT().IsValidUpgradeConfig(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(validation.ValidatorResult{IsValid: true, IsAvailableUpdate: true}, nil),
 								mockMetricsClient.EXPECT().UpdateMetricValidationSucceeded(gomock.Any()),
 								mockScheduler.EXPECT().IsReadyToUpgrade(gomock.Any(), gomock.Any()).Return(scheduler.SchedulerResult{IsReady: true}),
+								mockScheduler.EXPECT().IsFrozen(gomock.Any(), gomock.Any()).Return(scheduler.FreezeResult{}),
 								mockUCMgrBuilder.EXPECT().NewManager(gomock.Any()).Return(mockUCMgr, nil),
 								mockUCMgr.EXPECT().Refresh().Return(false, nil),
 								mockKubeClient.EXPECT().Status().Return(mockUpdater),
@@ -632,6 +772,104 @@ var _ = Describe("UpgradeConfigController", func() {
 				})
 			})
 
+			Context("When the UpgradeConfig is marked for cancellation", func() {
+				BeforeEach(func() {
+					upgradeConfig.Spec.Cancel = true
+					upgradeConfig.Status.History[0].Phase = upgradev1alpha1.UpgradePhaseUpgrading
+				})
+
+				Context("When the upgrade has not commenced", func() {
+					It("cancels the upgrade", func() {
+						matcher := testStructs.NewUpgradeConfigMatcher()
+						gomock.InOrder(
+							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+							mockCVClientBuilder.EXPECT().New(gomock.Any()).Return(mockCVClient),
+							mockCVClient.EXPECT().GetClusterVersion().Return(testClusterVersion, nil),
+							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+							mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+							mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
+							mockClusterUpgrader.EXPECT().CancelUpgrade(gomock.Any(), gomock.Any(), gomock.Any()).Return(upgradev1alpha1.UpgradePhaseCancelled, nil),
+							mockKubeClient.EXPECT().Status().Return(mockUpdater),
+							mockUpdater.EXPECT().Update(gomock.Any(), matcher),
+						)
+						mockClusterUpgrader.EXPECT().UpgradeCluster(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+						result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: upgradeConfigName})
+						Expect(err).NotTo(HaveOccurred())
+						Expect(result.Requeue).To(BeFalse())
+						Expect(result.RequeueAfter).To(BeZero())
+						history := matcher.ActualUpgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
+						Expect(history.Phase).To(Equal(upgradev1alpha1.UpgradePhaseCancelled))
+						Expect(history.CompleteTime).NotTo(BeNil())
+						Expect(meta.FindStatusCondition(matcher.ActualUpgradeConfig.Status.Conditions, upgradev1alpha1.UpgradeConfigReady).Reason).To(Equal("UpgradeCancelled"))
+					})
+				})
+
+				Context("When the cancellation clean-up is still in progress", func() {
+					It("requeues the cancellation", func() {
+						gomock.InOrder(
+							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+							mockCVClientBuilder.EXPECT().New(gomock.Any()).Return(mockCVClient),
+							mockCVClient.EXPECT().GetClusterVersion().Return(testClusterVersion, nil),
+							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+							mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+							mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(false, nil),
+							mockClusterUpgrader.EXPECT().CancelUpgrade(gomock.Any(), gomock.Any(), gomock.Any()).Return(upgradev1alpha1.UpgradePhaseUpgrading, nil),
+							mockKubeClient.EXPECT().Status().Return(mockUpdater),
+							mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()),
+						)
+						result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: upgradeConfigName})
+						Expect(err).NotTo(HaveOccurred())
+						Expect(result.Requeue).To(BeFalse())
+						Expect(result.RequeueAfter).To(Equal(upgradingReconcileTime))
+					})
+				})
+
+				Context("When the upgrade has already commenced", func() {
+					It("proceeds with upgrading the cluster", func() {
+						gomock.InOrder(
+							mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+							mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+							mockCVClientBuilder.EXPECT().New(gomock.Any()).Return(mockCVClient),
+							mockCVClient.EXPECT().GetClusterVersion().Return(testClusterVersion, nil),
+							mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+							mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+							mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(true, nil),
+							mockClusterUpgrader.EXPECT().UpgradeCluster(gomock.Any(), gomock.Any(), gomock.Any()).Return(upgradev1alpha1.UpgradePhaseUpgrading, nil),
+							mockKubeClient.EXPECT().Status().Return(mockUpdater),
+							mockUpdater.EXPECT().Update(gomock.Any(), gomock.Any()),
+						)
+						mockClusterUpgrader.EXPECT().CancelUpgrade(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+						result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: upgradeConfigName})
+						Expect(err).NotTo(HaveOccurred())
+						Expect(result.Requeue).To(BeFalse())
+						Expect(result.RequeueAfter).To(Equal(upgradingReconcileTime))
+					})
+				})
+			})
+
+			Context("When the upgrade phase is Cancelled", func() {
+				BeforeEach(func() {
+					upgradeConfig.Spec.Cancel = true
+					upgradeConfig.Status.History[0].Phase = upgradev1alpha1.UpgradePhaseCancelled
+				})
+				It("does nothing", func() {
+					gomock.InOrder(
+						mockEMBuilder.EXPECT().NewManager(gomock.Any()).Return(mockEMClient, nil),
+						mockKubeClient.EXPECT().Get(gomock.Any(), upgradeConfigName, gomock.Any()).SetArg(2, *upgradeConfig),
+						mockCVClientBuilder.EXPECT().New(gomock.Any()).Return(mockCVClient),
+						mockCVClient.EXPECT().GetClusterVersion().Return(testClusterVersion, nil),
+						mockConfigManagerBuilder.EXPECT().New(gomock.Any(), gomock.Any()).Return(mockConfigManager),
+						mockClusterUpgraderBuilder.EXPECT().NewClient(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), upgradeConfig.Spec.Type).Return(mockClusterUpgrader, nil),
+					)
+					result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: upgradeConfigName})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.Requeue).To(BeFalse())
+					Expect(result.RequeueAfter).To(BeZero())
+				})
+			})
+
 			Context("When the upgrade phase is Upgraded", func() {
 				BeforeEach(func() {
 					upgradeConfig.Status.History[0].Phase = upgradev1alpha1.UpgradePhaseUpgraded
@@ -22,6 +22,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"time"
@@ -72,11 +73,13 @@ import (
 	"github.com/openshift/managed-upgrade-operator/pkg/validation"
 	"github.com/openshift/managed-upgrade-operator/util"
 	"github.com/openshift/managed-upgrade-operator/version"
+	ucwebhook "github.com/openshift/managed-upgrade-operator/webhooks/upgradeconfig"
 
 	opmetrics "github.com/openshift/operator-custom-metrics/pkg/metrics"
 
 	"github.com/operator-framework/operator-lib/leader"
 
+	apiserverv1 "github.com/openshift/api/apiserver/v1"
 	configv1 "github.com/openshift/api/config/v1"
 	machineapi "github.com/openshift/api/machine/v1beta1"
 
@@ -94,6 +97,8 @@ var (
 	customMetricsPath       = "/metrics"
 	scheme                  = apiruntime.NewScheme()
 	setupLog                = ctrl.Log.WithName("setup")
+	// webhookCertDir is where OLM mounts the webhook serving certificates
+	webhookCertDir = filepath.Join(os.TempDir(), "k8s-webhook-server", "serving-certs")
 )
 var log = logf.Log.WithName("cmd")
 
@@ -105,6 +110,7 @@ func init() {
 	utilruntime.Must(routev1.Install(scheme))
 	utilruntime.Must(configv1.Install(scheme))
 	utilruntime.Must(machineapi.Install(scheme))
+	utilruntime.Must(apiserverv1.Install(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -115,6 +121,24 @@ func printVersion() {
 	log.Info(fmt.Sprintf("Version of operator-sdk: v%v", version.SDKVersion))
 }
 
+// webhooksEnabled returns whether the operator's admission webhooks should be served.
+// ENABLE_WEBHOOKS forces the choice when set. Otherwise the webhooks are only served when
+// the serving certificate provided by OLM is present, so that deploying the operator
+// outside of OLM does not fail to start the manager.
+func webhooksEnabled() bool {
+	switch os.Getenv("ENABLE_WEBHOOKS") {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if _, err := os.Stat(filepath.Join(webhookCertDir, "tls.crt")); err != nil {
+		setupLog.Info("Webhook serving certificate not found, not serving the admission webhooks", "certDir", webhookCertDir)
+		return false
+	}
+	return true
+}
+
 func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
@@ -231,6 +255,14 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Add UpgradeConfig validating webhook to the manager when its serving certificates are available
+	if webhooksEnabled() {
+		if err = (&ucwebhook.UpgradeConfigValidator{}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "UpgradeConfig")
+			os.Exit(1)
+		}
+	}
+
 	ctx := context.TODO()
 
 	// Get a config to talk to the apiserver
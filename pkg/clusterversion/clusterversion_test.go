@@ -471,3 +471,15 @@ var _ = Describe("ClusterVersion client and utils", func() {
 	})
 
 })
+
+var _ = Describe("Minor upgrades", func() {
+	It("classifies an upgrade to a new minor version as y-stream", func() {
+		Expect(GetMinorUpgrade("4.13.10", "4.14.2")).To(Equal("y"))
+	})
+	It("classifies an upgrade within a minor version as z-stream", func() {
+		Expect(GetMinorUpgrade("4.14.1", "4.14.2")).To(Equal("z"))
+	})
+	It("can't classify versions it can't parse", func() {
+		Expect(GetMinorUpgrade("4.14.1", "fakeVersion")).To(Equal("unknown"))
+	})
+})
@@ -1,7 +1,11 @@
 package drain
 
 import (
+	"fmt"
+	"strconv"
 	"time"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
 )
 
 // NodeDrain holds timeout and expected drain time fields required for NodeDrain execution
@@ -10,6 +14,91 @@ type NodeDrain struct {
 	Timeout                  int      `yaml:"timeOut"`
 	ExpectedNodeDrainTime    int      `yaml:"expectedNodeDrainTime" default:"8"`
 	IgnoredNamespacePatterns []string `yaml:"ignoredNamespacePatterns"`
+	// The chain of timed drain strategies, replacing the default chain when set
+	Strategies []StrategyConfig `yaml:"strategies"`
+	// Waits for the volumes of the drained pods to detach from the node
+	VolumeDetach VolumeDetach `yaml:"volumeDetach"`
+}
+
+// VolumeDetach configures the drain stage that waits for the ReadWriteOnce volumes of the drained
+// pods to detach from the node, which is disabled unless a timeout is set
+type VolumeDetach struct {
+	// Minutes after the node is cordoned after which the stuck VolumeAttachments are force-cleaned
+	TimeOut int `yaml:"timeOut"`
+	// CSI drivers whose stuck VolumeAttachments are force-cleaned
+	ForceDetachDrivers []string `yaml:"forceDetachDrivers"`
+}
+
+// GetTimeOutDuration returns the timeOut field from the VolumeDetach object
+func (vd *VolumeDetach) GetTimeOutDuration() time.Duration {
+	return time.Duration(vd.TimeOut) * time.Minute
+}
+
+// IsEnabled returns whether the volume detach drain stage is enabled
+func (vd *VolumeDetach) IsEnabled() bool {
+	return vd.TimeOut > 0
+}
+
+// StrategyAction is what a drain strategy does to the pods it applies to
+type StrategyAction string
+
+const (
+	// EvictAction evicts the pods through the Eviction API, respecting their PodDisruptionBudgets
+	EvictAction StrategyAction = "Evict"
+	// DeleteAction deletes the pods directly
+	DeleteAction StrategyAction = "Delete"
+	// RemoveFinalizersAction removes the finalizers of the pods
+	RemoveFinalizersAction StrategyAction = "RemoveFinalizers"
+	// DeleteStuckTerminatingAction force deletes the pods that are stuck terminating
+	DeleteStuckTerminatingAction StrategyAction = "DeleteStuckTerminating"
+)
+
+// PodFilter restricts the pods that a drain strategy applies to
+type PodFilter string
+
+const (
+	// PDBPodFilter matches pods protected by a PodDisruptionBudget
+	PDBPodFilter PodFilter = "PDB"
+	// NonPDBPodFilter matches pods not protected by a PodDisruptionBudget
+	NonPDBPodFilter PodFilter = "NonPDB"
+	// DeleteImmediatelyPodFilter matches pods with the DeleteImmediately drain policy
+	DeleteImmediatelyPodFilter PodFilter = "DeleteImmediately"
+)
+
+const (
+	// NodeDrainTimeoutWait executes a drain strategy once the nodeDrain timeOut has elapsed
+	NodeDrainTimeoutWait = "NodeDrainTimeout"
+	// PDBForceDrainTimeoutWait executes a drain strategy once the expected node drain time and the
+	// PDBForceDrainTimeout of the UpgradeConfig have elapsed. Pods annotated with a drain timeout
+	// use it in place of the PDBForceDrainTimeout.
+	PDBForceDrainTimeoutWait = "PDBForceDrainTimeout"
+)
+
+// StrategyConfig declares a timed drain strategy of the drain strategy chain
+type StrategyConfig struct {
+	// Name of the strategy, reported when it is executed
+	Name string `yaml:"name"`
+	// What the strategy does to the pods it applies to
+	Action StrategyAction `yaml:"action"`
+	// How long after the node is cordoned the strategy is executed: NodeDrainTimeout,
+	// PDBForceDrainTimeout or a number of minutes
+	Wait string `yaml:"wait"`
+	// Restricts the pods the strategy applies to, which are otherwise all pods on the node
+	// that are not part of a DaemonSet or in an ignored namespace
+	Pods []PodFilter `yaml:"pods"`
+}
+
+// defaultStrategies is the drain strategy chain used when none is configured. Pods are evicted
+// first, respecting their PodDisruptionBudgets, and are only deleted directly once the
+// PodDisruptionBudget force drain timeout has elapsed.
+var defaultStrategies = []StrategyConfig{
+	{Name: podEvictionName, Action: EvictAction, Wait: NodeDrainTimeoutWait},
+	{Name: immediatePodDeleteName, Action: DeleteAction, Wait: "0", Pods: []PodFilter{DeleteImmediatelyPodFilter}},
+	{Name: defaultPodFinalizerRemovalName, Action: RemoveFinalizersAction, Wait: NodeDrainTimeoutWait, Pods: []PodFilter{NonPDBPodFilter}},
+	{Name: defaultPodDeleteName, Action: DeleteAction, Wait: PDBForceDrainTimeoutWait, Pods: []PodFilter{NonPDBPodFilter}},
+	{Name: stuckTerminatingPodName, Action: DeleteStuckTerminatingAction, Wait: NodeDrainTimeoutWait, Pods: []PodFilter{NonPDBPodFilter}},
+	{Name: pdbPodDeleteName, Action: DeleteAction, Wait: PDBForceDrainTimeoutWait, Pods: []PodFilter{PDBPodFilter}},
+	{Name: pdbPodFinalizerRemovalName, Action: RemoveFinalizersAction, Wait: PDBForceDrainTimeoutWait, Pods: []PodFilter{PDBPodFilter}},
 }
 
 // GetTimeOutDuration returns the timout field from the NodeDrain object
@@ -21,3 +110,88 @@ func (nd *NodeDrain) GetTimeOutDuration() time.Duration {
 func (nd *NodeDrain) GetExpectedDrainDuration() time.Duration {
 	return time.Duration(nd.ExpectedNodeDrainTime) * time.Minute
 }
+
+// GetStrategies returns the configured drain strategy chain, or the default chain if none is configured
+func (nd *NodeDrain) GetStrategies() []StrategyConfig {
+	if len(nd.Strategies) == 0 {
+		return defaultStrategies
+	}
+	return nd.Strategies
+}
+
+// IsValid returns an error if the configured drain strategy chain or volume detach stage is invalid
+func (nd *NodeDrain) IsValid() error {
+	names := map[string]bool{}
+	for _, s := range nd.Strategies {
+		if s.Name == "" {
+			return fmt.Errorf("config nodeDrain strategy name is missing")
+		}
+		if names[s.Name] {
+			return fmt.Errorf("config nodeDrain strategy %q is declared more than once", s.Name)
+		}
+		names[s.Name] = true
+		if err := s.IsValid(); err != nil {
+			return err
+		}
+	}
+	if nd.VolumeDetach.IsEnabled() && names[volumeDetachName] {
+		return fmt.Errorf("config nodeDrain strategy %q is reserved for the volume detach stage", volumeDetachName)
+	}
+	if nd.VolumeDetach.TimeOut < 0 {
+		return fmt.Errorf("config nodeDrain volumeDetach timeOut is invalid")
+	}
+	for _, driver := range nd.VolumeDetach.ForceDetachDrivers {
+		if driver == "" {
+			return fmt.Errorf("config nodeDrain volumeDetach forceDetachDrivers has an empty driver")
+		}
+	}
+	return nil
+}
+
+// IsValid returns an error if the drain strategy is invalid
+func (s StrategyConfig) IsValid() error {
+	switch s.Action {
+	case EvictAction, DeleteAction, RemoveFinalizersAction, DeleteStuckTerminatingAction:
+	default:
+		return fmt.Errorf("config nodeDrain strategy %q action %q is invalid", s.Name, s.Action)
+	}
+	switch s.Wait {
+	case NodeDrainTimeoutWait, PDBForceDrainTimeoutWait:
+	default:
+		minutes, err := strconv.Atoi(s.Wait)
+		if err != nil || minutes < 0 {
+			return fmt.Errorf("config nodeDrain strategy %q wait %q is invalid", s.Name, s.Wait)
+		}
+	}
+	filters := map[PodFilter]bool{}
+	for _, f := range s.Pods {
+		switch f {
+		case PDBPodFilter, NonPDBPodFilter, DeleteImmediatelyPodFilter:
+			filters[f] = true
+		default:
+			return fmt.Errorf("config nodeDrain strategy %q pod filter %q is invalid", s.Name, f)
+		}
+	}
+	if filters[PDBPodFilter] && filters[NonPDBPodFilter] {
+		return fmt.Errorf("config nodeDrain strategy %q pod filters %q and %q exclude each other", s.Name, PDBPodFilter, NonPDBPodFilter)
+	}
+	return nil
+}
+
+// waitDuration returns how long after the node is cordoned the strategy is executed
+func (s StrategyConfig) waitDuration(nd *NodeDrain, uc *upgradev1alpha1.UpgradeConfig) time.Duration {
+	switch s.Wait {
+	case NodeDrainTimeoutWait:
+		return nd.GetTimeOutDuration()
+	case PDBForceDrainTimeoutWait:
+		return uc.GetPDBDrainTimeoutDuration() + nd.GetExpectedDrainDuration()
+	}
+	minutes, _ := strconv.Atoi(s.Wait)
+	return time.Duration(minutes) * time.Minute
+}
+
+// forcesPodRemoval returns whether the strategy removes pods from the node without respecting
+// their PodDisruptionBudgets
+func (s StrategyConfig) forcesPodRemoval() bool {
+	return s.Action != EvictAction
+}
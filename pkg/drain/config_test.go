@@ -0,0 +1,115 @@
+package drain
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Node drain config", func() {
+
+	var cfg *NodeDrain
+
+	BeforeEach(func() {
+		cfg = &NodeDrain{Timeout: 45, ExpectedNodeDrainTime: 8}
+	})
+
+	Context("When no drain strategies are configured", func() {
+		It("uses the default drain strategy chain", func() {
+			Expect(cfg.IsValid()).To(Succeed())
+			Expect(cfg.GetStrategies()).To(Equal(defaultStrategies))
+		})
+		It("has a valid default drain strategy chain", func() {
+			cfg.Strategies = defaultStrategies
+			Expect(cfg.IsValid()).To(Succeed())
+		})
+	})
+
+	Context("When drain strategies are configured", func() {
+		BeforeEach(func() {
+			cfg.Strategies = []StrategyConfig{
+				{Name: "EVICT", Action: EvictAction, Wait: NodeDrainTimeoutWait},
+				{Name: "STUCK", Action: DeleteStuckTerminatingAction, Wait: "15"},
+				{Name: "DELETE", Action: DeleteAction, Wait: PDBForceDrainTimeoutWait, Pods: []PodFilter{PDBPodFilter}},
+			}
+		})
+		It("accepts a valid chain", func() {
+			Expect(cfg.IsValid()).To(Succeed())
+		})
+		It("rejects a strategy without a name", func() {
+			cfg.Strategies[1].Name = ""
+			Expect(cfg.IsValid()).To(MatchError(ContainSubstring("name is missing")))
+		})
+		It("rejects a strategy declared more than once", func() {
+			cfg.Strategies[1].Name = "EVICT"
+			Expect(cfg.IsValid()).To(MatchError(ContainSubstring("more than once")))
+		})
+		It("rejects an unknown action", func() {
+			cfg.Strategies[1].Action = "Drain"
+			Expect(cfg.IsValid()).To(MatchError(ContainSubstring("action")))
+		})
+		It("rejects an invalid wait", func() {
+			cfg.Strategies[1].Wait = "-5"
+			Expect(cfg.IsValid()).To(MatchError(ContainSubstring("wait")))
+		})
+		It("rejects an unknown pod filter", func() {
+			cfg.Strategies[2].Pods = []PodFilter{"Stateful"}
+			Expect(cfg.IsValid()).To(MatchError(ContainSubstring("pod filter")))
+		})
+		It("rejects pod filters that exclude each other", func() {
+			cfg.Strategies[2].Pods = []PodFilter{PDBPodFilter, NonPDBPodFilter}
+			Expect(cfg.IsValid()).To(MatchError(ContainSubstring("exclude each other")))
+		})
+		It("rejects a negative volume detach timeout", func() {
+			cfg.VolumeDetach.TimeOut = -1
+			Expect(cfg.IsValid()).To(MatchError(ContainSubstring("volumeDetach timeOut")))
+		})
+		It("rejects a strategy named after the volume detach stage", func() {
+			cfg.VolumeDetach.TimeOut = 90
+			cfg.Strategies[1].Name = volumeDetachName
+			Expect(cfg.IsValid()).To(MatchError(ContainSubstring("reserved")))
+		})
+		It("appends the volume detach stage when it is enabled", func() {
+			cfg.VolumeDetach = VolumeDetach{TimeOut: 90, ForceDetachDrivers: []string{"ebs.csi.aws.com"}}
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			c := fake.NewClientBuilder().WithScheme(scheme).Build()
+			uc := &upgradev1alpha1.UpgradeConfig{Spec: upgradev1alpha1.UpgradeConfigSpec{PDBForceDrainTimeout: 60}}
+
+			ds, err := NewBuilder().NewNodeDrainStrategy(c, logf.Log.WithName("drain config test logger"), uc, cfg)
+			Expect(err).NotTo(HaveOccurred())
+			timedStrategies := ds.(*osdDrainStrategy).timedDrainStrategies
+			Expect(timedStrategies).To(HaveLen(4))
+			Expect(timedStrategies[3].GetName()).To(Equal(volumeDetachName))
+			Expect(timedStrategies[3].GetWaitDuration()).To(Equal(90 * time.Minute))
+			Expect(timedStrategies[3].GetStrategy()).To(Equal(&volumeDetachStrategy{client: c, forceDetachDrivers: []string{"ebs.csi.aws.com"}}))
+		})
+		It("builds the configured drain strategy chain", func() {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			c := fake.NewClientBuilder().WithScheme(scheme).Build()
+			uc := &upgradev1alpha1.UpgradeConfig{Spec: upgradev1alpha1.UpgradeConfigSpec{PDBForceDrainTimeout: 60}}
+
+			ds, err := NewBuilder().NewNodeDrainStrategy(c, logf.Log.WithName("drain config test logger"), uc, cfg)
+			Expect(err).NotTo(HaveOccurred())
+			timedStrategies := ds.(*osdDrainStrategy).timedDrainStrategies
+			Expect(timedStrategies).To(HaveLen(3))
+			Expect(timedStrategies[0].GetName()).To(Equal("EVICT"))
+			Expect(timedStrategies[0].GetWaitDuration()).To(Equal(45 * time.Minute))
+			Expect(timedStrategies[0].GetStrategy()).To(BeAssignableToTypeOf(&podEvictionStrategy{}))
+			Expect(timedStrategies[1].GetWaitDuration()).To(Equal(15 * time.Minute))
+			Expect(timedStrategies[1].GetStrategy()).To(BeAssignableToTypeOf(&stuckTerminatingStrategy{}))
+			Expect(timedStrategies[2].GetWaitDuration()).To(Equal(68 * time.Minute))
+			Expect(timedStrategies[2].GetStrategy()).To(BeAssignableToTypeOf(&podDeletionStrategy{}))
+			Expect(timedStrategies[2].GetDescription()).To(Equal("Pod deletion of [PDB] pods"))
+		})
+	})
+})
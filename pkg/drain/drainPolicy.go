@@ -0,0 +1,111 @@
+package drain
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/managed-upgrade-operator/pkg/pod"
+)
+
+const (
+	// DrainPolicyAnnotation sets, on a pod or its namespace, how the pod is treated when its node is drained
+	DrainPolicyAnnotation = "upgrade.managed.openshift.io/drain-policy"
+	// DrainGracePeriodAnnotation sets, on a pod or its namespace, the grace period in seconds with which
+	// the pod is deleted when its node is drained
+	DrainGracePeriodAnnotation = "upgrade.managed.openshift.io/drain-grace-period"
+	// DrainPDBTimeoutAnnotation sets, on a pod or its namespace, the minutes after which the pod is
+	// deleted, in place of the PodDisruptionBudget force drain timeout of the UpgradeConfig
+	DrainPDBTimeoutAnnotation = "upgrade.managed.openshift.io/drain-pdb-timeout"
+
+	// DrainPolicyNeverForceDelete pods are only evicted, and are never deleted or have their finalizers removed
+	DrainPolicyNeverForceDelete = "NeverForceDelete"
+	// DrainPolicyDeleteImmediately pods are stateless, and are deleted as soon as the drain starts
+	DrainPolicyDeleteImmediately = "DeleteImmediately"
+)
+
+// drainPolicies resolves the drain policy of pods from the annotations of the pods and their
+// namespaces. The annotation of a pod takes precedence over that of its namespace.
+type drainPolicies struct {
+	// Annotations of the namespaces, by namespace name
+	namespaceAnnotations map[string]map[string]string
+}
+
+func newDrainPolicies(c client.Client) (drainPolicies, error) {
+	nsList := &corev1.NamespaceList{}
+	err := c.List(context.TODO(), nsList)
+	if err != nil {
+		return drainPolicies{}, err
+	}
+	annotations := map[string]map[string]string{}
+	for _, ns := range nsList.Items {
+		if len(ns.Annotations) > 0 {
+			annotations[ns.Name] = ns.Annotations
+		}
+	}
+	return drainPolicies{namespaceAnnotations: annotations}, nil
+}
+
+func (dp drainPolicies) annotation(p corev1.Pod, key string) string {
+	if value, ok := p.Annotations[key]; ok {
+		return value
+	}
+	return dp.namespaceAnnotations[p.Namespace][key]
+}
+
+// gracePeriod returns the grace period in seconds with which the pod is deleted. Pods without a
+// valid grace period annotation are deleted without a grace period.
+func (dp drainPolicies) gracePeriod(p corev1.Pod) int64 {
+	gp, err := strconv.ParseInt(dp.annotation(p, DrainGracePeriodAnnotation), 10, 64)
+	if err != nil || gp < 0 {
+		return 0
+	}
+	return gp
+}
+
+// pdbTimeout returns the timeout after which the pod is deleted, if the pod has a valid timeout annotation
+func (dp drainPolicies) pdbTimeout(p corev1.Pod) (time.Duration, bool) {
+	minutes, err := strconv.Atoi(dp.annotation(p, DrainPDBTimeoutAnnotation))
+	if err != nil || minutes < 0 {
+		return 0, false
+	}
+	return time.Duration(minutes) * time.Minute, true
+}
+
+// pdbTimeouts returns the distinct timeouts, in ascending order, that the pods are annotated with
+func (dp drainPolicies) pdbTimeouts(pl *corev1.PodList) []time.Duration {
+	seen := map[time.Duration]bool{}
+	var timeouts []time.Duration
+	for _, p := range pl.Items {
+		if timeout, ok := dp.pdbTimeout(p); ok && !seen[timeout] {
+			seen[timeout] = true
+			timeouts = append(timeouts, timeout)
+		}
+	}
+	sort.Slice(timeouts, func(i, j int) bool { return timeouts[i] < timeouts[j] })
+	return timeouts
+}
+
+func (dp drainPolicies) isForceDeletable(p corev1.Pod) bool {
+	return dp.annotation(p, DrainPolicyAnnotation) != DrainPolicyNeverForceDelete
+}
+
+func (dp drainPolicies) isDeletedImmediately(p corev1.Pod) bool {
+	return dp.annotation(p, DrainPolicyAnnotation) == DrainPolicyDeleteImmediately
+}
+
+func (dp drainPolicies) hasDefaultPDBTimeout(p corev1.Pod) bool {
+	_, ok := dp.pdbTimeout(p)
+	return !ok
+}
+
+func (dp drainPolicies) hasPDBTimeout(timeout time.Duration) pod.PodPredicate {
+	return func(p corev1.Pod) bool {
+		t, ok := dp.pdbTimeout(p)
+		return ok && t == timeout
+	}
+}
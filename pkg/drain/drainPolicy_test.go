@@ -0,0 +1,118 @@
+package drain
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Drain policies", func() {
+
+	var (
+		policies drainPolicies
+		p        corev1.Pod
+	)
+
+	BeforeEach(func() {
+		policies = drainPolicies{namespaceAnnotations: map[string]map[string]string{
+			"stateless": {
+				DrainPolicyAnnotation:      DrainPolicyDeleteImmediately,
+				DrainGracePeriodAnnotation: "30",
+				DrainPDBTimeoutAnnotation:  "120",
+			},
+		}}
+		p = corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "test-namespace"}}
+	})
+
+	Context("When neither the pod nor its namespace is annotated", func() {
+		It("applies the default drain policy", func() {
+			Expect(policies.isForceDeletable(p)).To(BeTrue())
+			Expect(policies.isDeletedImmediately(p)).To(BeFalse())
+			Expect(policies.gracePeriod(p)).To(Equal(int64(0)))
+			Expect(policies.hasDefaultPDBTimeout(p)).To(BeTrue())
+		})
+	})
+
+	Context("When the namespace of the pod is annotated", func() {
+		BeforeEach(func() {
+			p.Namespace = "stateless"
+		})
+		It("applies the drain policy of the namespace", func() {
+			Expect(policies.isDeletedImmediately(p)).To(BeTrue())
+			Expect(policies.gracePeriod(p)).To(Equal(int64(30)))
+			Expect(policies.hasDefaultPDBTimeout(p)).To(BeFalse())
+			Expect(policies.hasPDBTimeout(120 * time.Minute)(p)).To(BeTrue())
+		})
+		It("applies the annotations of the pod over those of its namespace", func() {
+			p.Annotations = map[string]string{
+				DrainPolicyAnnotation:      DrainPolicyNeverForceDelete,
+				DrainGracePeriodAnnotation: "5",
+			}
+			Expect(policies.isDeletedImmediately(p)).To(BeFalse())
+			Expect(policies.isForceDeletable(p)).To(BeFalse())
+			Expect(policies.gracePeriod(p)).To(Equal(int64(5)))
+			Expect(policies.hasPDBTimeout(120 * time.Minute)(p)).To(BeTrue())
+		})
+	})
+
+	Context("When the pod is annotated with invalid values", func() {
+		It("ignores them", func() {
+			p.Annotations = map[string]string{
+				DrainGracePeriodAnnotation: "-1",
+				DrainPDBTimeoutAnnotation:  "2h",
+			}
+			Expect(policies.gracePeriod(p)).To(Equal(int64(0)))
+			Expect(policies.hasDefaultPDBTimeout(p)).To(BeTrue())
+		})
+	})
+
+	Context("When pods are annotated with drain timeouts", func() {
+		It("returns the distinct timeouts in ascending order", func() {
+			annotated := func(timeout string) corev1.Pod {
+				return corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{DrainPDBTimeoutAnnotation: timeout}}}
+			}
+			pl := &corev1.PodList{Items: []corev1.Pod{annotated("90"), p, annotated("30"), annotated("90")}}
+			Expect(policies.pdbTimeouts(pl)).To(Equal([]time.Duration{30 * time.Minute, 90 * time.Minute}))
+		})
+	})
+
+	Context("When building the node drain strategy", func() {
+		It("adds the strategies of the annotated drain timeouts", func() {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects([]client.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "stateless", Annotations: policies.namespaceAnnotations["stateless"]}},
+				&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "stateless"}},
+				&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "test-namespace"}},
+			}...).Build()
+			uc := &upgradev1alpha1.UpgradeConfig{Spec: upgradev1alpha1.UpgradeConfigSpec{PDBForceDrainTimeout: 60}}
+			cfg := &NodeDrain{Timeout: 45, ExpectedNodeDrainTime: 8}
+
+			ds, err := NewBuilder().NewNodeDrainStrategy(c, logf.Log.WithName("drain policy test logger"), uc, cfg)
+			Expect(err).NotTo(HaveOccurred())
+			waitDurations := map[string]time.Duration{}
+			for _, ts := range ds.(*osdDrainStrategy).timedDrainStrategies {
+				waitDurations[ts.GetName()] = ts.GetWaitDuration()
+			}
+			Expect(waitDurations).To(HaveKeyWithValue(immediatePodDeleteName, time.Duration(0)))
+			Expect(waitDurations).To(HaveKeyWithValue(defaultPodDeleteName, 68*time.Minute))
+			Expect(waitDurations).To(HaveKeyWithValue(defaultPodDeleteName+"-120M", 128*time.Minute))
+			Expect(waitDurations).To(HaveKeyWithValue(pdbPodDeleteName+"-120M", 128*time.Minute))
+			Expect(waitDurations).To(HaveKeyWithValue(pdbPodFinalizerRemovalName+"-120M", 128*time.Minute))
+			Expect(waitDurations).NotTo(HaveKey(defaultPodFinalizerRemovalName + "-120M"))
+			Expect(waitDurations).To(HaveKeyWithValue(stuckTerminatingPodName, 45*time.Minute))
+			Expect(waitDurations).NotTo(HaveKey(stuckTerminatingPodName + "-120M"))
+		})
+	})
+})
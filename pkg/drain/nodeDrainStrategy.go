@@ -15,11 +15,14 @@ import (
 )
 
 var (
+	podEvictionName                = "EVICT"
+	immediatePodDeleteName         = "IMMEDIATE-DELETE"
 	defaultPodDeleteName           = "DELETE"
 	pdbPodDeleteName               = "PDB-DELETE"
 	defaultPodFinalizerRemovalName = "DEFAULT-FINALIZER"
 	pdbPodFinalizerRemovalName     = "PDB-FINALIZER"
 	stuckTerminatingPodName        = "POD-STUCK-TERMINATING"
+	volumeDetachName               = "VOLUME-DETACH"
 )
 
 // NewNodeDrainStrategy returns a new node drain stategy
@@ -53,19 +56,31 @@ func (ds *osdDrainStrategy) Execute(node *corev1.Node, logger logr.Logger) ([]*D
 			dsName := ds.GetName()
 			expectedTime := result.AddedAt.Add(ds.GetWaitDuration())
 			drainStrategyMsg := fmt.Sprintf("drain strategy %v for node %v, commencing drain at %v, execution expected after %v", dsName, node.Name, result.AddedAt, expectedTime)
-			if isAfter(result.AddedAt, ds.GetWaitDuration()) {
+			due := isAfter(result.AddedAt, ds.GetWaitDuration())
+			strategy := ds.GetStrategy()
+			if due {
 				logger.Info(fmt.Sprintf("Executing %s", drainStrategyMsg))
-				r, err := ds.GetStrategy().Execute(node, logger)
+				r, err := strategy.Execute(node, logger)
 				if err != nil {
 					return nil, err
 				}
 				me = multierror.Append(err, me)
 				if r.HasExecuted {
-					res = append(res, &DrainStrategyResult{Message: fmt.Sprintf("Executed %s . Result: %s", drainStrategyMsg, r.Message)})
+					res = append(res, &DrainStrategyResult{Name: dsName, Message: fmt.Sprintf("Executed %s . Result: %s", drainStrategyMsg, r.Message), Count: r.Count})
 				}
 			} else {
 				logger.Info(fmt.Sprintf("Will not yet execute %s", drainStrategyMsg))
 			}
+			// Report what the strategy is waiting on, both before and after it is due
+			if ps, ok := strategy.(pendingDrainStrategy); ok {
+				pending, err := ps.Pending(node, logger)
+				if err != nil {
+					return nil, err
+				}
+				if pending != "" {
+					res = append(res, &DrainStrategyResult{Name: dsName, Message: fmt.Sprintf("Drain strategy %v is waiting for %s", dsName, pending), IsPending: true})
+				}
+			}
 		}
 	}
 
@@ -97,6 +97,7 @@ var _ = Describe("OSD Drain Strategy", func() {
 			gomock.InOrder(
 				mockMachineryClient.EXPECT().IsNodeCordoned(gomock.Any()).Return(&machinery.IsCordonedResult{IsCordoned: true, AddedAt: fortyFiveMinsAgo}),
 				mockTimedDrainOne.EXPECT().GetWaitDuration().Return(time.Minute*60).Times(2),
+				mockTimedDrainOne.EXPECT().GetStrategy().Return(mockStrategyOne),
 				mockStrategyOne.EXPECT().Execute(gomock.Any(), gomock.Any()).Times(0),
 				mockTimedDrainOne.EXPECT().GetDescription().Times(0).Return("Drain one"),
 				mockTimedDrainOne.EXPECT().GetName().Return("test strategy"),
@@ -122,6 +123,7 @@ var _ = Describe("OSD Drain Strategy", func() {
 				mockStrategyOne.EXPECT().Execute(gomock.Any(), gomock.Any()).Times(1).Return(&DrainStrategyResult{Message: "", HasExecuted: true}, nil),
 				mockTimedDrainTwo.EXPECT().GetName().Return("test strategy"),
 				mockTimedDrainTwo.EXPECT().GetWaitDuration().Return(time.Minute*60).Times(2),
+				mockTimedDrainTwo.EXPECT().GetStrategy().Return(mockStrategyTwo),
 				mockStrategyTwo.EXPECT().Execute(gomock.Any(), gomock.Any()).Times(0),
 			)
 			result, err := osdDrain.Execute(&corev1.Node{}, logger)
@@ -1,7 +1,11 @@
 package drain
 
 import (
+	"sort"
+	"strings"
+
 	"github.com/go-logr/logr"
+	"github.com/hashicorp/go-multierror"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -11,6 +15,8 @@ import (
 type podDeletionStrategy struct {
 	client  client.Client
 	filters []pod.PodPredicate
+	// Resolves the grace period of each pod, which defaults to zero
+	policies drainPolicies
 }
 
 func (pds *podDeletionStrategy) Execute(node *corev1.Node, logger logr.Logger) (*DrainStrategyResult, error) {
@@ -20,15 +26,40 @@ func (pds *podDeletionStrategy) Execute(node *corev1.Node, logger logr.Logger) (
 		return nil, err
 	}
 
-	gp := int64(0)
-	res, err := pod.DeletePods(pds.client, logger, podsToDelete, true, &client.DeleteOptions{GracePeriodSeconds: &gp})
-	if err != nil {
+	// Pods are deleted in groups that share a grace period
+	byGracePeriod := map[int64]*corev1.PodList{}
+	var gracePeriods []int64
+	for _, p := range podsToDelete.Items {
+		gp := pds.policies.gracePeriod(p)
+		if _, ok := byGracePeriod[gp]; !ok {
+			byGracePeriod[gp] = &corev1.PodList{}
+			gracePeriods = append(gracePeriods, gp)
+		}
+		byGracePeriod[gp].Items = append(byGracePeriod[gp].Items, p)
+	}
+	sort.Slice(gracePeriods, func(i, j int) bool { return gracePeriods[i] < gracePeriods[j] })
+
+	me := &multierror.Error{}
+	var messages []string
+	numMarkedForDeletion := 0
+	for _, gp := range gracePeriods {
+		gp := gp
+		res, err := pod.DeletePods(pds.client, logger, byGracePeriod[gp], true, &client.DeleteOptions{GracePeriodSeconds: &gp})
+		if err != nil {
+			me = multierror.Append(err, me)
+			continue
+		}
+		messages = append(messages, res.Message)
+		numMarkedForDeletion += res.NumMarkedForDeletion
+	}
+	if err := me.ErrorOrNil(); err != nil {
 		return nil, err
 	}
 
 	return &DrainStrategyResult{
-		Message:     res.Message,
-		HasExecuted: res.NumMarkedForDeletion > 0,
+		Message:     strings.Join(messages, "; "),
+		HasExecuted: numMarkedForDeletion > 0,
+		Count:       numMarkedForDeletion,
 	}, nil
 }
 
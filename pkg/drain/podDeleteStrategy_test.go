@@ -1,6 +1,7 @@
 package drain
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/openshift/managed-upgrade-operator/pkg/pod"
@@ -126,6 +128,22 @@ var _ = Describe("Pod Delete Strategy", func() {
 			Expect(err).To(BeNil())
 		})
 
+		It("Deletes pods with the grace period of their drain policy", func() {
+			podList.Items[0].Annotations = map[string]string{DrainGracePeriodAnnotation: "30"}
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, podList),
+				mockKubeClient.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+						Expect(obj.GetName()).To(Equal("pod1"))
+						Expect(*opts[0].(*client.DeleteOptions).GracePeriodSeconds).To(Equal(int64(30)))
+						return nil
+					}),
+			)
+			result, err := pds.Execute(node, logger)
+			Expect(result.HasExecuted).To(BeTrue())
+			Expect(err).To(BeNil())
+		})
+
 		It("Returns error if fails to return a list of pods", func() {
 			gomock.InOrder(
 				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, podList).Return(fmt.Errorf("fake error")),
@@ -0,0 +1,46 @@
+package drain
+
+import (
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/managed-upgrade-operator/pkg/pod"
+)
+
+// podEvictionStrategy evicts pods through the Eviction API, which respects their
+// PodDisruptionBudgets. Evictions refused by a PodDisruptionBudget are retried each time
+// the strategy is executed.
+type podEvictionStrategy struct {
+	client  client.Client
+	filters []pod.PodPredicate
+}
+
+func (pes *podEvictionStrategy) Execute(node *corev1.Node, logger logr.Logger) (*DrainStrategyResult, error) {
+	filters := append([]pod.PodPredicate{isOnNode(node), isNotTerminating}, pes.filters...)
+	podsToEvict, err := pod.GetPodList(pes.client, node, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := pod.EvictPods(pes.client, logger, podsToEvict)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DrainStrategyResult{
+		Message:     res.Message,
+		HasExecuted: res.NumEvicted > 0,
+		Count:       res.NumEvicted,
+	}, nil
+}
+
+func (pes *podEvictionStrategy) IsValid(node *corev1.Node, logger logr.Logger) (bool, error) {
+	filters := append([]pod.PodPredicate{isOnNode(node), isNotTerminating}, pes.filters...)
+	targetPods, err := pod.GetPodList(pes.client, node, filters)
+	if err != nil {
+		return false, err
+	}
+
+	return len(targetPods.Items) > 0, nil
+}
@@ -0,0 +1,170 @@
+package drain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"go.uber.org/mock/gomock"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/openshift/managed-upgrade-operator/pkg/pod"
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pod Eviction Strategy", func() {
+
+	const (
+		POD_NAMESPACE = "test-namespace"
+	)
+
+	var (
+		logger                logr.Logger
+		mockCtrl              *gomock.Controller
+		mockKubeClient        *mocks.MockClient
+		mockSubResourceClient *mocks.MockSubResourceClient
+		pes                   *podEvictionStrategy
+		node                  *corev1.Node
+		podList               corev1.PodList
+		tooManyRequests       error
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		mockSubResourceClient = mocks.NewMockSubResourceClient(mockCtrl)
+		logger = logf.Log.WithName("pod eviction strategy test logger")
+		node = &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "n1",
+			},
+		}
+		pes = &podEvictionStrategy{
+			client:  mockKubeClient,
+			filters: []pod.PodPredicate{},
+		}
+		podList = corev1.PodList{
+			Items: []corev1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "pod1",
+						Namespace: POD_NAMESPACE,
+					},
+					Spec: corev1.PodSpec{
+						NodeName: "n1",
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "pod2",
+						Namespace:         POD_NAMESPACE,
+						DeletionTimestamp: &metav1.Time{Time: time.Now()},
+					},
+					Spec: corev1.PodSpec{
+						NodeName: "n1",
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "pod3",
+						Namespace: POD_NAMESPACE,
+					},
+					Spec: corev1.PodSpec{
+						NodeName: "n2",
+					},
+				},
+			},
+		}
+		tooManyRequests = apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 10)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("Execute pod eviction strategy on a node", func() {
+
+		It("Evicts the pods on the node that are not terminating", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, podList),
+				mockKubeClient.EXPECT().SubResource("eviction").Return(mockSubResourceClient),
+				mockSubResourceClient.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ interface{}, obj *corev1.Pod, eviction *policyv1.Eviction, _ ...interface{}) error {
+						Expect(obj.Name).To(Equal("pod1"))
+						Expect(eviction.Name).To(Equal("pod1"))
+						Expect(eviction.Namespace).To(Equal(POD_NAMESPACE))
+						return nil
+					}),
+			)
+			result, err := pes.Execute(node, logger)
+			Expect(err).To(BeNil())
+			Expect(result.HasExecuted).To(BeTrue())
+			Expect(result.Message).To(Equal("Pod(s) pod1 have been evicted"))
+			Expect(result.Count).To(Equal(1))
+		})
+
+		It("Retries the eviction later when a PodDisruptionBudget refuses it", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, podList),
+				mockKubeClient.EXPECT().SubResource("eviction").Return(mockSubResourceClient),
+				mockSubResourceClient.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(tooManyRequests),
+			)
+			result, err := pes.Execute(node, logger)
+			Expect(err).To(BeNil())
+			Expect(result.HasExecuted).To(BeFalse())
+			Expect(result.Message).To(ContainSubstring("eviction of pod(s) pod1 is blocked by PodDisruptionBudgets"))
+
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, podList),
+				mockKubeClient.EXPECT().SubResource("eviction").Return(mockSubResourceClient),
+				mockSubResourceClient.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+			)
+			result, err = pes.Execute(node, logger)
+			Expect(err).To(BeNil())
+			Expect(result.HasExecuted).To(BeTrue())
+		})
+
+		It("Returns an error when the eviction fails", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, podList),
+				mockKubeClient.EXPECT().SubResource("eviction").Return(mockSubResourceClient),
+				mockSubResourceClient.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake error")),
+			)
+			result, err := pes.Execute(node, logger)
+			Expect(err).To(HaveOccurred())
+			Expect(result).To(BeNil())
+		})
+
+		It("Returns an error when the pods can't be listed", func() {
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake error"))
+			result, err := pes.Execute(node, logger)
+			Expect(err).To(HaveOccurred())
+			Expect(result).To(BeNil())
+		})
+	})
+
+	Context("Pod eviction strategy validity", func() {
+		It("Is valid while there are pods to evict", func() {
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, podList)
+			isValid, err := pes.IsValid(node, logger)
+			Expect(err).To(BeNil())
+			Expect(isValid).To(BeTrue())
+		})
+
+		It("Is not valid when every pod on the node is terminating", func() {
+			podList.Items = podList.Items[1:]
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(1, podList)
+			isValid, err := pes.IsValid(node, logger)
+			Expect(err).To(BeNil())
+			Expect(isValid).To(BeFalse())
+		})
+	})
+})
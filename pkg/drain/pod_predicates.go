@@ -25,6 +25,12 @@ func isOnNode(node *corev1.Node) pod.PodPredicate {
 	}
 }
 
+// DrainedPodPredicates returns the predicates matching the pods that are evicted from the node
+// when it is drained, and so have to be scheduled onto other nodes
+func DrainedPodPredicates(node *corev1.Node) []pod.PodPredicate {
+	return []pod.PodPredicate{isOnNode(node), isNotDaemonSet, isNotFinished}
+}
+
 func isDaemonSet(pod corev1.Pod) bool {
 	isDaemonSet := false
 	if len(pod.OwnerReferences) > 0 {
@@ -65,22 +71,32 @@ func hasNoFinalizers(p corev1.Pod) bool {
 	return len(p.ObjectMeta.GetFinalizers()) == 0
 }
 
+func isNotFinished(p corev1.Pod) bool {
+	return p.Status.Phase != corev1.PodSucceeded && p.Status.Phase != corev1.PodFailed
+}
+
 func isTerminating(p corev1.Pod) bool {
 	return p.DeletionTimestamp != nil
 }
 
+func isNotTerminating(p corev1.Pod) bool {
+	return p.DeletionTimestamp == nil
+}
+
 func isAllowedNamespace(ignoredNamespacePatterns []string) pod.PodPredicate {
 	return func(p corev1.Pod) bool {
-		return containsIgnoredNamespace(p, ignoredNamespacePatterns)
+		return !IsIgnoredNamespace(p.Namespace, ignoredNamespacePatterns)
 	}
 }
 
-func containsIgnoredNamespace(p corev1.Pod, ignoredNamespacePatterns []string) bool {
+// IsIgnoredNamespace returns whether the namespace matches one of the patterns of namespaces
+// whose pods are not drained
+func IsIgnoredNamespace(namespace string, ignoredNamespacePatterns []string) bool {
 	for _, nsPattern := range ignoredNamespacePatterns {
 		rxp := regexp.MustCompile(nsPattern)
-		if rxp.MatchString(p.Namespace) {
-			return false
+		if rxp.MatchString(namespace) {
+			return true
 		}
 	}
-	return true
+	return false
 }
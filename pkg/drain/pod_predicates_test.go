@@ -26,20 +26,51 @@ var _ = Describe("Pod Predicates", func() {
 		})
 		Context("testing if pod namespace is allowed", func() {
 			It("allows pods with namespaces not in the ignore list", func() {
-				r := containsIgnoredNamespace(pod, []string{"not-same-as-pod", "also-not-the-same"})
-				Expect(r).To(BeTrue())
+				r := IsIgnoredNamespace(pod.Namespace, []string{"not-same-as-pod", "also-not-the-same"})
+				Expect(r).To(BeFalse())
 			})
 			It("allows pods if there are no namespaces being ignored", func() {
-				r := containsIgnoredNamespace(pod, []string{})
-				Expect(r).To(BeTrue())
+				r := IsIgnoredNamespace(pod.Namespace, []string{})
+				Expect(r).To(BeFalse())
 			})
 			It("ignore pods with namespaces in the ignore list", func() {
-				r := containsIgnoredNamespace(pod, []string{"not-same-as-pod", "test-namespace"})
-				Expect(r).To(BeFalse())
+				r := IsIgnoredNamespace(pod.Namespace, []string{"not-same-as-pod", "test-namespace"})
+				Expect(r).To(BeTrue())
 			})
 			It("ignore pods if the namespace matches a regular expression", func() {
-				r := containsIgnoredNamespace(pod, []string{"test-n.+"})
-				Expect(r).To(BeFalse())
+				r := IsIgnoredNamespace(pod.Namespace, []string{"test-n.+"})
+				Expect(r).To(BeTrue())
+			})
+		})
+		Context("testing if pod is drained from a node", func() {
+			var node *corev1.Node
+
+			BeforeEach(func() {
+				node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test-node"}}
+				pod.Spec.NodeName = node.Name
+			})
+			isDrained := func(p corev1.Pod) bool {
+				for _, predicate := range DrainedPodPredicates(node) {
+					if !predicate(p) {
+						return false
+					}
+				}
+				return true
+			}
+			It("drains pods running on the node", func() {
+				Expect(isDrained(pod)).To(BeTrue())
+			})
+			It("does not drain pods running on other nodes", func() {
+				pod.Spec.NodeName = "other-node"
+				Expect(isDrained(pod)).To(BeFalse())
+			})
+			It("does not drain DaemonSet pods", func() {
+				pod.OwnerReferences = []metav1.OwnerReference{{Kind: "DaemonSet"}}
+				Expect(isDrained(pod)).To(BeFalse())
+			})
+			It("does not drain finished pods", func() {
+				pod.Status.Phase = corev1.PodSucceeded
+				Expect(isDrained(pod)).To(BeFalse())
 			})
 		})
 	})
@@ -28,6 +28,7 @@ func (rfs *removeFinalizersStrategy) Execute(node *corev1.Node, logger logr.Logg
 	return &DrainStrategyResult{
 		Message:     res.Message,
 		HasExecuted: res.NumRemoved > 0,
+		Count:       res.NumRemoved,
 	}, nil
 }
 
@@ -2,6 +2,7 @@ package drain
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -38,6 +39,12 @@ type DrainStrategy interface {
 	IsValid(*corev1.Node, logr.Logger) (bool, error)
 }
 
+// pendingDrainStrategy is a DrainStrategy that reports what it is waiting on, such as the pods
+// still holding the node's volumes, so that the wait is visible before the strategy times out
+type pendingDrainStrategy interface {
+	Pending(*corev1.Node, logr.Logger) (string, error)
+}
+
 // TimedDrainStrategy enables implementation for a TimedDrainStrategy
 //
 //go:generate mockgen -destination=./timedDrainStrategyMock.go -package=drain -self_package=github.com/openshift/managed-upgrade-operator/pkg/drain github.com/openshift/managed-upgrade-operator/pkg/drain TimedDrainStrategy
@@ -76,40 +83,104 @@ func (dsb *drainStrategyBuilder) NewNodeDrainStrategy(c client.Client, logger lo
 		}
 	}
 
-	defaultOsdPodPredicates := []pod.PodPredicate{isNotDaemonSet}
-	isNotPdbPod := isNotPdbPod(pdbList)
-	isPdbPod := isPdbPod(pdbList)
-	isAllowedNamespace := isAllowedNamespace(cfg.IgnoredNamespacePatterns)
-	defaultDuration := cfg.GetTimeOutDuration()
-	pdbDuration := uc.GetPDBDrainTimeoutDuration() + cfg.GetExpectedDrainDuration()
-	ts := []TimedDrainStrategy{
-		newTimedStrategy(defaultPodDeleteName, "Default pod deletion", defaultDuration, &podDeletionStrategy{
-			client:  c,
-			filters: append(defaultOsdPodPredicates, isNotPdbPod, isAllowedNamespace),
-		}),
-		newTimedStrategy(defaultPodFinalizerRemovalName, "Default pod finalizer removal", defaultDuration, &removeFinalizersStrategy{
-			client:  c,
-			filters: append(defaultOsdPodPredicates, isNotPdbPod, isAllowedNamespace),
-		}),
-		newTimedStrategy(stuckTerminatingPodName, "Pod stuck terminating removal", defaultDuration, &stuckTerminatingStrategy{
-			client:  c,
-			filters: append(defaultOsdPodPredicates, isNotPdbPod, isAllowedNamespace),
-		}),
-		newTimedStrategy(pdbPodDeleteName, "PDB pod deletion", pdbDuration, &podDeletionStrategy{
-			client:  c,
-			filters: append(defaultOsdPodPredicates, isPdbPod, isAllowedNamespace),
-		}),
-		newTimedStrategy(pdbPodFinalizerRemovalName, "PDB Pod finalizer removal", pdbDuration, &removeFinalizersStrategy{
-			client:  c,
-			filters: append(defaultOsdPodPredicates, isPdbPod, isAllowedNamespace),
-		}),
+	policies, err := newDrainPolicies(c)
+	if err != nil {
+		return nil, err
+	}
+	allPods := &corev1.PodList{}
+	err = c.List(context.TODO(), allPods)
+	if err != nil {
+		return nil, err
+	}
+
+	var ts []TimedDrainStrategy
+	for _, sc := range cfg.GetStrategies() {
+		ts = append(ts, newConfiguredStrategies(c, uc, cfg, sc, pdbList, policies, allPods)...)
+	}
+	// Once the pods have left the node, the drain waits for their volumes to detach
+	if cfg.VolumeDetach.IsEnabled() {
+		ts = append(ts, newTimedStrategy(volumeDetachName, "Volume detach", cfg.VolumeDetach.GetTimeOutDuration(), &volumeDetachStrategy{
+			client:             c,
+			forceDetachDrivers: cfg.VolumeDetach.ForceDetachDrivers,
+		}))
 	}
 
 	return NewNodeDrainStrategy(c, cfg, ts)
 }
 
+// newConfiguredStrategies returns the timed drain strategies of a configured strategy. Strategies that
+// forcefully remove pods skip pods whose drain policy forbids it. Those that wait for the
+// PodDisruptionBudget force drain timeout skip pods annotated with a drain timeout, and are
+// repeated for each annotated timeout, suffixed with the timeout in minutes.
+func newConfiguredStrategies(c client.Client, uc *upgradev1alpha1.UpgradeConfig, cfg *NodeDrain, sc StrategyConfig, pdbList *policyv1.PodDisruptionBudgetList, policies drainPolicies, allPods *corev1.PodList) []TimedDrainStrategy {
+	filters := []pod.PodPredicate{isNotDaemonSet, isAllowedNamespace(cfg.IgnoredNamespacePatterns)}
+	for _, f := range sc.Pods {
+		switch f {
+		case PDBPodFilter:
+			filters = append(filters, isPdbPod(pdbList))
+		case NonPDBPodFilter:
+			filters = append(filters, isNotPdbPod(pdbList))
+		case DeleteImmediatelyPodFilter:
+			filters = append(filters, policies.isDeletedImmediately)
+		}
+	}
+	if sc.forcesPodRemoval() {
+		filters = append(filters, policies.isForceDeletable)
+	}
+	// Further filters are appended to copies of the filters
+	filters = filters[:len(filters):len(filters)]
+	if !sc.forcesPodRemoval() || sc.Wait != PDBForceDrainTimeoutWait {
+		return []TimedDrainStrategy{newTimedStrategy(sc.Name, describeStrategy(sc), sc.waitDuration(cfg, uc), newStrategy(c, sc.Action, filters, policies))}
+	}
+
+	ts := []TimedDrainStrategy{
+		newTimedStrategy(sc.Name, describeStrategy(sc), sc.waitDuration(cfg, uc), newStrategy(c, sc.Action, append(filters, policies.hasDefaultPDBTimeout), policies)),
+	}
+	for _, timeout := range policies.pdbTimeouts(allPods) {
+		ts = append(ts, newTimedStrategy(fmt.Sprintf("%s-%dM", sc.Name, int(timeout.Minutes())), describeStrategy(sc)+" after the annotated drain timeout",
+			timeout+cfg.GetExpectedDrainDuration(), newStrategy(c, sc.Action, append(filters, policies.hasPDBTimeout(timeout)), policies)))
+	}
+	return ts
+}
+
+func newStrategy(c client.Client, action StrategyAction, filters []pod.PodPredicate, policies drainPolicies) DrainStrategy {
+	switch action {
+	case DeleteAction:
+		return &podDeletionStrategy{client: c, filters: filters, policies: policies}
+	case RemoveFinalizersAction:
+		return &removeFinalizersStrategy{client: c, filters: filters}
+	case DeleteStuckTerminatingAction:
+		return &stuckTerminatingStrategy{client: c, filters: filters}
+	}
+	return &podEvictionStrategy{client: c, filters: filters}
+}
+
+func describeStrategy(sc StrategyConfig) string {
+	var description string
+	switch sc.Action {
+	case DeleteAction:
+		description = "Pod deletion"
+	case RemoveFinalizersAction:
+		description = "Pod finalizer removal"
+	case DeleteStuckTerminatingAction:
+		description = "Pod stuck terminating removal"
+	default:
+		description = "Pod eviction"
+	}
+	if len(sc.Pods) > 0 {
+		description = fmt.Sprintf("%s of %v pods", description, sc.Pods)
+	}
+	return description
+}
+
 // DrainStrategyResult holds fields illustrating a drain strategies result
 type DrainStrategyResult struct {
+	// Name of the timed drain strategy that produced the result
+	Name        string
 	Message     string
 	HasExecuted bool
+	// Number of pods, or volume attachments, that the strategy acted on
+	Count int
+	// Whether the message describes what the strategy is waiting on, rather than what it did
+	IsPending bool
 }
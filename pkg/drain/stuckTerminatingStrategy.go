@@ -30,6 +30,7 @@ func (sts *stuckTerminatingStrategy) Execute(node *corev1.Node, logger logr.Logg
 	return &DrainStrategyResult{
 		Message:     res.Message,
 		HasExecuted: res.NumMarkedForDeletion > 0,
+		Count:       res.NumMarkedForDeletion,
 	}, nil
 }
 
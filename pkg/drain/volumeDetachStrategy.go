@@ -0,0 +1,210 @@
+package drain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/hashicorp/go-multierror"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/managed-upgrade-operator/pkg/pod"
+)
+
+// volumeDetachStrategy waits for the ReadWriteOnce volumes of the drained pods to detach from the
+// node, reports the pods still holding them while it waits, and force-cleans the VolumeAttachments of the given
+// CSI drivers that remain once no pod holds them
+type volumeDetachStrategy struct {
+	client client.Client
+	// CSI drivers whose stuck VolumeAttachments are force-cleaned
+	forceDetachDrivers []string
+}
+
+// attachedVolume is a VolumeAttachment of a ReadWriteOnce volume to a node
+type attachedVolume struct {
+	attachment storagev1.VolumeAttachment
+	// The claim bound to the volume, if any
+	claim *corev1.ObjectReference
+}
+
+func (vds *volumeDetachStrategy) Execute(node *corev1.Node, logger logr.Logger) (*DrainStrategyResult, error) {
+	attached, err := nodeAttachedVolumes(vds.client, node)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := pod.GetPodList(vds.client, node, []pod.PodPredicate{isOnNode(node), isNotFinished})
+	if err != nil {
+		return nil, err
+	}
+
+	me := &multierror.Error{}
+	var forceDetached []string
+	for _, av := range attached {
+		av := av
+		if holders := podsUsingClaim(pods, av.claim); len(holders) > 0 {
+			logger.Info(fmt.Sprintf("VolumeAttachment %v of node %v is still held by pod(s) %v", av.attachment.Name, node.Name, strings.Join(holders, ",")))
+			continue
+		}
+		if !vds.isForceDetachable(av.attachment) {
+			logger.Info(fmt.Sprintf("VolumeAttachment %v of node %v is still attached, and driver %v is not force detached", av.attachment.Name, node.Name, av.attachment.Spec.Attacher))
+			continue
+		}
+		logger.Info(fmt.Sprintf("Applying volume detach drain strategy to VolumeAttachment %v", av.attachment.Name))
+		err := forceDetach(vds.client, &av.attachment)
+		if err != nil {
+			logger.Error(err, fmt.Sprintf("failed to force detach the VolumeAttachment %v", av.attachment.Name))
+			me = multierror.Append(err, me)
+		} else {
+			forceDetached = append(forceDetached, av.attachment.Name)
+		}
+	}
+	if err := me.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	return &DrainStrategyResult{
+		Message:     fmt.Sprintf("VolumeAttachment(s) %s have been force detached", strings.Join(forceDetached, ",")),
+		HasExecuted: len(forceDetached) > 0,
+		Count:       len(forceDetached),
+	}, nil
+}
+
+// Pending returns the pods on the node still holding its ReadWriteOnce volumes, for each
+// VolumeAttachment that is held
+func (vds *volumeDetachStrategy) Pending(node *corev1.Node, logger logr.Logger) (string, error) {
+	attached, err := nodeAttachedVolumes(vds.client, node)
+	if err != nil {
+		return "", err
+	}
+	if len(attached) == 0 {
+		return "", nil
+	}
+	pods, err := pod.GetPodList(vds.client, node, []pod.PodPredicate{isOnNode(node), isNotFinished})
+	if err != nil {
+		return "", err
+	}
+
+	var held []string
+	for _, av := range attached {
+		if holders := podsUsingClaim(pods, av.claim); len(holders) > 0 {
+			held = append(held, fmt.Sprintf("VolumeAttachment %v held by pod(s) %v", av.attachment.Name, strings.Join(holders, ",")))
+		}
+	}
+	return strings.Join(held, "; "), nil
+}
+
+// IsValid returns whether ReadWriteOnce volumes are still attached to the node, so that the drain is
+// not considered to have failed while the volumes detach
+func (vds *volumeDetachStrategy) IsValid(node *corev1.Node, logger logr.Logger) (bool, error) {
+	attached, err := nodeAttachedVolumes(vds.client, node)
+	if err != nil {
+		return false, err
+	}
+
+	return len(attached) > 0, nil
+}
+
+func (vds *volumeDetachStrategy) isForceDetachable(va storagev1.VolumeAttachment) bool {
+	for _, driver := range vds.forceDetachDrivers {
+		if va.Spec.Attacher == driver {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeAttachedVolumes returns the VolumeAttachments of ReadWriteOnce volumes to the node
+func nodeAttachedVolumes(c client.Client, node *corev1.Node) ([]attachedVolume, error) {
+	vaList := &storagev1.VolumeAttachmentList{}
+	err := c.List(context.TODO(), vaList)
+	if err != nil {
+		return nil, err
+	}
+
+	var attached []attachedVolume
+	for _, va := range vaList.Items {
+		if va.Spec.NodeName != node.Name {
+			continue
+		}
+		var accessModes []corev1.PersistentVolumeAccessMode
+		var claim *corev1.ObjectReference
+		switch {
+		case va.Spec.Source.PersistentVolumeName != nil:
+			pv := &corev1.PersistentVolume{}
+			err := c.Get(context.TODO(), client.ObjectKey{Name: *va.Spec.Source.PersistentVolumeName}, pv)
+			if err != nil {
+				if apierrors.IsNotFound(err) {
+					// The attachment of a deleted volume is still stuck on the node
+					attached = append(attached, attachedVolume{attachment: va})
+					continue
+				}
+				return nil, err
+			}
+			accessModes = pv.Spec.AccessModes
+			claim = pv.Spec.ClaimRef
+		case va.Spec.Source.InlineVolumeSpec != nil:
+			accessModes = va.Spec.Source.InlineVolumeSpec.AccessModes
+		}
+		if isReadWriteOnce(accessModes) {
+			attached = append(attached, attachedVolume{attachment: va, claim: claim})
+		}
+	}
+	return attached, nil
+}
+
+func isReadWriteOnce(accessModes []corev1.PersistentVolumeAccessMode) bool {
+	for _, m := range accessModes {
+		if m == corev1.ReadWriteOnce || m == corev1.ReadWriteOncePod {
+			return true
+		}
+	}
+	return false
+}
+
+// podsUsingClaim returns the names of the pods that mount the claim
+func podsUsingClaim(pl *corev1.PodList, claim *corev1.ObjectReference) []string {
+	if claim == nil {
+		return nil
+	}
+	var holders []string
+	for _, p := range pl.Items {
+		if p.Namespace != claim.Namespace {
+			continue
+		}
+		for _, v := range p.Spec.Volumes {
+			if v.PersistentVolumeClaim != nil && v.PersistentVolumeClaim.ClaimName == claim.Name {
+				holders = append(holders, fmt.Sprintf("%v/%v", p.Namespace, p.Name))
+				break
+			}
+		}
+	}
+	return holders
+}
+
+// forceDetach deletes the VolumeAttachment and removes its finalizers, so that it is removed
+// without waiting for the CSI driver to detach the volume
+func forceDetach(c client.Client, va *storagev1.VolumeAttachment) error {
+	if va.DeletionTimestamp == nil {
+		err := c.Delete(context.TODO(), va)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+	}
+	if len(va.Finalizers) == 0 {
+		return nil
+	}
+	patch := client.MergeFrom(va.DeepCopy())
+	va.Finalizers = nil
+	err := c.Patch(context.TODO(), va, patch)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
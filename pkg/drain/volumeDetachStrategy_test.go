@@ -0,0 +1,160 @@
+package drain
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Volume Detach Strategy", func() {
+
+	const (
+		driver      = "ebs.csi.aws.com"
+		otherDriver = "file.csi.example.com"
+	)
+
+	var (
+		logger     logr.Logger
+		node       *corev1.Node
+		objs       []client.Object
+		kubeClient client.Client
+		vds        *volumeDetachStrategy
+
+		volume = func(name string, accessMode corev1.PersistentVolumeAccessMode, claim string) *corev1.PersistentVolume {
+			return &corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec: corev1.PersistentVolumeSpec{
+					AccessModes: []corev1.PersistentVolumeAccessMode{accessMode},
+					ClaimRef:    &corev1.ObjectReference{Namespace: "test-namespace", Name: claim},
+				},
+			}
+		}
+		attachment = func(name, attacher, nodeName, pv string) *storagev1.VolumeAttachment {
+			return &storagev1.VolumeAttachment{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Finalizers: []string{"external-attacher/" + attacher}},
+				Spec: storagev1.VolumeAttachmentSpec{
+					Attacher: attacher,
+					NodeName: nodeName,
+					Source:   storagev1.VolumeAttachmentSource{PersistentVolumeName: &pv},
+				},
+				Status: storagev1.VolumeAttachmentStatus{Attached: true},
+			}
+		}
+		podUsingClaim = func(name, nodeName, claim string) *corev1.Pod {
+			return &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-namespace"},
+				Spec: corev1.PodSpec{
+					NodeName: nodeName,
+					Volumes: []corev1.Volume{{
+						Name:         "data",
+						VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claim}},
+					}},
+				},
+			}
+		}
+		build = func() {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			kubeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+				WithIndex(&corev1.Pod{}, "spec.nodeName", func(o client.Object) []string {
+					return []string{o.(*corev1.Pod).Spec.NodeName}
+				}).Build()
+			vds = &volumeDetachStrategy{client: kubeClient, forceDetachDrivers: []string{driver}}
+		}
+		exists = func(name string) bool {
+			err := kubeClient.Get(context.TODO(), client.ObjectKey{Name: name}, &storagev1.VolumeAttachment{})
+			if apierrors.IsNotFound(err) {
+				return false
+			}
+			Expect(err).NotTo(HaveOccurred())
+			return true
+		}
+	)
+
+	BeforeEach(func() {
+		logger = logf.Log.WithName("volume detach strategy test logger")
+		node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n1"}}
+		objs = []client.Object{
+			volume("pv-rwo", corev1.ReadWriteOnce, "claim-rwo"),
+			volume("pv-rwx", corev1.ReadWriteMany, "claim-rwx"),
+			attachment("va-rwx", driver, "n1", "pv-rwx"),
+			attachment("va-other-node", driver, "n2", "pv-rwo"),
+		}
+	})
+
+	Context("When no ReadWriteOnce volumes are attached to the node", func() {
+		It("is not valid", func() {
+			build()
+			valid, err := vds.IsValid(node, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(valid).To(BeFalse())
+		})
+	})
+
+	Context("When a ReadWriteOnce volume is attached to the node", func() {
+		BeforeEach(func() {
+			objs = append(objs, attachment("va-rwo", driver, "n1", "pv-rwo"))
+		})
+
+		It("is valid until the volume detaches", func() {
+			build()
+			valid, err := vds.IsValid(node, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(valid).To(BeTrue())
+		})
+		It("does not force detach the volume while a pod on the node holds it", func() {
+			objs = append(objs, podUsingClaim("holder", "n1", "claim-rwo"))
+			build()
+			result, err := vds.Execute(node, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.HasExecuted).To(BeFalse())
+			Expect(exists("va-rwo")).To(BeTrue())
+		})
+		It("force detaches the volume once no pod on the node holds it", func() {
+			objs = append(objs, podUsingClaim("rescheduled", "n2", "claim-rwo"))
+			build()
+			result, err := vds.Execute(node, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.HasExecuted).To(BeTrue())
+			Expect(result.Message).To(ContainSubstring("va-rwo"))
+			Expect(exists("va-rwo")).To(BeFalse())
+			Expect(exists("va-rwx")).To(BeTrue())
+			Expect(exists("va-other-node")).To(BeTrue())
+		})
+		It("reports the pods on the node still holding the volume", func() {
+			objs = append(objs, podUsingClaim("holder", "n1", "claim-rwo"), podUsingClaim("rescheduled", "n2", "claim-rwo"))
+			build()
+			pending, err := vds.Pending(node, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pending).To(Equal("VolumeAttachment va-rwo held by pod(s) test-namespace/holder"))
+		})
+		It("reports nothing once no pod on the node holds the volume", func() {
+			objs = append(objs, podUsingClaim("rescheduled", "n2", "claim-rwo"))
+			build()
+			pending, err := vds.Pending(node, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pending).To(BeEmpty())
+		})
+		It("does not force detach the volume of a driver that is not configured", func() {
+			objs = append(objs, attachment("va-other-driver", otherDriver, "n1", "pv-rwo"))
+			build()
+			result, err := vds.Execute(node, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.HasExecuted).To(BeTrue())
+			Expect(exists("va-rwo")).To(BeFalse())
+			Expect(exists("va-other-driver")).To(BeTrue())
+		})
+	})
+})
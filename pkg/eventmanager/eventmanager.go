@@ -22,6 +22,8 @@ const (
 	UPGRADE_EXTDEPCHECK_FAILED_DESC = "Cluster upgrade to version %s was cancelled during the External Dependency Availability Check step. A required external dependency of the upgrade was unavailable, so the upgrade did not proceed. Automated upgrades will be retried on their next scheduling cycle. If you have manually scheduled an upgrade instead, it must now be rescheduled"
 	// UPGRADE_SCALE_FAILED_DESC describes the upgrade scaling failed
 	UPGRADE_SCALE_FAILED_DESC = "Cluster upgrade to version %s was cancelled during the Scale-Up Worker Node step. A temporary additional worker node was unable to be created to temporarily house workloads, so the upgrade did not proceed. Automated upgrades will be retried on their next scheduling cycle. If you have manually scheduled an upgrade instead, it must now be rescheduled"
+	// UPGRADE_CANCELLED_DESC describes the upgrade cancelled before it commenced
+	UPGRADE_CANCELLED_DESC = "Cluster upgrade to version %s was cancelled before it commenced. Any temporary worker nodes and maintenance windows created for the upgrade have been removed. If you still wish to upgrade the cluster, the upgrade must now be rescheduled"
 	// UPGRADE_SCALE_SKIP_DESC describes the upgrade scaling skipped
 	UPGRADE_SCALE_SKIP_DESC = "Cluster upgrade to version %s has skipped Scale-Up additional Worker Node step for compute capacity reservation. This is an informational notification and no action is required by you"
 
@@ -33,6 +35,12 @@ const (
 	UPGRADE_PREHEALTHCHECK_DELAY_DESC = "Cluster upgrade to version %s is experiencing a delay as health alerts are firing in the cluster which could impact the upgrade's operation. The upgrade will continue to retry. This is an informational notification and no action is required by you"
 	// UPGRADE_EXTDEPCHECK_DELAY_DESC describes the upgrade external dependency check delay
 	UPGRADE_EXTDEPCHECK_DELAY_DESC = "Cluster upgrade to version %s is experiencing a delay as an external dependency of the upgrade is currently unavailable. The upgrade will continue to retry. This is an informational notification and no action is required by you"
+	// UPGRADE_FREEZE_DELAY_DESC describes the upgrade delayed by a freeze period
+	UPGRADE_FREEZE_DELAY_DESC = "Cluster upgrade to version %s is experiencing a delay as a change freeze is in effect (%s). The upgrade will start once the freeze has ended. This is an informational notification and no action is required by you"
+	// UPGRADE_STEP_FAILING_DELAY_DESC describes the upgrade delayed by a repeatedly failing step
+	UPGRADE_STEP_FAILING_DELAY_DESC = "Cluster upgrade to version %s is experiencing a delay as its %s step has failed %d times. The upgrade will continue to retry. This is an informational notification and no action is required by you"
+	// UPGRADE_STEP_EXHAUSTED_DELAY_DESC describes the upgrade delayed by a step that exhausted its retry budget
+	UPGRADE_STEP_EXHAUSTED_DELAY_DESC = "Cluster upgrade to version %s is experiencing a delay as its %s step has not succeeded within its allowed attempts. The upgrade will continue to retry. This is an informational notification and no action is required by you"
 	// UPGRADE_SCALE_DELAY_DESC describes the upgrade scaling delayed
 	UPGRADE_SCALE_DELAY_DESC = "Cluster upgrade to version %s is experiencing a delay attempting to scale up an additional worker node. The upgrade will continue to retry. This is an informational notification and no action is required by you"
 	// UPGRADE_SCALE_DELAY_SKIP_DESC describes the upgrade scaling skipped after delay
@@ -133,12 +141,18 @@ func (s *eventManager) Notify(state notifier.MuoState) error {
 		description = fmt.Sprintf(UPGRADE_SCALE_SKIP_DESC, uc.Spec.Desired.Version)
 	case notifier.MuoStateDelayed:
 		description = createDelayedDescription(uc)
+	case notifier.MuoStateFrozen:
+		description = createFrozenDescription(uc)
+	case notifier.MuoStateStepExhausted:
+		description = createStepExhaustedDescription(uc)
 	case notifier.MuoStateSkipped:
 		description = fmt.Sprintf(UPGRADE_SCALE_DELAY_SKIP_DESC, uc.Spec.Desired.Version)
 	case notifier.MuoStateCompleted:
 		description = fmt.Sprintf("Cluster has been successfully upgraded to version %s", uc.Spec.Desired.Version)
 	case notifier.MuoStateFailed:
 		description = createFailureDescription(uc)
+	case notifier.MuoStateCancelled:
+		description = fmt.Sprintf(UPGRADE_CANCELLED_DESC, uc.Spec.Desired.Version)
 	case notifier.MuoStateControlPlaneUpgradeStartedSL:
 		description = fmt.Sprintf(UPGRADE_CONTROL_PLANE_STARTED_DESC, uc.Spec.Desired.Version)
 	case notifier.MuoStateControlPlaneUpgradeFinishedSL:
@@ -243,6 +257,31 @@ func createFailureDescription(uc *v1alpha1.UpgradeConfig) string {
 	return description
 }
 
+// Generates a Frozen notification description from the freeze period holding the upgrade
+func createFrozenDescription(uc *v1alpha1.UpgradeConfig) string {
+	var reason string
+	history := uc.Status.History.GetHistory(uc.Spec.Desired.Version)
+	if history != nil {
+		if condition := history.Conditions.GetCondition(v1alpha1.FreezePeriodEnded); condition != nil {
+			reason = condition.Message
+		}
+	}
+	return fmt.Sprintf(UPGRADE_FREEZE_DELAY_DESC, uc.Spec.Desired.Version, reason)
+}
+
+// Generates a StepExhausted notification description from the incomplete step of the upgrade
+func createStepExhaustedDescription(uc *v1alpha1.UpgradeConfig) string {
+	history := uc.Status.History.GetHistory(uc.Spec.Desired.Version)
+	if history != nil {
+		for _, condition := range history.Conditions {
+			if condition.IsFalse() {
+				return fmt.Sprintf(UPGRADE_STEP_EXHAUSTED_DELAY_DESC, uc.Spec.Desired.Version, condition.Type)
+			}
+		}
+	}
+	return fmt.Sprintf(UPGRADE_DEFAULT_DELAY_DESC, uc.Spec.Desired.Version)
+}
+
 // Generates a Delayed notification description based on the UpgradeConfig's last state
 func createDelayedDescription(uc *v1alpha1.UpgradeConfig) string {
 	// Default delayed message
@@ -282,6 +321,10 @@ func createDelayedDescription(uc *v1alpha1.UpgradeConfig) string {
 		description = fmt.Sprintf(UPGRADE_EXTDEPCHECK_DELAY_DESC, uc.Spec.Desired.Version)
 	case v1alpha1.UpgradeScaleUpExtraNodes:
 		description = fmt.Sprintf(UPGRADE_SCALE_DELAY_DESC, uc.Spec.Desired.Version)
+	default:
+		if delayedCondition.Attempts > 0 {
+			description = fmt.Sprintf(UPGRADE_STEP_FAILING_DELAY_DESC, uc.Spec.Desired.Version, delayedCondition.Type, delayedCondition.Attempts)
+		}
 	}
 
 	return description
@@ -114,6 +114,33 @@ var _ = Describe("OCM Notifier", func() {
 
 	})
 
+	Context("When notifying a cancelled state", func() {
+		var uc upgradev1alpha1.UpgradeConfig
+		var testState = notifier.MuoStateCancelled
+		BeforeEach(func() {
+			upgradeConfigName = types.NamespacedName{
+				Name:      TEST_UPGRADECONFIG_CR,
+				Namespace: TEST_OPERATOR_NAMESPACE,
+			}
+			uc = *testStructs.NewUpgradeConfigBuilder().WithNamespacedName(upgradeConfigName).WithPhase(upgradev1alpha1.UpgradePhasePending).GetUpgradeConfig()
+			uc.Spec.Desired.Version = TEST_UPGRADE_VERSION
+			uc.Status.History[0].Version = TEST_UPGRADE_VERSION
+			uc.Spec.UpgradeAt = TEST_UPGRADE_TIME
+		})
+
+		It("sends a correct notification and description", func() {
+			expectedDescription := fmt.Sprintf(UPGRADE_CANCELLED_DESC, uc.Spec.Desired.Version)
+			gomock.InOrder(
+				mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+				mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
+				mockNotifier.EXPECT().NotifyState(testState, expectedDescription),
+				mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+			)
+			err := manager.Notify(testState)
+			Expect(err).To(BeNil())
+		})
+	})
+
 	Context("When notifying a failed state", func() {
 		var uc upgradev1alpha1.UpgradeConfig
 		var testState = notifier.MuoStateFailed
@@ -298,6 +325,29 @@ var _ = Describe("OCM Notifier", func() {
 			})
 		})
 
+		Context("when a step is repeatedly failing", func() {
+			It("sends a correct notification and description", func() {
+				uc.Status.History[0].Conditions = []upgradev1alpha1.UpgradeCondition{
+					{
+						Type:      upgradev1alpha1.ControlPlaneMaintWindow,
+						Status:    "False",
+						Reason:    "ControlPlaneMaintenanceWindowCreated not done",
+						Attempts:  5,
+						LastError: "a bad time",
+					},
+				}
+				expectedDescription := fmt.Sprintf(UPGRADE_STEP_FAILING_DELAY_DESC, uc.Spec.Desired.Version, upgradev1alpha1.ControlPlaneMaintWindow, 5)
+				gomock.InOrder(
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
+					mockNotifier.EXPECT().NotifyState(testState, expectedDescription),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+				)
+				err := manager.Notify(testState)
+				Expect(err).To(BeNil())
+			})
+		})
+
 		Context("when an indeterminate failure occurs", func() {
 			It("sends a correct default notification and description", func() {
 				uc.Status.History[0].Conditions = []upgradev1alpha1.UpgradeCondition{
@@ -322,6 +372,83 @@ var _ = Describe("OCM Notifier", func() {
 
 	})
 
+	Context("When notifying a frozen state", func() {
+		var uc upgradev1alpha1.UpgradeConfig
+		var testState = notifier.MuoStateFrozen
+		BeforeEach(func() {
+			upgradeConfigName = types.NamespacedName{
+				Name:      TEST_UPGRADECONFIG_CR,
+				Namespace: TEST_OPERATOR_NAMESPACE,
+			}
+			uc = *testStructs.NewUpgradeConfigBuilder().WithNamespacedName(upgradeConfigName).WithPhase(upgradev1alpha1.UpgradePhasePending).GetUpgradeConfig()
+			uc.Spec.Desired.Version = TEST_UPGRADE_VERSION
+			uc.Status.History[0].Version = TEST_UPGRADE_VERSION
+			uc.Spec.UpgradeAt = TEST_UPGRADE_TIME
+		})
+
+		Context("when a freeze period is in effect", func() {
+			It("sends a correct notification and description", func() {
+				uc.Status.History[0].Conditions = []upgradev1alpha1.UpgradeCondition{
+					{
+						Type:    upgradev1alpha1.FreezePeriodEnded,
+						Status:  "False",
+						Reason:  "Freeze period in effect",
+						Message: "Upgrades are frozen until 2024-01-02T00:00:00Z: Holiday change freeze",
+					},
+				}
+				expectedDescription := fmt.Sprintf(UPGRADE_FREEZE_DELAY_DESC, uc.Spec.Desired.Version, "Upgrades are frozen until 2024-01-02T00:00:00Z: Holiday change freeze")
+				gomock.InOrder(
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
+					mockNotifier.EXPECT().NotifyState(testState, expectedDescription),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+				)
+				err := manager.Notify(testState)
+				Expect(err).To(BeNil())
+			})
+		})
+
+	})
+
+	Context("When notifying a step exhausted state", func() {
+		var uc upgradev1alpha1.UpgradeConfig
+		var testState = notifier.MuoStateStepExhausted
+		BeforeEach(func() {
+			upgradeConfigName = types.NamespacedName{
+				Name:      TEST_UPGRADECONFIG_CR,
+				Namespace: TEST_OPERATOR_NAMESPACE,
+			}
+			uc = *testStructs.NewUpgradeConfigBuilder().WithNamespacedName(upgradeConfigName).WithPhase(upgradev1alpha1.UpgradePhaseUpgrading).GetUpgradeConfig()
+			uc.Spec.Desired.Version = TEST_UPGRADE_VERSION
+			uc.Status.History[0].Version = TEST_UPGRADE_VERSION
+			uc.Spec.UpgradeAt = TEST_UPGRADE_TIME
+		})
+
+		Context("when a step has exhausted its attempts", func() {
+			It("sends a correct notification and description", func() {
+				uc.Status.History[0].Conditions = []upgradev1alpha1.UpgradeCondition{
+					{
+						Type:      upgradev1alpha1.ExtDepAvailabilityCheck,
+						Status:    "False",
+						Reason:    "ExtDepAvailabilityCheck not done",
+						Attempts:  5,
+						LastError: "An external dependency is down.",
+					},
+				}
+				expectedDescription := fmt.Sprintf(UPGRADE_STEP_EXHAUSTED_DELAY_DESC, uc.Spec.Desired.Version, upgradev1alpha1.ExtDepAvailabilityCheck)
+				gomock.InOrder(
+					mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+					mockMetricsClient.EXPECT().IsMetricNotificationEventSentSet(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION).Return(false, nil),
+					mockNotifier.EXPECT().NotifyState(testState, expectedDescription),
+					mockMetricsClient.EXPECT().UpdateMetricNotificationEventSent(TEST_UPGRADECONFIG_CR, string(testState), TEST_UPGRADE_VERSION),
+				)
+				err := manager.Notify(testState)
+				Expect(err).To(BeNil())
+			})
+		})
+
+	})
+
 	Context("When notifying a MuoStateHealthCheck state", func() {
 		var uc upgradev1alpha1.UpgradeConfig
 		var testState = notifier.MuoStateHealthCheckSL
@@ -0,0 +1,307 @@
+// Package hostedcluster provides upgrade related functions for clusters with a hosted control plane,
+// whose release is driven by HostedCluster and NodePool resources.
+package hostedcluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+)
+
+const (
+	// DesiredVersionAnnotation records the version a HostedCluster's release was set to by an upgrade
+	DesiredVersionAnnotation = "upgrade.managed.openshift.io/desired-version"
+	// PausedAnnotation marks a NodePool that has been paused on behalf of a paused upgrade
+	PausedAnnotation = "upgrade.managed.openshift.io/paused"
+	// KubeconfigKey is the key of the kubeconfig in a management cluster kubeconfig Secret
+	KubeconfigKey = "kubeconfig"
+
+	// updatingVersionCondition is the NodePool condition reporting a rollout of a new release
+	updatingVersionCondition = "UpdatingVersion"
+	// completedState is the state of a completed entry in a HostedCluster's version history
+	completedState = "Completed"
+)
+
+var (
+	logger logr.Logger = logf.Log.WithName("hostedcluster")
+
+	// HostedClusterGVK is the GroupVersionKind of a HostedCluster
+	HostedClusterGVK = schema.GroupVersionKind{Group: "hypershift.openshift.io", Version: "v1beta1", Kind: "HostedCluster"}
+	// NodePoolListGVK is the GroupVersionKind of a list of NodePools
+	NodePoolListGVK = schema.GroupVersionKind{Group: "hypershift.openshift.io", Version: "v1beta1", Kind: "NodePoolList"}
+)
+
+// HostedCluster interface enables implementations of the HostedCluster
+//
+//go:generate mockgen -destination=mocks/mockHostedCluster.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/hostedcluster HostedCluster
+type HostedCluster interface {
+	HasUpgradeCommenced(uc *upgradev1alpha1.UpgradeConfig) (bool, error)
+	EnsureDesiredRelease(uc *upgradev1alpha1.UpgradeConfig, image string) (bool, error)
+	HasUpgradeCompleted(uc *upgradev1alpha1.UpgradeConfig) (bool, error)
+	EnsureNodePoolsRelease() (bool, error)
+	IsNodePoolsUpgrading() (*NodePoolsUpgradingResult, error)
+	SetNodePoolsPaused(paused bool) error
+}
+
+// NodePoolsUpgradingResult describes the progress of the NodePools' rollout of the desired release
+type NodePoolsUpgradingResult struct {
+	IsUpgrading  bool
+	UpdatedCount int32
+	MachineCount int32
+}
+
+type hostedClusterClient struct {
+	client    client.Client
+	namespace string
+	name      string
+}
+
+// NewHostedClusterClient returns a HostedCluster interface for the named HostedCluster
+func NewHostedClusterClient(c client.Client, namespace string, name string) HostedCluster {
+	return &hostedClusterClient{client: c, namespace: namespace, name: name}
+}
+
+// NewManagementClient returns a client for the management cluster hosting the control plane, built
+// from the kubeconfig held in the named Secret
+func NewManagementClient(c client.Client, namespace string, secretName string) (client.Client, error) {
+	secret := &corev1.Secret{}
+	err := c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: secretName}, secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get management cluster kubeconfig secret %s/%s: %v", namespace, secretName, err)
+	}
+	kubeconfig, ok := secret.Data[KubeconfigKey]
+	if !ok {
+		return nil, fmt.Errorf("management cluster kubeconfig secret %s/%s has no %s key", namespace, secretName, KubeconfigKey)
+	}
+	cfg, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse management cluster kubeconfig: %v", err)
+	}
+	return client.New(cfg, client.Options{})
+}
+
+// getHostedCluster returns the HostedCluster
+func (h *hostedClusterClient) getHostedCluster() (*unstructured.Unstructured, error) {
+	hc := &unstructured.Unstructured{}
+	hc.SetGroupVersionKind(HostedClusterGVK)
+	err := h.client.Get(context.TODO(), types.NamespacedName{Namespace: h.namespace, Name: h.name}, hc)
+	if err != nil {
+		return nil, err
+	}
+	return hc, nil
+}
+
+// getNodePools returns the NodePools of the HostedCluster
+func (h *hostedClusterClient) getNodePools() ([]unstructured.Unstructured, error) {
+	npList := &unstructured.UnstructuredList{}
+	npList.SetGroupVersionKind(NodePoolListGVK)
+	err := h.client.List(context.TODO(), npList, client.InNamespace(h.namespace))
+	if err != nil {
+		return nil, err
+	}
+	nodePools := []unstructured.Unstructured{}
+	for _, np := range npList.Items {
+		clusterName, _, _ := unstructured.NestedString(np.Object, "spec", "clusterName")
+		if clusterName == h.name {
+			nodePools = append(nodePools, np)
+		}
+	}
+	return nodePools, nil
+}
+
+// HasUpgradeCommenced checks if the HostedCluster's release has been set to the desired version
+func (h *hostedClusterClient) HasUpgradeCommenced(uc *upgradev1alpha1.UpgradeConfig) (bool, error) {
+	hc, err := h.getHostedCluster()
+	if err != nil {
+		return false, err
+	}
+
+	if hc.GetAnnotations()[DesiredVersionAnnotation] == uc.Spec.Desired.Version {
+		logger.Info(fmt.Sprintf("HostedCluster is already set to Version %s", uc.Spec.Desired.Version))
+		return true, nil
+	}
+	desiredVersion, _, _ := unstructured.NestedString(hc.Object, "status", "version", "desired", "version")
+	return desiredVersion == uc.Spec.Desired.Version, nil
+}
+
+// EnsureDesiredRelease sets the HostedCluster's release to the image of the desired version
+func (h *hostedClusterClient) EnsureDesiredRelease(uc *upgradev1alpha1.UpgradeConfig, image string) (bool, error) {
+	hc, err := h.getHostedCluster()
+	if err != nil {
+		return false, err
+	}
+
+	patch := client.MergeFrom(hc.DeepCopy())
+	err = unstructured.SetNestedField(hc.Object, image, "spec", "release", "image")
+	if err != nil {
+		return false, err
+	}
+	if uc.Spec.Desired.Channel != "" {
+		err = unstructured.SetNestedField(hc.Object, uc.Spec.Desired.Channel, "spec", "channel")
+		if err != nil {
+			return false, err
+		}
+	}
+	annotations := hc.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[DesiredVersionAnnotation] = uc.Spec.Desired.Version
+	hc.SetAnnotations(annotations)
+
+	err = h.client.Patch(context.TODO(), hc, patch)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// HasUpgradeCompleted checks if the HostedCluster reports its control plane upgrade to the desired version as completed
+func (h *hostedClusterClient) HasUpgradeCompleted(uc *upgradev1alpha1.UpgradeConfig) (bool, error) {
+	hc, err := h.getHostedCluster()
+	if err != nil {
+		return false, err
+	}
+
+	history, _, _ := unstructured.NestedSlice(hc.Object, "status", "version", "history")
+	for _, entry := range history {
+		e, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if e["version"] == uc.Spec.Desired.Version && e["state"] == completedState {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// EnsureNodePoolsRelease sets the release of the HostedCluster's NodePools to that of the HostedCluster,
+// returning true once all NodePools have been set
+func (h *hostedClusterClient) EnsureNodePoolsRelease() (bool, error) {
+	hc, err := h.getHostedCluster()
+	if err != nil {
+		return false, err
+	}
+	image, _, _ := unstructured.NestedString(hc.Object, "spec", "release", "image")
+	if image == "" {
+		return false, fmt.Errorf("HostedCluster %s/%s has no release image", h.namespace, h.name)
+	}
+
+	nodePools, err := h.getNodePools()
+	if err != nil {
+		return false, err
+	}
+	for i := range nodePools {
+		np := &nodePools[i]
+		current, _, _ := unstructured.NestedString(np.Object, "spec", "release", "image")
+		if current == image {
+			continue
+		}
+		patch := client.MergeFrom(np.DeepCopy())
+		err = unstructured.SetNestedField(np.Object, image, "spec", "release", "image")
+		if err != nil {
+			return false, err
+		}
+		logger.Info(fmt.Sprintf("Setting NodePool %s release to %s", np.GetName(), image))
+		err = h.client.Patch(context.TODO(), np, patch)
+		if err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// IsNodePoolsUpgrading determines if the HostedCluster's NodePools are still rolling out the
+// version the HostedCluster was upgraded to
+func (h *hostedClusterClient) IsNodePoolsUpgrading() (*NodePoolsUpgradingResult, error) {
+	hc, err := h.getHostedCluster()
+	if err != nil {
+		return nil, err
+	}
+	desiredVersion := hc.GetAnnotations()[DesiredVersionAnnotation]
+
+	nodePools, err := h.getNodePools()
+	if err != nil {
+		return nil, err
+	}
+	result := &NodePoolsUpgradingResult{}
+	for _, np := range nodePools {
+		replicas, _, _ := unstructured.NestedInt64(np.Object, "status", "replicas")
+		result.MachineCount += int32(replicas)
+
+		version, _, _ := unstructured.NestedString(np.Object, "status", "version")
+		if (desiredVersion != "" && version != desiredVersion) || isUpdatingVersion(np) {
+			result.IsUpgrading = true
+			continue
+		}
+		result.UpdatedCount += int32(replicas)
+	}
+	return result, nil
+}
+
+// SetNodePoolsPaused pauses or unpauses the HostedCluster's NodePools. A NodePool is only
+// unpaused if it was paused by SetNodePoolsPaused, so a NodePool paused by other means
+// is left paused.
+func (h *hostedClusterClient) SetNodePoolsPaused(paused bool) error {
+	nodePools, err := h.getNodePools()
+	if err != nil {
+		return err
+	}
+	for i := range nodePools {
+		np := &nodePools[i]
+		_, pausedByUpgrade := np.GetAnnotations()[PausedAnnotation]
+		pausedUntil, _, _ := unstructured.NestedString(np.Object, "spec", "pausedUntil")
+		if paused == (pausedUntil != "") || (!paused && !pausedByUpgrade) {
+			continue
+		}
+
+		patch := client.MergeFrom(np.DeepCopy())
+		annotations := np.GetAnnotations()
+		if paused {
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[PausedAnnotation] = "true"
+			err = unstructured.SetNestedField(np.Object, "true", "spec", "pausedUntil")
+			if err != nil {
+				return err
+			}
+		} else {
+			delete(annotations, PausedAnnotation)
+			unstructured.RemoveNestedField(np.Object, "spec", "pausedUntil")
+		}
+		np.SetAnnotations(annotations)
+
+		err = h.client.Patch(context.TODO(), np, patch)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isUpdatingVersion returns whether a NodePool reports that it is rolling out a new release
+func isUpdatingVersion(np unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(np.Object, "status", "conditions")
+	for _, condition := range conditions {
+		c, ok := condition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if c["type"] == updatingVersionCondition && c["status"] == string(corev1.ConditionTrue) {
+			return true
+		}
+	}
+	return false
+}
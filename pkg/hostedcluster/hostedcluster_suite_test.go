@@ -0,0 +1,13 @@
+package hostedcluster
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestHostedCluster(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "HostedCluster Suite")
+}
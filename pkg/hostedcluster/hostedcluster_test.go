@@ -0,0 +1,160 @@
+package hostedcluster
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
+)
+
+var _ = Describe("HostedCluster client", func() {
+	const (
+		testNamespace = "clusters"
+		testName      = "test-cluster"
+		testImage     = "quay.io/openshift-release-dev/ocp-release:4.14.1-x86_64"
+	)
+
+	var (
+		kubeClient    client.Client
+		hcClient      HostedCluster
+		upgradeConfig *upgradev1alpha1.UpgradeConfig
+
+		newHostedCluster = func(fields map[string]interface{}) *unstructured.Unstructured {
+			hc := &unstructured.Unstructured{Object: fields}
+			hc.SetGroupVersionKind(HostedClusterGVK)
+			hc.SetNamespace(testNamespace)
+			hc.SetName(testName)
+			return hc
+		}
+		newNodePool = func(name string, clusterName string, status map[string]interface{}) *unstructured.Unstructured {
+			np := &unstructured.Unstructured{Object: map[string]interface{}{
+				"spec":   map[string]interface{}{"clusterName": clusterName},
+				"status": status,
+			}}
+			np.SetGroupVersionKind(schema.GroupVersionKind{Group: NodePoolListGVK.Group, Version: NodePoolListGVK.Version, Kind: "NodePool"})
+			np.SetNamespace(testNamespace)
+			np.SetName(name)
+			return np
+		}
+		getObject = func(kind string, name string) *unstructured.Unstructured {
+			obj := &unstructured.Unstructured{}
+			obj.SetGroupVersionKind(schema.GroupVersionKind{Group: HostedClusterGVK.Group, Version: HostedClusterGVK.Version, Kind: kind})
+			Expect(kubeClient.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: name}, obj)).To(Succeed())
+			return obj
+		}
+		build = func(objs ...client.Object) {
+			kubeClient = fake.NewClientBuilder().WithObjects(objs...).Build()
+			hcClient = NewHostedClusterClient(kubeClient, testNamespace, testName)
+		}
+	)
+
+	BeforeEach(func() {
+		upgradeConfig = testStructs.NewUpgradeConfigBuilder().GetUpgradeConfig()
+		upgradeConfig.Spec.Desired.Version = "4.14.1"
+		upgradeConfig.Spec.Desired.Channel = "stable-4.14"
+	})
+
+	Context("When the upgrade has not commenced", func() {
+		BeforeEach(func() {
+			build(newHostedCluster(map[string]interface{}{}))
+		})
+		It("reports that it has not commenced", func() {
+			commenced, err := hcClient.HasUpgradeCommenced(upgradeConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(commenced).To(BeFalse())
+		})
+		It("sets the HostedCluster's release to the desired version", func() {
+			ok, err := hcClient.EnsureDesiredRelease(upgradeConfig, testImage)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+
+			hc := getObject("HostedCluster", testName)
+			image, _, _ := unstructured.NestedString(hc.Object, "spec", "release", "image")
+			Expect(image).To(Equal(testImage))
+			channel, _, _ := unstructured.NestedString(hc.Object, "spec", "channel")
+			Expect(channel).To(Equal("stable-4.14"))
+
+			commenced, err := hcClient.HasUpgradeCommenced(upgradeConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(commenced).To(BeTrue())
+		})
+	})
+
+	Context("When checking if the control plane upgrade has completed", func() {
+		It("reports completion once the version's history entry is completed", func() {
+			build(newHostedCluster(map[string]interface{}{
+				"status": map[string]interface{}{"version": map[string]interface{}{"history": []interface{}{
+					map[string]interface{}{"version": "4.14.1", "state": "Completed"},
+				}}},
+			}))
+			completed, err := hcClient.HasUpgradeCompleted(upgradeConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(completed).To(BeTrue())
+		})
+		It("reports that a partial upgrade has not completed", func() {
+			build(newHostedCluster(map[string]interface{}{
+				"status": map[string]interface{}{"version": map[string]interface{}{"history": []interface{}{
+					map[string]interface{}{"version": "4.14.1", "state": "Partial"},
+				}}},
+			}))
+			completed, err := hcClient.HasUpgradeCompleted(upgradeConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(completed).To(BeFalse())
+		})
+	})
+
+	Context("When upgrading the NodePools", func() {
+		BeforeEach(func() {
+			hc := newHostedCluster(map[string]interface{}{
+				"spec": map[string]interface{}{"release": map[string]interface{}{"image": testImage}},
+			})
+			hc.SetAnnotations(map[string]string{DesiredVersionAnnotation: "4.14.1"})
+			build(hc,
+				newNodePool("workers-a", testName, map[string]interface{}{"version": "4.14.1", "replicas": int64(2)}),
+				newNodePool("workers-b", testName, map[string]interface{}{"version": "4.14.0", "replicas": int64(3)}),
+				newNodePool("other", "other-cluster", map[string]interface{}{"version": "4.13.0", "replicas": int64(1)}),
+			)
+		})
+		It("sets the release of only the HostedCluster's NodePools", func() {
+			ok, err := hcClient.EnsureNodePoolsRelease()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			for _, name := range []string{"workers-a", "workers-b"} {
+				image, _, _ := unstructured.NestedString(getObject("NodePool", name).Object, "spec", "release", "image")
+				Expect(image).To(Equal(testImage))
+			}
+			image, _, _ := unstructured.NestedString(getObject("NodePool", "other").Object, "spec", "release", "image")
+			Expect(image).To(BeEmpty())
+		})
+		It("reports the machines of the NodePools that have not rolled out the desired version", func() {
+			result, err := hcClient.IsNodePoolsUpgrading()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.IsUpgrading).To(BeTrue())
+			Expect(result.UpdatedCount).To(Equal(int32(2)))
+			Expect(result.MachineCount).To(Equal(int32(5)))
+		})
+		It("only unpauses the NodePools it paused", func() {
+			Expect(hcClient.SetNodePoolsPaused(true)).To(Succeed())
+			np := getObject("NodePool", "workers-a")
+			pausedUntil, _, _ := unstructured.NestedString(np.Object, "spec", "pausedUntil")
+			Expect(pausedUntil).To(Equal("true"))
+			Expect(np.GetAnnotations()).To(HaveKey(PausedAnnotation))
+
+			Expect(hcClient.SetNodePoolsPaused(false)).To(Succeed())
+			np = getObject("NodePool", "workers-a")
+			_, found, _ := unstructured.NestedString(np.Object, "spec", "pausedUntil")
+			Expect(found).To(BeFalse())
+			Expect(np.GetAnnotations()).NotTo(HaveKey(PausedAnnotation))
+			_, found, _ = unstructured.NestedString(getObject("NodePool", "other").Object, "spec", "pausedUntil")
+			Expect(found).To(BeFalse())
+		})
+	})
+})
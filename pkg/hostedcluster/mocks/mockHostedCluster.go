@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/openshift/managed-upgrade-operator/pkg/hostedcluster (interfaces: HostedCluster)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/mockHostedCluster.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/hostedcluster HostedCluster
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	v1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	hostedcluster "github.com/openshift/managed-upgrade-operator/pkg/hostedcluster"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHostedCluster is a mock of HostedCluster interface.
+type MockHostedCluster struct {
+	ctrl     *gomock.Controller
+	recorder *MockHostedClusterMockRecorder
+}
+
+// MockHostedClusterMockRecorder is the mock recorder for MockHostedCluster.
+type MockHostedClusterMockRecorder struct {
+	mock *MockHostedCluster
+}
+
+// NewMockHostedCluster creates a new mock instance.
+func NewMockHostedCluster(ctrl *gomock.Controller) *MockHostedCluster {
+	mock := &MockHostedCluster{ctrl: ctrl}
+	mock.recorder = &MockHostedClusterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHostedCluster) EXPECT() *MockHostedClusterMockRecorder {
+	return m.recorder
+}
+
+// EnsureDesiredRelease mocks base method.
+func (m *MockHostedCluster) EnsureDesiredRelease(arg0 *v1alpha1.UpgradeConfig, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureDesiredRelease", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnsureDesiredRelease indicates an expected call of EnsureDesiredRelease.
+func (mr *MockHostedClusterMockRecorder) EnsureDesiredRelease(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureDesiredRelease", reflect.TypeOf((*MockHostedCluster)(nil).EnsureDesiredRelease), arg0, arg1)
+}
+
+// EnsureNodePoolsRelease mocks base method.
+func (m *MockHostedCluster) EnsureNodePoolsRelease() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureNodePoolsRelease")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnsureNodePoolsRelease indicates an expected call of EnsureNodePoolsRelease.
+func (mr *MockHostedClusterMockRecorder) EnsureNodePoolsRelease() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureNodePoolsRelease", reflect.TypeOf((*MockHostedCluster)(nil).EnsureNodePoolsRelease))
+}
+
+// HasUpgradeCommenced mocks base method.
+func (m *MockHostedCluster) HasUpgradeCommenced(arg0 *v1alpha1.UpgradeConfig) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasUpgradeCommenced", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasUpgradeCommenced indicates an expected call of HasUpgradeCommenced.
+func (mr *MockHostedClusterMockRecorder) HasUpgradeCommenced(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasUpgradeCommenced", reflect.TypeOf((*MockHostedCluster)(nil).HasUpgradeCommenced), arg0)
+}
+
+// HasUpgradeCompleted mocks base method.
+func (m *MockHostedCluster) HasUpgradeCompleted(arg0 *v1alpha1.UpgradeConfig) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasUpgradeCompleted", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasUpgradeCompleted indicates an expected call of HasUpgradeCompleted.
+func (mr *MockHostedClusterMockRecorder) HasUpgradeCompleted(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasUpgradeCompleted", reflect.TypeOf((*MockHostedCluster)(nil).HasUpgradeCompleted), arg0)
+}
+
+// IsNodePoolsUpgrading mocks base method.
+func (m *MockHostedCluster) IsNodePoolsUpgrading() (*hostedcluster.NodePoolsUpgradingResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsNodePoolsUpgrading")
+	ret0, _ := ret[0].(*hostedcluster.NodePoolsUpgradingResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsNodePoolsUpgrading indicates an expected call of IsNodePoolsUpgrading.
+func (mr *MockHostedClusterMockRecorder) IsNodePoolsUpgrading() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsNodePoolsUpgrading", reflect.TypeOf((*MockHostedCluster)(nil).IsNodePoolsUpgrading))
+}
+
+// SetNodePoolsPaused mocks base method.
+func (m *MockHostedCluster) SetNodePoolsPaused(arg0 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNodePoolsPaused", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNodePoolsPaused indicates an expected call of SetNodePoolsPaused.
+func (mr *MockHostedClusterMockRecorder) SetNodePoolsPaused(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNodePoolsPaused", reflect.TypeOf((*MockHostedCluster)(nil).SetNodePoolsPaused), arg0)
+}
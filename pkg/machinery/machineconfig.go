@@ -2,6 +2,7 @@ package machinery
 
 import (
 	"context"
+	"sort"
 
 	machineconfigapi "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -13,20 +14,85 @@ type UpgradingResult struct {
 	IsUpgrading  bool
 	UpdatedCount int32
 	MachineCount int32
+	// Pools holds the result of each MachineConfigPool of the node type, by name
+	Pools []PoolUpgradingResult
+}
+
+// PoolUpgradingResult provides a struct to illustrate the upgrading result of a single MachineConfigPool
+type PoolUpgradingResult struct {
+	Name         string
+	IsUpgrading  bool
+	UpdatedCount int32
+	MachineCount int32
 }
 
 // IsUpgrading determines if machines are currently upgrading by comparing
-// MachineCount and UpdatedMachineCount
+// MachineCount and UpdatedMachineCount. The worker node type covers every
+// MachineConfigPool other than the master pool, such as infra or custom pools.
 func (m *machinery) IsUpgrading(c client.Client, nodeType string) (*UpgradingResult, error) {
+	var configPools []machineconfigapi.MachineConfigPool
+	if nodeType == WorkerNodeType {
+		poolList := &machineconfigapi.MachineConfigPoolList{}
+		err := c.List(context.TODO(), poolList)
+		if err != nil {
+			return nil, err
+		}
+		for _, pool := range poolList.Items {
+			if pool.Name != MasterNodeType {
+				configPools = append(configPools, pool)
+			}
+		}
+		sort.Slice(configPools, func(i, j int) bool { return configPools[i].Name < configPools[j].Name })
+	} else {
+		configPool := &machineconfigapi.MachineConfigPool{}
+		err := c.Get(context.TODO(), types.NamespacedName{Name: nodeType}, configPool)
+		if err != nil {
+			return nil, err
+		}
+		configPools = append(configPools, *configPool)
+	}
+
+	result := &UpgradingResult{}
+	for _, configPool := range configPools {
+		poolResult := PoolUpgradingResult{
+			Name:         configPool.Name,
+			IsUpgrading:  configPool.Status.MachineCount != configPool.Status.UpdatedMachineCount,
+			UpdatedCount: configPool.Status.UpdatedMachineCount,
+			MachineCount: configPool.Status.MachineCount,
+		}
+		result.IsUpgrading = result.IsUpgrading || poolResult.IsUpgrading
+		result.UpdatedCount += poolResult.UpdatedCount
+		result.MachineCount += poolResult.MachineCount
+		result.Pools = append(result.Pools, poolResult)
+	}
+	return result, nil
+}
+
+// SetPoolPaused pauses or unpauses the named MachineConfigPool. A pool is only
+// unpaused if it was paused by SetPoolPaused, so a pool paused by other means
+// is left untouched.
+func (m *machinery) SetPoolPaused(c client.Client, nodeType string, paused bool) error {
 	configPool := &machineconfigapi.MachineConfigPool{}
 	err := c.Get(context.TODO(), types.NamespacedName{Name: nodeType}, configPool)
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	_, pausedByUpgrade := configPool.Annotations[PausedAnnotation]
+	if paused == configPool.Spec.Paused || (!paused && !pausedByUpgrade) {
+		return nil
+	}
+
+	patch := client.MergeFrom(configPool.DeepCopy())
+	configPool.Spec.Paused = paused
+	if paused {
+		if configPool.Annotations == nil {
+			configPool.Annotations = map[string]string{}
+		}
+		configPool.Annotations[PausedAnnotation] = "true"
+	} else {
+		delete(configPool.Annotations, PausedAnnotation)
 	}
 
-	return &UpgradingResult{
-		IsUpgrading:  configPool.Status.MachineCount != configPool.Status.UpdatedMachineCount,
-		UpdatedCount: configPool.Status.UpdatedMachineCount,
-		MachineCount: configPool.Status.MachineCount,
-	}, nil
+	return c.Patch(context.TODO(), configPool, patch)
 }
@@ -9,6 +9,12 @@ import (
 const (
 	// MasterLabel for master node
 	MasterLabel = "node-role.kubernetes.io/master"
+	// MasterNodeType is the node type, and MachineConfigPool, of control plane nodes
+	MasterNodeType = "master"
+	// WorkerNodeType is the node type of every node outside the master MachineConfigPool
+	WorkerNodeType = "worker"
+	// PausedAnnotation marks a MachineConfigPool that has been paused on behalf of a paused upgrade
+	PausedAnnotation = "upgrade.managed.openshift.io/paused"
 )
 
 // Machinery enables an implementation of a Machinery interface
@@ -16,6 +22,7 @@ const (
 //go:generate mockgen -destination=mocks/machinery.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/machinery Machinery
 type Machinery interface {
 	IsUpgrading(c client.Client, nodeType string) (*UpgradingResult, error)
+	SetPoolPaused(c client.Client, nodeType string, paused bool) error
 	IsNodeCordoned(node *corev1.Node) *IsCordonedResult
 	IsNodeUpgrading(node *corev1.Node) bool
 	HasMemoryPressure(node *corev1.Node) bool
@@ -1,6 +1,7 @@
 package machinery
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -36,7 +38,7 @@ var _ = Describe("Machinery client and utils", func() {
 
 	Context("When assessing whether all machines are upgraded", func() {
 		var configPool *machineconfigapi.MachineConfigPool
-		var nodeType = "worker"
+		var nodeType = "master"
 
 		Context("When checking IsUpgrading errors", func() {
 			It("reports the error", func() {
@@ -73,6 +75,103 @@ var _ = Describe("Machinery client and utils", func() {
 				Expect(result.IsUpgrading).To(BeTrue())
 			})
 		})
+
+		Context("When assessing the worker machines", func() {
+			var newPool = func(name string, machines int32, updated int32) machineconfigapi.MachineConfigPool {
+				return machineconfigapi.MachineConfigPool{
+					ObjectMeta: metav1.ObjectMeta{Name: name},
+					Status:     machineconfigapi.MachineConfigPoolStatus{MachineCount: machines, UpdatedMachineCount: updated},
+				}
+			}
+
+			It("reports the error", func() {
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(fmt.Errorf("Fake error"))
+				result, err := machineryClient.IsUpgrading(mockKubeClient, WorkerNodeType)
+				Expect(err).To(HaveOccurred())
+				Expect(result).To(BeNil())
+			})
+			It("reports the progress of every pool other than the master pool", func() {
+				pools := machineconfigapi.MachineConfigPoolList{Items: []machineconfigapi.MachineConfigPool{
+					newPool("worker", 3, 3),
+					newPool("master", 3, 1),
+					newPool("infra", 2, 1),
+				}}
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, pools).Return(nil)
+				result, err := machineryClient.IsUpgrading(mockKubeClient, WorkerNodeType)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.IsUpgrading).To(BeTrue())
+				Expect(result.MachineCount).To(Equal(int32(5)))
+				Expect(result.UpdatedCount).To(Equal(int32(4)))
+				Expect(result.Pools).To(Equal([]PoolUpgradingResult{
+					{Name: "infra", IsUpgrading: true, UpdatedCount: 1, MachineCount: 2},
+					{Name: "worker", IsUpgrading: false, UpdatedCount: 3, MachineCount: 3},
+				}))
+			})
+			It("reports that all machines are upgraded once every pool is upgraded", func() {
+				pools := machineconfigapi.MachineConfigPoolList{Items: []machineconfigapi.MachineConfigPool{
+					newPool("worker", 3, 3),
+					newPool("infra", 2, 2),
+				}}
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, pools).Return(nil)
+				result, err := machineryClient.IsUpgrading(mockKubeClient, WorkerNodeType)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.IsUpgrading).To(BeFalse())
+			})
+		})
+	})
+
+	Context("When setting the paused state of a machine config pool", func() {
+		var configPool *machineconfigapi.MachineConfigPool
+		var nodeType = "worker"
+
+		Context("When pausing an unpaused pool", func() {
+			It("pauses the pool and marks it as paused by the upgrade", func() {
+				configPool = &machineconfigapi.MachineConfigPool{}
+				gomock.InOrder(
+					mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: nodeType}, gomock.Any()).SetArg(2, *configPool).Return(nil),
+					mockKubeClient.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+						func(ctx context.Context, mcp *machineconfigapi.MachineConfigPool, patch client.Patch, opts ...client.PatchOption) error {
+							Expect(mcp.Spec.Paused).To(BeTrue())
+							Expect(mcp.Annotations).To(HaveKey(PausedAnnotation))
+							return nil
+						}),
+				)
+				err := machineryClient.SetPoolPaused(mockKubeClient, nodeType, true)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("When resuming a pool paused by the upgrade", func() {
+			It("unpauses the pool", func() {
+				configPool = &machineconfigapi.MachineConfigPool{
+					ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{PausedAnnotation: "true"}},
+					Spec:       machineconfigapi.MachineConfigPoolSpec{Paused: true},
+				}
+				gomock.InOrder(
+					mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: nodeType}, gomock.Any()).SetArg(2, *configPool).Return(nil),
+					mockKubeClient.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+						func(ctx context.Context, mcp *machineconfigapi.MachineConfigPool, patch client.Patch, opts ...client.PatchOption) error {
+							Expect(mcp.Spec.Paused).To(BeFalse())
+							Expect(mcp.Annotations).NotTo(HaveKey(PausedAnnotation))
+							return nil
+						}),
+				)
+				err := machineryClient.SetPoolPaused(mockKubeClient, nodeType, false)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("When resuming a pool that was not paused by the upgrade", func() {
+			It("leaves the pool paused", func() {
+				configPool = &machineconfigapi.MachineConfigPool{
+					Spec: machineconfigapi.MachineConfigPoolSpec{Paused: true},
+				}
+				mockKubeClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: nodeType}, gomock.Any()).SetArg(2, *configPool).Return(nil)
+				mockKubeClient.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				err := machineryClient.SetPoolPaused(mockKubeClient, nodeType, false)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
 	})
 
 	Context("When assessing if a node is cordoned", func() {
@@ -125,3 +125,17 @@ func (mr *MockMachineryMockRecorder) IsUpgrading(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUpgrading", reflect.TypeOf((*MockMachinery)(nil).IsUpgrading), arg0, arg1)
 }
+
+// SetPoolPaused mocks base method.
+func (m *MockMachinery) SetPoolPaused(arg0 client.Client, arg1 string, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPoolPaused", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPoolPaused indicates an expected call of SetPoolPaused.
+func (mr *MockMachineryMockRecorder) SetPoolPaused(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPoolPaused", reflect.TypeOf((*MockMachinery)(nil).SetPoolPaused), arg0, arg1, arg2)
+}
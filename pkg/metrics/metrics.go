@@ -62,6 +62,13 @@ const (
 	ClusterNodeQueryFailed           = "cluster_node_query_failed"
 	ClusterNodesManuallyCordoned     = "cluster_node_manually_cordoned"
 	ClusterNodesTaintedUnschedulable = "cluster_node_taint_unschedulable"
+	PromQLHealthCheckFailed          = "promql_healthcheck_failed"
+	PodDisruptionBudgetQueryFailed   = "pdb_query_failed"
+	PodDisruptionBudgetsBlocking     = "pdb_blocking_drain"
+	DrainCapacityQueryFailed         = "drain_capacity_query_failed"
+	DrainCapacityInsufficient        = "drain_capacity_insufficient"
+	RemovedAPIsQueryFailed           = "removed_apis_query_failed"
+	RemovedAPIsInUse                 = "removed_apis_in_use"
 )
 
 // Alerts sourced from https://github.com/openshift/managed-cluster-config/blob/master/deploy/sre-prometheus/100-managed-upgrade-operator.PrometheusRule.yaml
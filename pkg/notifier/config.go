@@ -9,12 +9,23 @@ const (
 	LOCAL ConfigManagerSource = "LOCAL"
 )
 
+const (
+	// LOG denotes a notifier that writes to the operator's log
+	LOG NotifierType = "LOG"
+	// EVENT denotes a notifier that records Kubernetes events on the UpgradeConfig
+	EVENT NotifierType = "EVENT"
+)
+
 // ConfigManagerSource is a type that denotes the source of configuration management
 type ConfigManagerSource string
 
+// NotifierType is a type that denotes the notifier used when the config manager source is not OCM
+type NotifierType string
+
 // NotifierConfig is a type that provides a NotifierConfig
 type NotifierConfig struct {
 	ConfigManager NotifierConfigManager `yaml:"configManager"`
+	Notifier      NotifierTarget        `yaml:"notifier"`
 }
 
 // NotifierConfigManager is a type that provides a notifier source
@@ -22,8 +33,20 @@ type NotifierConfigManager struct {
 	Source string `yaml:"source"`
 }
 
+// NotifierTarget is a type that selects the notifier used when the config manager source is not OCM
+type NotifierTarget struct {
+	Type string `yaml:"type"`
+}
+
 // IsValid returns no error if the notifier config is valid
 func (cfg *NotifierConfig) IsValid() error {
+	switch NotifierType(strings.ToUpper(cfg.Notifier.Type)) {
+	case "", LOG, EVENT:
+		break
+	default:
+		return ErrNoNotifierConfigured
+	}
+
 	// the source can be missing. if it's not empty, validate it is a supported value
 	if cfg.ConfigManager.Source == "" {
 		return nil
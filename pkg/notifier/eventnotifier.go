@@ -0,0 +1,83 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager"
+)
+
+// eventSourceComponent is the component reported as the source of the notifier's events
+const eventSourceComponent = "managed-upgrade-operator"
+
+// warningStates are the states that are notified with Warning rather than Normal events
+var warningStates = map[MuoState]bool{
+	MuoStateDelayed:       true,
+	MuoStateFrozen:        true,
+	MuoStateStepExhausted: true,
+	MuoStateFailed:        true,
+	MuoStateSkipped:       true,
+	MuoStateScaleSkipped:  true,
+	MuoStateHealthCheckSL: true,
+}
+
+// NewEventNotifier returns an eventNotifier
+func NewEventNotifier(client client.Client, upgradeConfigManager upgradeconfigmanager.UpgradeConfigManager) (*eventNotifier, error) {
+	return &eventNotifier{
+		client:               client,
+		upgradeConfigManager: upgradeConfigManager,
+	}, nil
+}
+
+// A notifier that records each state as a Kubernetes Event on the UpgradeConfig
+type eventNotifier struct {
+	// Cluster k8s client
+	client client.Client
+	// Retrieves the upgrade config from the cluster
+	upgradeConfigManager upgradeconfigmanager.UpgradeConfigManager
+}
+
+func (s *eventNotifier) NotifyState(value MuoState, description string) error {
+	uc, err := s.upgradeConfigManager.Get()
+	if err != nil {
+		return fmt.Errorf("can't get the UpgradeConfig to record an event for: %v", err)
+	}
+
+	eventType := corev1.EventTypeNormal
+	if warningStates[value] {
+		eventType = corev1.EventTypeWarning
+	}
+	now := metav1.Time{Time: time.Now()}
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: uc.Name + ".",
+			Namespace:    uc.Namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: upgradev1alpha1.GroupVersion.String(),
+			Kind:       "UpgradeConfig",
+			Name:       uc.Name,
+			Namespace:  uc.Namespace,
+			UID:        uc.UID,
+		},
+		Reason:         string(value),
+		Message:        description,
+		Type:           eventType,
+		Source:         corev1.EventSource{Component: eventSourceComponent},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+
+	err = s.client.Create(context.TODO(), event)
+	if err != nil {
+		return fmt.Errorf("failed to record %s event: %v", value, err)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mockUCMgr "github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager/mocks"
+	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Event Notifier", func() {
+	var (
+		mockCtrl                 *gomock.Controller
+		kubeClient               client.Client
+		mockUpgradeConfigManager *mockUCMgr.MockUpgradeConfigManager
+		notifier                 *eventNotifier
+
+		listEvents = func() []corev1.Event {
+			events := &corev1.EventList{}
+			Expect(kubeClient.List(context.TODO(), events)).To(Succeed())
+			return events.Items
+		}
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		kubeClient = fake.NewClientBuilder().Build()
+		mockUpgradeConfigManager = mockUCMgr.NewMockUpgradeConfigManager(mockCtrl)
+		notifier = &eventNotifier{
+			client:               kubeClient,
+			upgradeConfigManager: mockUpgradeConfigManager,
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("Notify state", func() {
+		BeforeEach(func() {
+			uc := testStructs.NewUpgradeConfigBuilder().WithNamespacedName(types.NamespacedName{Name: "test-upgradeconfig", Namespace: TEST_OPERATOR_NAMESPACE}).GetUpgradeConfig()
+			mockUpgradeConfigManager.EXPECT().Get().Return(uc, nil)
+		})
+
+		It("records a Normal event on the UpgradeConfig", func() {
+			Expect(notifier.NotifyState(MuoStateStarted, TEST_STATE_DESCRIPTION)).To(Succeed())
+			events := listEvents()
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].Namespace).To(Equal(TEST_OPERATOR_NAMESPACE))
+			Expect(events[0].InvolvedObject.Kind).To(Equal("UpgradeConfig"))
+			Expect(events[0].InvolvedObject.Name).To(Equal("test-upgradeconfig"))
+			Expect(events[0].Reason).To(Equal(string(MuoStateStarted)))
+			Expect(events[0].Message).To(Equal(TEST_STATE_DESCRIPTION))
+			Expect(events[0].Type).To(Equal(corev1.EventTypeNormal))
+		})
+
+		It("records a Warning event for a failed upgrade", func() {
+			Expect(notifier.NotifyState(MuoStateFailed, TEST_STATE_DESCRIPTION)).To(Succeed())
+			events := listEvents()
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].Type).To(Equal(corev1.EventTypeWarning))
+		})
+	})
+
+	Context("When the UpgradeConfig can't be retrieved", func() {
+		It("returns an error", func() {
+			mockUpgradeConfigManager.EXPECT().Get().Return(nil, fmt.Errorf("fake error"))
+			Expect(notifier.NotifyState(MuoStateStarted, TEST_STATE_DESCRIPTION)).To(MatchError(ContainSubstring("fake error")))
+			Expect(listEvents()).To(BeEmpty())
+		})
+	})
+
+	Context("When validating the notifier config", func() {
+		It("accepts the event notifier", func() {
+			cfg := NotifierConfig{ConfigManager: NotifierConfigManager{Source: "LOCAL"}, Notifier: NotifierTarget{Type: "Event"}}
+			Expect(cfg.IsValid()).To(Succeed())
+		})
+		It("rejects an unknown notifier", func() {
+			cfg := NotifierConfig{ConfigManager: NotifierConfigManager{Source: "LOCAL"}, Notifier: NotifierTarget{Type: "Pager"}}
+			Expect(cfg.IsValid()).To(Equal(ErrNoNotifierConfigured))
+		})
+	})
+})
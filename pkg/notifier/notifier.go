@@ -31,6 +31,8 @@ const (
 	MuoStateStarted                       MuoState = "StateStarted"
 	MuoStateCompleted                     MuoState = "StateCompleted"
 	MuoStateDelayed                       MuoState = "StateDelayed"
+	MuoStateFrozen                        MuoState = "StateFrozen"
+	MuoStateStepExhausted                 MuoState = "StateStepExhausted"
 	MuoStateFailed                        MuoState = "StateFailed"
 	MuoStateCancelled                     MuoState = "StateCancelled"
 	MuoStateScheduled                     MuoState = "StateScheduled"
@@ -82,6 +84,10 @@ func (nb *notifierBuilder) New(client client.Client, cfgBuilder configmanager.Co
 		}
 		return mgr, nil
 	default:
+		if NotifierType(strings.ToUpper(cfg.Notifier.Type)) == EVENT {
+			mgr, err := NewEventNotifier(client, upgradeConfigManager)
+			return mgr, err
+		}
 		// Create a log notifier as a fallback
 		mgr, err := NewLogNotifier()
 		return mgr, err
@@ -50,15 +50,17 @@ const (
 )
 
 var stateMap = map[MuoState]OcmState{
-	MuoStatePending:      OcmStatePending,
-	MuoStateCancelled:    OcmStateCancelled,
-	MuoStateStarted:      OcmStateStarted,
-	MuoStateCompleted:    OcmStateCompleted,
-	MuoStateDelayed:      OcmStateDelayed,
-	MuoStateFailed:       OcmStateFailed,
-	MuoStateScheduled:    OcmStateScheduled,
-	MuoStateSkipped:      OcmStateDelayed,
-	MuoStateScaleSkipped: OcmStateDelayed,
+	MuoStatePending:       OcmStatePending,
+	MuoStateCancelled:     OcmStateCancelled,
+	MuoStateStarted:       OcmStateStarted,
+	MuoStateCompleted:     OcmStateCompleted,
+	MuoStateDelayed:       OcmStateDelayed,
+	MuoStateFrozen:        OcmStateDelayed,
+	MuoStateStepExhausted: OcmStateDelayed,
+	MuoStateFailed:        OcmStateFailed,
+	MuoStateScheduled:     OcmStateScheduled,
+	MuoStateSkipped:       OcmStateDelayed,
+	MuoStateScaleSkipped:  OcmStateDelayed,
 }
 
 var (
@@ -122,7 +124,7 @@ func (s *ocmNotifier) NotifyState(state MuoState, description string) error {
 	var muoCurrent MuoState
 	// Return the MuoState from the current OcmState, determine if MUO is "skipped" or "delayed" it is OCM "deleyed"
 	if OcmState(currentState.Value) == OcmStateDelayed {
-		if strings.Contains(currentState.Description, "retry") {
+		if strings.Contains(currentState.Description, "retry") || strings.Contains(currentState.Description, "freeze") {
 			muoCurrent = MuoStateDelayed
 		} else {
 			muoCurrent = MuoStateSkipped
@@ -137,7 +139,12 @@ func (s *ocmNotifier) NotifyState(state MuoState, description string) error {
 
 	// Don't notify if the state is already at the same value
 	// Only notify if it's a valid transition
-	shouldNotify := validateStateTransition(muoCurrent, state)
+	// Freeze periods and exhausted steps are reported to OCM as delays
+	transitionTo := state
+	if state == MuoStateFrozen || state == MuoStateStepExhausted {
+		transitionTo = MuoStateDelayed
+	}
+	shouldNotify := validateStateTransition(muoCurrent, transitionTo)
 	if !shouldNotify {
 		return nil
 	}
@@ -188,16 +195,20 @@ func validateStateTransition(from MuoState, to MuoState) bool {
 		// We shouldn't even be in this state to transition from
 		return false
 	case MuoStateScheduled:
-		// Can only go to started state
+		// Can go to started, delayed (when held by a freeze period) or cancelled state
 		switch to {
 		case MuoStateStarted:
 			return true
+		case MuoStateDelayed:
+			return true
+		case MuoStateCancelled:
+			return true
 		default:
 			return false
 		}
 
 	case MuoStateStarted:
-		// Can go to a scale skipped, healthCheck, delayed, completed or failed state
+		// Can go to a scale skipped, healthCheck, delayed, completed, failed or cancelled state
 		switch to {
 		case MuoStateScaleSkipped:
 			return true
@@ -207,12 +218,14 @@ func validateStateTransition(from MuoState, to MuoState) bool {
 			return true
 		case MuoStateFailed:
 			return true
+		case MuoStateCancelled:
+			return true
 		default:
 			return false
 		}
 
 	case MuoStateScaleSkipped:
-		// can go to skipped, delayed, completed or failed state
+		// can go to skipped, delayed, completed, failed or cancelled state
 		switch to {
 		case MuoStateDelayed:
 			return true
@@ -222,19 +235,25 @@ func validateStateTransition(from MuoState, to MuoState) bool {
 			return true
 		case MuoStateCompleted:
 			return true
+		case MuoStateCancelled:
+			return true
 		default:
 			return false
 		}
 
 	case MuoStateDelayed:
-		// can go to completed or failed or skipped state
+		// can go to started (after a freeze period) or completed or failed or skipped or cancelled state
 		switch to {
+		case MuoStateStarted:
+			return true
 		case MuoStateCompleted:
 			return true
 		case MuoStateFailed:
 			return true
 		case MuoStateSkipped:
 			return true
+		case MuoStateCancelled:
+			return true
 		default:
 			return false
 		}
@@ -256,6 +275,9 @@ func validateStateTransition(from MuoState, to MuoState) bool {
 	case MuoStateFailed:
 		// can't go anywhere
 		return false
+	case MuoStateCancelled:
+		// can't go anywhere
+		return false
 	default:
 		return false
 	}
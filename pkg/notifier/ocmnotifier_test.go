@@ -212,6 +212,51 @@ var _ = Describe("OCM Notifier", func() {
 				})
 			})
 
+			Context("When the policy was delayed by a freeze period", func() {
+				var uc upgradev1alpha1.UpgradeConfig
+				BeforeEach(func() {
+					uc = *testStructs.NewUpgradeConfigBuilder().WithNamespacedName(upgradeConfigName).WithPhase(upgradev1alpha1.UpgradePhasePending).GetUpgradeConfig()
+					uc.Spec.Desired.Version = TEST_UPGRADEPOLICY_VERSION
+					uc.Spec.UpgradeAt = TEST_UPGRADEPOLICY_TIME
+					upgradePolicyState = ocm.UpgradePolicyState{
+						Value:       string(OcmStateDelayed),
+						Description: "Cluster upgrade to version 4.4.5 is experiencing a delay as a change freeze is in effect",
+					}
+				})
+				It("notifies the upgrade start once the freeze ends", func() {
+					gomock.InOrder(
+						mockOcmClient.EXPECT().GetCluster().Return(&cluster, nil),
+						mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+						mockOcmClient.EXPECT().GetClusterUpgradePolicies(TEST_CLUSTER_ID).Return(&upgradePolicyListResponse, nil),
+						mockOcmClient.EXPECT().GetClusterUpgradePolicyState(TEST_POLICY_ID, TEST_CLUSTER_ID).Return(&upgradePolicyState, nil),
+						mockOcmClient.EXPECT().SetState(string(OcmStateStarted), TEST_STATE_DESCRIPTION, TEST_POLICY_ID, TEST_CLUSTER_ID),
+					)
+					err := notifier.NotifyState(MuoStateStarted, TEST_STATE_DESCRIPTION)
+					Expect(err).To(BeNil())
+				})
+			})
+
+			Context("When a scheduled policy is held by a freeze period", func() {
+				var uc upgradev1alpha1.UpgradeConfig
+				BeforeEach(func() {
+					uc = *testStructs.NewUpgradeConfigBuilder().WithNamespacedName(upgradeConfigName).WithPhase(upgradev1alpha1.UpgradePhasePending).GetUpgradeConfig()
+					uc.Spec.Desired.Version = TEST_UPGRADEPOLICY_VERSION
+					uc.Spec.UpgradeAt = TEST_UPGRADEPOLICY_TIME
+					upgradePolicyState.Value = string(OcmStateScheduled)
+				})
+				It("notifies the freeze as a delay", func() {
+					gomock.InOrder(
+						mockOcmClient.EXPECT().GetCluster().Return(&cluster, nil),
+						mockUpgradeConfigManager.EXPECT().Get().Return(&uc, nil),
+						mockOcmClient.EXPECT().GetClusterUpgradePolicies(TEST_CLUSTER_ID).Return(&upgradePolicyListResponse, nil),
+						mockOcmClient.EXPECT().GetClusterUpgradePolicyState(TEST_POLICY_ID, TEST_CLUSTER_ID).Return(&upgradePolicyState, nil),
+						mockOcmClient.EXPECT().SetState(string(OcmStateDelayed), TEST_STATE_DESCRIPTION, TEST_POLICY_ID, TEST_CLUSTER_ID),
+					)
+					err := notifier.NotifyState(MuoStateFrozen, TEST_STATE_DESCRIPTION)
+					Expect(err).To(BeNil())
+				})
+			})
+
 		})
 	})
 })
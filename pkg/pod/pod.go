@@ -8,6 +8,9 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/hashicorp/go-multierror"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -66,6 +69,59 @@ func DeletePods(c client.Client, logger logr.Logger, pl *corev1.PodList, ignoreA
 	}, me.ErrorOrNil()
 }
 
+// EvictResult holds fields describing the result of a pod eviction
+type EvictResult struct {
+	Message    string
+	NumEvicted int
+	// Pods whose eviction was refused, to be retried, because it would violate a PodDisruptionBudget
+	NumBlocked int
+}
+
+// EvictPods attempts to evict a given PodList through the Eviction API, so that the
+// PodDisruptionBudgets of the pods are respected, and returns an EvictResult and error.
+// Pods that are already being deleted are ignored. A pod whose eviction is refused with
+// 429 Too Many Requests is counted as blocked rather than failed, as its eviction can be
+// retried once its PodDisruptionBudget allows.
+func EvictPods(c client.Client, logger logr.Logger, pl *corev1.PodList) (*EvictResult, error) {
+	me := &multierror.Error{}
+	var podsEvicted []string
+	var podsBlocked []string
+	for _, p := range pl.Items {
+		p := p
+		if p.DeletionTimestamp != nil {
+			logger.Info(fmt.Sprintf("Ignoring evicting pod %v because it is already being deleted", p.Name))
+			continue
+		}
+		logger.Info(fmt.Sprintf("Applying pod eviction drain strategy to pod %v/%v", p.Namespace, p.Name))
+		eviction := &policyv1.Eviction{
+			ObjectMeta: metav1.ObjectMeta{Namespace: p.Namespace, Name: p.Name},
+		}
+		err := c.SubResource("eviction").Create(context.TODO(), &p, eviction)
+		switch {
+		case err == nil:
+			podsEvicted = append(podsEvicted, p.Name)
+		case apierrors.IsTooManyRequests(err):
+			logger.Info(fmt.Sprintf("eviction of the pod %v/%v is blocked by a PodDisruptionBudget, it will be retried", p.Namespace, p.Name))
+			podsBlocked = append(podsBlocked, p.Name)
+		case apierrors.IsNotFound(err):
+			logger.Info(fmt.Sprintf("pod %v/%v is already gone", p.Namespace, p.Name))
+		default:
+			logger.Error(err, fmt.Sprintf("failed to evict the pod %v/%v", p.Namespace, p.Name))
+			me = multierror.Append(err, me)
+		}
+	}
+
+	message := fmt.Sprintf("Pod(s) %s have been evicted", strings.Join(podsEvicted, ","))
+	if len(podsBlocked) > 0 {
+		message = fmt.Sprintf("%s, eviction of pod(s) %s is blocked by PodDisruptionBudgets", message, strings.Join(podsBlocked, ","))
+	}
+	return &EvictResult{
+		Message:    message,
+		NumEvicted: len(podsEvicted),
+		NumBlocked: len(podsBlocked),
+	}, me.ErrorOrNil()
+}
+
 // RemoveFinalizersResult is a type that describes the result of removing a finalizer
 type RemoveFinalizersResult struct {
 	Message    string
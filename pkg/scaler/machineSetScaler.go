@@ -22,21 +22,49 @@ const (
 	LABEL_UPGRADE = "upgrade.managed.openshift.io"
 	// LABEL_MACHINESET is the label used for machinesets
 	LABEL_MACHINESET = "machine.openshift.io/cluster-api-machineset"
+	// LABEL_MACHINE_ROLE is the label of the role of a machine
+	LABEL_MACHINE_ROLE = "machine.openshift.io/cluster-api-machine-role"
 	// MACHINE_API_NAMESPACE is the namespace of the machine api
 	MACHINE_API_NAMESPACE = "openshift-machine-api"
 )
 
-type machineSetScaler struct{}
+type machineSetScaler struct {
+	// Select the original worker MachineSets by the role of the machines they create, rather than
+	// by the machine pool label set by Hive
+	selectByMachineRole bool
+}
 
-// CanScale will check if the MachineSet scaler is capable of performing a scale-out event
-func (s *machineSetScaler) CanScale(c client.Client, logger logr.Logger) (bool, error) {
+// getWorkerMachineSets returns the original worker MachineSets that can be scaled
+func (s *machineSetScaler) getWorkerMachineSets(c client.Client) (*machineapi.MachineSetList, error) {
 	originalMachineSets := &machineapi.MachineSetList{}
+	if !s.selectByMachineRole {
+		err := c.List(context.TODO(), originalMachineSets, []client.ListOption{
+			client.InNamespace(MACHINE_API_NAMESPACE),
+			client.MatchingLabels{"hive.openshift.io/machine-pool": "worker"},
+		}...)
+		return originalMachineSets, err
+	}
 
-	// Do we have an original "worker" machineset that can be scaled?
-	err := c.List(context.TODO(), originalMachineSets, []client.ListOption{
+	machineSets := &machineapi.MachineSetList{}
+	err := c.List(context.TODO(), machineSets, []client.ListOption{
 		client.InNamespace(MACHINE_API_NAMESPACE),
-		client.MatchingLabels{"hive.openshift.io/machine-pool": "worker"},
+		NotMatchingLabels{LABEL_UPGRADE: "true"},
 	}...)
+	if err != nil {
+		return nil, err
+	}
+	for _, ms := range machineSets.Items {
+		if ms.Spec.Template.ObjectMeta.Labels[LABEL_MACHINE_ROLE] == "worker" {
+			originalMachineSets.Items = append(originalMachineSets.Items, ms)
+		}
+	}
+	return originalMachineSets, nil
+}
+
+// CanScale will check if the MachineSet scaler is capable of performing a scale-out event
+func (s *machineSetScaler) CanScale(c client.Client, logger logr.Logger) (bool, error) {
+	// Do we have an original "worker" machineset that can be scaled?
+	originalMachineSets, err := s.getWorkerMachineSets(c)
 	if err != nil {
 		logger.Error(err, "failed to get original machinesets")
 		return false, err
@@ -50,6 +78,16 @@ func (s *machineSetScaler) CanScale(c client.Client, logger logr.Logger) (bool,
 	return true, nil
 }
 
+// ScaleUpNodeCount returns how many extra nodes EnsureScaleUpNodes adds to the cluster, which is
+// one for each original worker MachineSet
+func (s *machineSetScaler) ScaleUpNodeCount(c client.Client) (int, error) {
+	originalMachineSets, err := s.getWorkerMachineSets(c)
+	if err != nil {
+		return 0, err
+	}
+	return len(originalMachineSets.Items), nil
+}
+
 // EnsureScaleUpNodes will create a new MachineSet with 1 extra replicas for workers in every region and report when the nodes are ready.
 func (s *machineSetScaler) EnsureScaleUpNodes(c client.Client, timeOut time.Duration, logger logr.Logger) (bool, error) {
 	upgradeMachinesets := &machineapi.MachineSetList{}
@@ -62,12 +100,7 @@ func (s *machineSetScaler) EnsureScaleUpNodes(c client.Client, timeOut time.Dura
 		logger.Error(err, "failed to get upgrade extra machinesets")
 		return false, err
 	}
-	originalMachineSets := &machineapi.MachineSetList{}
-
-	err = c.List(context.TODO(), originalMachineSets, []client.ListOption{
-		client.InNamespace(MACHINE_API_NAMESPACE),
-		client.MatchingLabels{"hive.openshift.io/machine-pool": "worker"},
-	}...)
+	originalMachineSets, err := s.getWorkerMachineSets(c)
 	if err != nil {
 		logger.Error(err, "failed to get original machinesets")
 		return false, err
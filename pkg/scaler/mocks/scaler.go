@@ -86,3 +86,18 @@ func (mr *MockScalerMockRecorder) EnsureScaleUpNodes(arg0, arg1, arg2 any) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureScaleUpNodes", reflect.TypeOf((*MockScaler)(nil).EnsureScaleUpNodes), arg0, arg1, arg2)
 }
+
+// ScaleUpNodeCount mocks base method.
+func (m *MockScaler) ScaleUpNodeCount(arg0 client.Client) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScaleUpNodeCount", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScaleUpNodeCount indicates an expected call of ScaleUpNodeCount.
+func (mr *MockScalerMockRecorder) ScaleUpNodeCount(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScaleUpNodeCount", reflect.TypeOf((*MockScaler)(nil).ScaleUpNodeCount), arg0)
+}
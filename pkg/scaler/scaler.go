@@ -13,6 +13,7 @@ import (
 //go:generate mockgen -destination=mocks/scaler.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/scaler Scaler
 type Scaler interface {
 	CanScale(client.Client, logr.Logger) (bool, error)
+	ScaleUpNodeCount(client.Client) (int, error)
 	EnsureScaleUpNodes(client.Client, time.Duration, logr.Logger) (bool, error)
 	EnsureScaleDownNodes(client.Client, drain.NodeDrainStrategy, logr.Logger) (bool, error)
 }
@@ -22,6 +23,12 @@ func NewScaler() Scaler {
 	return &machineSetScaler{}
 }
 
+// NewMachineRoleScaler returns a Scaler that scales the MachineSets creating worker machines, for
+// clusters whose MachineSets are not labelled by Hive
+func NewMachineRoleScaler() Scaler {
+	return &machineSetScaler{selectByMachineRole: true}
+}
+
 type scaleTimeOutError struct {
 	message string
 }
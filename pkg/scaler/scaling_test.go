@@ -81,7 +81,65 @@ var _ = Describe("Node scaling tests", func() {
 				Expect(result).To(BeTrue())
 			})
 		})
+		Context("and worker machinesets are selected by machine role", func() {
+			BeforeEach(func() {
+				scaler = NewMachineRoleScaler()
+			})
+			It("will only consider machinesets creating worker machines", func() {
+				machineSets := &machineapi.MachineSetList{
+					Items: []machineapi.MachineSet{
+						{
+							ObjectMeta: metav1.ObjectMeta{Name: "infra", Namespace: MACHINE_API_NAMESPACE},
+							Spec: machineapi.MachineSetSpec{Template: machineapi.MachineTemplateSpec{
+								ObjectMeta: machineapi.ObjectMeta{Labels: map[string]string{LABEL_MACHINE_ROLE: "infra"}},
+							}},
+						},
+					},
+				}
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
+					client.InNamespace(MACHINE_API_NAMESPACE), NotMatchingLabels{LABEL_UPGRADE: "true"},
+				}).SetArg(1, *machineSets)
+				result, err := scaler.CanScale(mockKubeClient, logger)
+				Expect(err).To(BeNil())
+				Expect(result).To(BeFalse())
+			})
+			It("will flag that scaling is possible with a worker machineset", func() {
+				machineSets := &machineapi.MachineSetList{
+					Items: []machineapi.MachineSet{
+						{
+							ObjectMeta: metav1.ObjectMeta{Name: "worker-a", Namespace: MACHINE_API_NAMESPACE},
+							Spec: machineapi.MachineSetSpec{Template: machineapi.MachineTemplateSpec{
+								ObjectMeta: machineapi.ObjectMeta{Labels: map[string]string{LABEL_MACHINE_ROLE: "worker"}},
+							}},
+						},
+					},
+				}
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
+					client.InNamespace(MACHINE_API_NAMESPACE), NotMatchingLabels{LABEL_UPGRADE: "true"},
+				}).SetArg(1, *machineSets)
+				result, err := scaler.CanScale(mockKubeClient, logger)
+				Expect(err).To(BeNil())
+				Expect(result).To(BeTrue())
+			})
+		})
 	})
+	Context("When counting the nodes a scale-out adds", func() {
+		It("will count one node for each worker machineset", func() {
+			originalMachineSets := &machineapi.MachineSetList{
+				Items: []machineapi.MachineSet{
+					{ObjectMeta: metav1.ObjectMeta{Name: "worker-a", Namespace: MACHINE_API_NAMESPACE}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "worker-b", Namespace: MACHINE_API_NAMESPACE}},
+				},
+			}
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
+				client.InNamespace(MACHINE_API_NAMESPACE), client.MatchingLabels{"hive.openshift.io/machine-pool": "worker"},
+			}).SetArg(1, *originalMachineSets)
+			result, err := scaler.ScaleUpNodeCount(mockKubeClient)
+			Expect(err).To(BeNil())
+			Expect(result).To(Equal(2))
+		})
+	})
+
 	Context("When the upgrade is scaling out workers", func() {
 		var upgradeMachinesets *machineapi.MachineSetList
 		var originalMachineSets *machineapi.MachineSetList
@@ -0,0 +1,131 @@
+package scheduler
+
+import (
+	"fmt"
+	"time"
+)
+
+const freezeDateLayout = "01-02"
+
+// FreezePeriod is a period of time during which upgrades must not start. It is either a one-off
+// period between two absolute times, or a period that recurs every year between two dates.
+type FreezePeriod struct {
+	// Reason is reported in status and notifications while the freeze is in effect
+	Reason string `yaml:"reason"`
+	// Start is the RFC3339 time at which a one-off freeze starts
+	Start string `yaml:"start"`
+	// End is the RFC3339 time at which a one-off freeze ends
+	End string `yaml:"end"`
+	// Recurring describes a freeze that recurs every year
+	Recurring *RecurringFreeze `yaml:"recurring"`
+}
+
+// RecurringFreeze is a freeze that recurs every year between two dates
+type RecurringFreeze struct {
+	// StartDate is the first day of the freeze, in MM-DD format
+	StartDate string `yaml:"startDate"`
+	// EndDate is the last day of the freeze, in MM-DD format. A freeze with an EndDate
+	// before its StartDate ends in the following year.
+	EndDate string `yaml:"endDate"`
+	// TimeZone is the IANA time zone the dates are expressed in, defaulting to UTC
+	TimeZone string `yaml:"timeZone"`
+}
+
+// FreezeResult is a type that holds fields describing whether a freeze is in effect
+type FreezeResult struct {
+	IsFrozen bool
+	Reason   string
+	Until    time.Time
+}
+
+// ValidateFreezePeriods returns an error describing the first invalid freeze period found, if any
+func ValidateFreezePeriods(periods []FreezePeriod) error {
+	for i, p := range periods {
+		_, err := p.occurrences(time.Now())
+		if err != nil {
+			return fmt.Errorf("freezePeriods[%d]: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// IsFrozen returns whether any of the freeze periods is in effect at the given time
+func (s *scheduler) IsFrozen(periods []FreezePeriod, t time.Time) FreezeResult {
+	result := FreezeResult{IsFrozen: false}
+	for _, p := range periods {
+		occurrences, err := p.occurrences(t)
+		if err != nil {
+			logger.Error(err, "ignoring invalid freeze period", "reason", p.Reason)
+			continue
+		}
+		for _, o := range occurrences {
+			if t.Before(o.start) || !t.Before(o.end) {
+				continue
+			}
+			// Report the freeze that remains in effect for longest
+			if !result.IsFrozen || o.end.After(result.Until) {
+				result = FreezeResult{IsFrozen: true, Reason: p.Reason, Until: o.end}
+			}
+		}
+	}
+	return result
+}
+
+type freezeOccurrence struct {
+	start time.Time
+	end   time.Time
+}
+
+// occurrences returns the occurrences of the freeze period that may be in effect at the given time
+func (p FreezePeriod) occurrences(t time.Time) ([]freezeOccurrence, error) {
+	if p.Recurring == nil {
+		if p.Start == "" || p.End == "" {
+			return nil, fmt.Errorf("either start and end, or recurring, must be specified")
+		}
+		start, err := time.Parse(time.RFC3339, p.Start)
+		if err != nil {
+			return nil, fmt.Errorf("start %q must be a RFC3339 timestamp", p.Start)
+		}
+		end, err := time.Parse(time.RFC3339, p.End)
+		if err != nil {
+			return nil, fmt.Errorf("end %q must be a RFC3339 timestamp", p.End)
+		}
+		if !end.After(start) {
+			return nil, fmt.Errorf("end %q must be after start %q", p.End, p.Start)
+		}
+		return []freezeOccurrence{{start: start, end: end}}, nil
+	}
+
+	if p.Start != "" || p.End != "" {
+		return nil, fmt.Errorf("start and end can't be specified for a recurring freeze")
+	}
+	r := p.Recurring
+	startDate, err := time.Parse(freezeDateLayout, r.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("recurring.startDate %q must be in MM-DD format", r.StartDate)
+	}
+	endDate, err := time.Parse(freezeDateLayout, r.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("recurring.endDate %q must be in MM-DD format", r.EndDate)
+	}
+	location, err := time.LoadLocation(r.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown recurring.timeZone %q", r.TimeZone)
+	}
+
+	// An occurrence that started in the previous year may still be in effect
+	year := t.In(location).Year()
+	occurrences := make([]freezeOccurrence, 0, 2)
+	for _, y := range []int{year - 1, year} {
+		endYear := y
+		if endDate.Before(startDate) {
+			endYear++
+		}
+		occurrences = append(occurrences, freezeOccurrence{
+			start: time.Date(y, startDate.Month(), startDate.Day(), 0, 0, 0, 0, location),
+			// The freeze lasts until the end of its last day
+			end: time.Date(endYear, endDate.Month(), endDate.Day()+1, 0, 0, 0, 0, location),
+		})
+	}
+	return occurrences, nil
+}
@@ -0,0 +1,103 @@
+package scheduler
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Freeze periods", func() {
+	var (
+		s        *scheduler
+		mustTime = func(value string) time.Time {
+			t, err := time.Parse(time.RFC3339, value)
+			Expect(err).NotTo(HaveOccurred())
+			return t
+		}
+	)
+
+	BeforeEach(func() {
+		s = &scheduler{}
+	})
+
+	Context("When a one-off freeze period is specified", func() {
+		periods := []FreezePeriod{
+			{Reason: "End of quarter", Start: "2024-03-25T00:00:00Z", End: "2024-04-02T00:00:00Z"},
+		}
+
+		It("should be frozen during the period", func() {
+			result := s.IsFrozen(periods, mustTime("2024-03-30T12:00:00Z"))
+			Expect(result.IsFrozen).To(BeTrue())
+			Expect(result.Reason).To(Equal("End of quarter"))
+			Expect(result.Until).To(BeTemporally("==", mustTime("2024-04-02T00:00:00Z")))
+		})
+
+		It("should not be frozen outside the period", func() {
+			Expect(s.IsFrozen(periods, mustTime("2024-03-24T23:59:59Z")).IsFrozen).To(BeFalse())
+			Expect(s.IsFrozen(periods, mustTime("2024-04-02T00:00:00Z")).IsFrozen).To(BeFalse())
+		})
+	})
+
+	Context("When a recurring freeze period is specified", func() {
+		periods := []FreezePeriod{
+			{Reason: "Holiday change freeze", Recurring: &RecurringFreeze{StartDate: "12-20", EndDate: "01-02", TimeZone: "Europe/Berlin"}},
+		}
+
+		It("should be frozen at the start of the period in its time zone", func() {
+			result := s.IsFrozen(periods, mustTime("2024-12-19T23:30:00Z"))
+			Expect(result.IsFrozen).To(BeTrue())
+			Expect(result.Until).To(BeTemporally("==", mustTime("2025-01-02T23:00:00Z")))
+		})
+
+		It("should be frozen in the new year", func() {
+			result := s.IsFrozen(periods, mustTime("2025-01-02T12:00:00Z"))
+			Expect(result.IsFrozen).To(BeTrue())
+			Expect(result.Until).To(BeTemporally("==", mustTime("2025-01-02T23:00:00Z")))
+		})
+
+		It("should not be frozen outside the period", func() {
+			Expect(s.IsFrozen(periods, mustTime("2024-12-19T22:30:00Z")).IsFrozen).To(BeFalse())
+			Expect(s.IsFrozen(periods, mustTime("2025-06-01T00:00:00Z")).IsFrozen).To(BeFalse())
+		})
+	})
+
+	Context("When overlapping freeze periods are in effect", func() {
+		It("should report the period that ends last", func() {
+			periods := []FreezePeriod{
+				{Reason: "Short", Start: "2024-03-25T00:00:00Z", End: "2024-03-27T00:00:00Z"},
+				{Reason: "Long", Start: "2024-03-20T00:00:00Z", End: "2024-04-02T00:00:00Z"},
+			}
+			result := s.IsFrozen(periods, mustTime("2024-03-26T00:00:00Z"))
+			Expect(result.IsFrozen).To(BeTrue())
+			Expect(result.Reason).To(Equal("Long"))
+		})
+	})
+
+	Context("When validating freeze periods", func() {
+		It("should accept valid periods", func() {
+			Expect(ValidateFreezePeriods([]FreezePeriod{
+				{Start: "2024-03-25T00:00:00Z", End: "2024-04-02T00:00:00Z"},
+				{Recurring: &RecurringFreeze{StartDate: "12-20", EndDate: "01-02"}},
+			})).To(Succeed())
+		})
+
+		It("should reject a period that ends before it starts", func() {
+			Expect(ValidateFreezePeriods([]FreezePeriod{
+				{Start: "2024-04-02T00:00:00Z", End: "2024-03-25T00:00:00Z"},
+			})).NotTo(Succeed())
+		})
+
+		It("should reject a period that is both one-off and recurring", func() {
+			Expect(ValidateFreezePeriods([]FreezePeriod{
+				{Start: "2024-03-25T00:00:00Z", End: "2024-04-02T00:00:00Z", Recurring: &RecurringFreeze{StartDate: "12-20", EndDate: "01-02"}},
+			})).NotTo(Succeed())
+		})
+
+		It("should reject a malformed recurring date", func() {
+			Expect(ValidateFreezePeriods([]FreezePeriod{
+				{Recurring: &RecurringFreeze{StartDate: "20-12", EndDate: "01-02"}},
+			})).NotTo(Succeed())
+		})
+	})
+})
@@ -41,6 +41,20 @@ func (m *MockScheduler) EXPECT() *MockSchedulerMockRecorder {
 	return m.recorder
 }
 
+// IsFrozen mocks base method.
+func (m *MockScheduler) IsFrozen(arg0 []scheduler.FreezePeriod, arg1 time.Time) scheduler.FreezeResult {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsFrozen", arg0, arg1)
+	ret0, _ := ret[0].(scheduler.FreezeResult)
+	return ret0
+}
+
+// IsFrozen indicates an expected call of IsFrozen.
+func (mr *MockSchedulerMockRecorder) IsFrozen(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsFrozen", reflect.TypeOf((*MockScheduler)(nil).IsFrozen), arg0, arg1)
+}
+
 // IsReadyToUpgrade mocks base method.
 func (m *MockScheduler) IsReadyToUpgrade(arg0 *v1alpha1.UpgradeConfig, arg1 time.Duration) scheduler.SchedulerResult {
 	m.ctrl.T.Helper()
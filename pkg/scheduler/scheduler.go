@@ -18,6 +18,7 @@ var (
 //go:generate mockgen -destination=mocks/mockScheduler.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/scheduler Scheduler
 type Scheduler interface {
 	IsReadyToUpgrade(*upgradev1alpha1.UpgradeConfig, time.Duration) SchedulerResult
+	IsFrozen([]FreezePeriod, time.Time) FreezeResult
 }
 
 type scheduler struct{}
@@ -29,29 +30,66 @@ func NewScheduler() Scheduler {
 
 // SchedulerResult is a type that holds fields describing a schedulers result
 type SchedulerResult struct {
-	IsReady          bool
-	IsBreached       bool
-	TimeUntilUpgrade time.Duration
+	IsReady           bool
+	IsBreached        bool
+	TimeUntilUpgrade  time.Duration
+	NextEligibleStart time.Time
 }
 
+// IsReadyToUpgrade returns whether the upgrade may start now, and if not, when it next may.
+// An upgrade that has been able to start for longer than the timeOut is reported as breached.
 func (s *scheduler) IsReadyToUpgrade(upgradeConfig *upgradev1alpha1.UpgradeConfig, timeOut time.Duration) SchedulerResult {
-	upgradeTime, err := time.Parse(time.RFC3339, upgradeConfig.Spec.UpgradeAt)
+	return isReadyToUpgradeAt(upgradeConfig, timeOut, time.Now())
+}
+
+// isReadyToUpgradeAt returns whether the upgrade may start at the given time, and if not, when it next may
+func isReadyToUpgradeAt(upgradeConfig *upgradev1alpha1.UpgradeConfig, timeOut time.Duration, now time.Time) SchedulerResult {
+	startTime, err := eligibleFrom(upgradeConfig, now)
 	if err != nil {
-		logger.Error(err, "failed to parse spec.upgradeAt", upgradeConfig.Spec.UpgradeAt)
+		logger.Error(err, "failed to determine when the upgrade may start", "upgradeAt", upgradeConfig.Spec.UpgradeAt)
 		return SchedulerResult{IsReady: false, IsBreached: false, TimeUntilUpgrade: 0}
 	}
-	now := time.Now()
-	if now.After(upgradeTime) {
+
+	if now.After(startTime) {
 		// Is the current time within the allowable upgrade window
-		if upgradeTime.Add(timeOut).After(now) {
-			return SchedulerResult{IsReady: true, IsBreached: false, TimeUntilUpgrade: 0}
+		if startTime.Add(timeOut).After(now) {
+			return SchedulerResult{IsReady: true, IsBreached: false, TimeUntilUpgrade: 0, NextEligibleStart: now}
 		}
-
-		return SchedulerResult{IsReady: true, IsBreached: true, TimeUntilUpgrade: 0}
+		return SchedulerResult{IsReady: true, IsBreached: true, TimeUntilUpgrade: 0, NextEligibleStart: now}
 	}
 
 	// It hasn't reached the upgrade window yet
-	pendingTime := upgradeTime.Sub(now)
+	pendingTime := startTime.Sub(now)
 	logger.Info(fmt.Sprintf("Upgrade is scheduled in %d hours %d mins", int(pendingTime.Hours()), int(pendingTime.Minutes())-(int(pendingTime.Hours())*60)))
-	return SchedulerResult{IsReady: false, IsBreached: false, TimeUntilUpgrade: pendingTime}
+	return SchedulerResult{IsReady: false, IsBreached: false, TimeUntilUpgrade: pendingTime, NextEligibleStart: startTime}
+}
+
+// eligibleFrom returns the time from which the upgrade has been, or will next be, continuously
+// able to start, as of the given time. This is the upgradeAt time, or the opening of the
+// maintenance window occurrence that is open at or opens next after the given time, whichever
+// is later.
+func eligibleFrom(upgradeConfig *upgradev1alpha1.UpgradeConfig, t time.Time) (time.Time, error) {
+	upgradeTime, err := time.Parse(time.RFC3339, upgradeConfig.Spec.UpgradeAt)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse spec.upgradeAt: %v", err)
+	}
+	if len(upgradeConfig.Spec.MaintenanceWindows) == 0 {
+		return upgradeTime, nil
+	}
+
+	windows, err := parseWindows(upgradeConfig.Spec.MaintenanceWindows)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if t.Before(upgradeTime) {
+		t = upgradeTime
+	}
+	open, _ := nextWindow(windows, t)
+	if open.IsZero() {
+		return time.Time{}, fmt.Errorf("no maintenance window opens after %s", t.Format(time.RFC3339))
+	}
+	if open.Before(upgradeTime) {
+		return upgradeTime, nil
+	}
+	return open, nil
 }
@@ -34,6 +34,116 @@ var _ = Describe("Scheduler", func() {
 		Expect(result.IsReady).To(BeTrue())
 		Expect(result.IsBreached).To(BeTrue())
 	})
+
+	Context("When maintenance windows are specified", func() {
+		var (
+			s        *scheduler
+			allDays  = []upgradev1alpha1.Weekday{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
+			midweek  = []upgradev1alpha1.Weekday{"Tuesday", "Wednesday", "Thursday"}
+			mustTime = func(value string) time.Time {
+				t, err := time.Parse(time.RFC3339, value)
+				Expect(err).NotTo(HaveOccurred())
+				return t
+			}
+		)
+
+		BeforeEach(func() {
+			s = &scheduler{}
+			// 2024-01-01 is a Monday
+			upgradeConfig = testUpgradeConfig(true, "2024-01-01T00:00:00Z")
+			upgradeConfig.Spec.MaintenanceWindows = []upgradev1alpha1.MaintenanceWindow{
+				{Days: midweek, StartTime: "02:00", EndTime: "06:00", TimeZone: "Europe/Berlin"},
+			}
+		})
+
+		It("should start at the next window opening in the window's time zone", func() {
+			result := isReadyToUpgradeAt(upgradeConfig, 60*time.Minute, mustTime("2024-01-01T10:00:00Z"))
+			Expect(result.IsReady).To(BeFalse())
+			Expect(result.NextEligibleStart).To(BeTemporally("==", mustTime("2024-01-02T01:00:00Z")))
+			Expect(result.TimeUntilUpgrade).To(Equal(15 * time.Hour))
+		})
+
+		It("should follow daylight saving time in the window's time zone", func() {
+			upgradeConfig.Spec.UpgradeAt = "2024-07-01T00:00:00Z"
+			result := isReadyToUpgradeAt(upgradeConfig, 60*time.Minute, mustTime("2024-07-01T10:00:00Z"))
+			Expect(result.IsReady).To(BeFalse())
+			Expect(result.NextEligibleStart).To(BeTemporally("==", mustTime("2024-07-02T00:00:00Z")))
+		})
+
+		It("should start immediately when a window is open", func() {
+			now := mustTime("2024-01-03T02:00:00Z")
+			result := isReadyToUpgradeAt(upgradeConfig, 120*time.Minute, now)
+			Expect(result.IsReady).To(BeTrue())
+			Expect(result.IsBreached).To(BeFalse())
+			Expect(result.NextEligibleStart).To(BeTemporally("==", now))
+		})
+
+		It("should indicate a breach once the window has been open for longer than the timeout", func() {
+			result := isReadyToUpgradeAt(upgradeConfig, 30*time.Minute, mustTime("2024-01-03T02:00:00Z"))
+			Expect(result.IsReady).To(BeTrue())
+			Expect(result.IsBreached).To(BeTrue())
+		})
+
+		It("should wait for the following week once this week's windows have closed", func() {
+			result := isReadyToUpgradeAt(upgradeConfig, 60*time.Minute, mustTime("2024-01-04T06:00:00Z"))
+			Expect(result.IsReady).To(BeFalse())
+			Expect(result.NextEligibleStart).To(BeTemporally("==", mustTime("2024-01-09T01:00:00Z")))
+		})
+
+		It("should not start before upgradeAt even if a window is open", func() {
+			upgradeConfig.Spec.UpgradeAt = "2024-01-03T03:00:00Z"
+			result := isReadyToUpgradeAt(upgradeConfig, 60*time.Minute, mustTime("2024-01-03T02:00:00Z"))
+			Expect(result.IsReady).To(BeFalse())
+			Expect(result.NextEligibleStart).To(BeTemporally("==", mustTime("2024-01-03T03:00:00Z")))
+		})
+
+		It("should support windows that span midnight", func() {
+			upgradeConfig.Spec.MaintenanceWindows = []upgradev1alpha1.MaintenanceWindow{
+				{Days: []upgradev1alpha1.Weekday{"Friday"}, StartTime: "22:00", EndTime: "02:00"},
+			}
+			result := isReadyToUpgradeAt(upgradeConfig, 240*time.Minute, mustTime("2024-01-06T01:00:00Z"))
+			Expect(result.IsReady).To(BeTrue())
+			Expect(result.IsBreached).To(BeFalse())
+		})
+
+		It("should pick the earliest of several windows", func() {
+			upgradeConfig.Spec.MaintenanceWindows = append(upgradeConfig.Spec.MaintenanceWindows,
+				upgradev1alpha1.MaintenanceWindow{Days: []upgradev1alpha1.Weekday{"Monday"}, StartTime: "18:00", EndTime: "19:00", TimeZone: "America/New_York"})
+			result := isReadyToUpgradeAt(upgradeConfig, 60*time.Minute, mustTime("2024-01-01T10:00:00Z"))
+			Expect(result.IsReady).To(BeFalse())
+			Expect(result.NextEligibleStart).To(BeTemporally("==", mustTime("2024-01-01T23:00:00Z")))
+		})
+
+		It("should not be ready to upgrade with an invalid window", func() {
+			upgradeConfig.Spec.MaintenanceWindows[0].TimeZone = "Europe/Atlantis"
+			result := isReadyToUpgradeAt(upgradeConfig, 60*time.Minute, mustTime("2024-01-03T02:00:00Z"))
+			Expect(result.IsReady).To(BeFalse())
+			Expect(result.NextEligibleStart.IsZero()).To(BeTrue())
+		})
+
+		It("should be ready to upgrade if a window is open now", func() {
+			now := time.Now().UTC()
+			upgradeConfig = testUpgradeConfig(true, now.Add(-10*time.Minute).Format(time.RFC3339))
+			upgradeConfig.Spec.MaintenanceWindows = []upgradev1alpha1.MaintenanceWindow{
+				{Days: allDays, StartTime: now.Add(-time.Hour).Format("15:04"), EndTime: now.Add(time.Hour).Format("15:04")},
+			}
+			result := s.IsReadyToUpgrade(upgradeConfig, 60*time.Minute)
+			Expect(result.IsReady).To(BeTrue())
+			Expect(result.IsBreached).To(BeFalse())
+		})
+
+		It("should not be ready to upgrade until the next window opens", func() {
+			now := time.Now().UTC()
+			upgradeConfig = testUpgradeConfig(true, now.Add(-10*time.Minute).Format(time.RFC3339))
+			upgradeConfig.Spec.MaintenanceWindows = []upgradev1alpha1.MaintenanceWindow{
+				{Days: allDays, StartTime: now.Add(2 * time.Hour).Format("15:04"), EndTime: now.Add(3 * time.Hour).Format("15:04")},
+			}
+			result := s.IsReadyToUpgrade(upgradeConfig, 60*time.Minute)
+			Expect(result.IsReady).To(BeFalse())
+			Expect(result.TimeUntilUpgrade).To(BeNumerically("~", 2*time.Hour, time.Minute))
+			Expect(result.NextEligibleStart).To(BeTemporally("~", now.Add(2*time.Hour), time.Minute))
+		})
+	})
 })
 
 func testUpgradeConfig(proceed bool, upgradeAt string) *upgradev1alpha1.UpgradeConfig {
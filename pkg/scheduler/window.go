@@ -0,0 +1,136 @@
+package scheduler
+
+import (
+	"fmt"
+	"time"
+	// Embed the IANA time zone database so that window time zones resolve
+	// regardless of the zoneinfo available in the operator image
+	_ "time/tzdata"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+)
+
+const windowTimeLayout = "15:04"
+
+var weekdays = map[upgradev1alpha1.Weekday]time.Weekday{
+	"Sunday":    time.Sunday,
+	"Monday":    time.Monday,
+	"Tuesday":   time.Tuesday,
+	"Wednesday": time.Wednesday,
+	"Thursday":  time.Thursday,
+	"Friday":    time.Friday,
+	"Saturday":  time.Saturday,
+}
+
+// window is a parsed MaintenanceWindow
+type window struct {
+	days     map[time.Weekday]bool
+	start    time.Duration
+	duration time.Duration
+	location *time.Location
+}
+
+// ValidateMaintenanceWindows returns an error describing the first invalid window found, if any
+func ValidateMaintenanceWindows(windows []upgradev1alpha1.MaintenanceWindow) error {
+	_, err := parseWindows(windows)
+	return err
+}
+
+func parseWindows(windows []upgradev1alpha1.MaintenanceWindow) ([]window, error) {
+	parsed := make([]window, 0, len(windows))
+	for i, mw := range windows {
+		w, err := parseWindow(mw)
+		if err != nil {
+			return nil, fmt.Errorf("spec.maintenanceWindows[%d]: %v", i, err)
+		}
+		parsed = append(parsed, w)
+	}
+	return parsed, nil
+}
+
+func parseWindow(mw upgradev1alpha1.MaintenanceWindow) (window, error) {
+	if len(mw.Days) == 0 {
+		return window{}, fmt.Errorf("at least one day must be specified")
+	}
+	days := make(map[time.Weekday]bool, len(mw.Days))
+	for _, d := range mw.Days {
+		wd, ok := weekdays[d]
+		if !ok {
+			return window{}, fmt.Errorf("unknown day %q", d)
+		}
+		days[wd] = true
+	}
+
+	start, err := parseTimeOfDay(mw.StartTime)
+	if err != nil {
+		return window{}, fmt.Errorf("startTime %q must be in HH:MM format", mw.StartTime)
+	}
+	end, err := parseTimeOfDay(mw.EndTime)
+	if err != nil {
+		return window{}, fmt.Errorf("endTime %q must be in HH:MM format", mw.EndTime)
+	}
+	duration := end - start
+	// Windows closing at or before their start time close on the following day
+	if duration <= 0 {
+		duration += 24 * time.Hour
+	}
+
+	location, err := time.LoadLocation(mw.TimeZone)
+	if err != nil {
+		return window{}, fmt.Errorf("unknown timeZone %q", mw.TimeZone)
+	}
+
+	return window{
+		days:     days,
+		start:    start,
+		duration: duration,
+		location: location,
+	}, nil
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse(windowTimeLayout, s)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// occurrenceAt returns the opening and closing times of the occurrence of the window that
+// is open at, or opens next after, the given time.
+func (w window) occurrenceAt(t time.Time) (time.Time, time.Time) {
+	local := t.In(w.location)
+	var open, close time.Time
+	// Start from the previous day so that an occurrence spanning midnight is found
+	for offset := -1; offset <= 7; offset++ {
+		day := time.Date(local.Year(), local.Month(), local.Day()+offset, 0, 0, 0, 0, w.location)
+		if !w.days[day.Weekday()] {
+			continue
+		}
+		o := time.Date(day.Year(), day.Month(), day.Day(), int(w.start.Hours()), int(w.start.Minutes())%60, 0, 0, w.location)
+		c := o.Add(w.duration)
+		if !c.After(t) {
+			continue
+		}
+		if open.IsZero() || o.Before(open) {
+			open, close = o, c
+		}
+	}
+	return open, close
+}
+
+// nextWindow returns the opening time of the earliest window occurrence that is open at,
+// or opens next after, the given time. The occurrence's closing time is also returned.
+func nextWindow(windows []window, t time.Time) (time.Time, time.Time) {
+	var open, close time.Time
+	for _, w := range windows {
+		o, c := w.occurrenceAt(t)
+		if o.IsZero() {
+			continue
+		}
+		if open.IsZero() || o.Before(open) {
+			open, close = o, c
+		}
+	}
+	return open, close
+}
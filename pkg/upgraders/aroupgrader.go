@@ -16,7 +16,6 @@ import (
 	"github.com/openshift/managed-upgrade-operator/pkg/maintenance"
 	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
 	"github.com/openshift/managed-upgrade-operator/pkg/scaler"
-	"github.com/openshift/managed-upgrade-operator/pkg/upgradesteps"
 )
 
 // aroUpgrader is a cluster upgrader suitable for ARO clusters.
@@ -25,6 +24,28 @@ type aroUpgrader struct {
 	*clusterUpgrader
 }
 
+// aroUpgradeSteps are the default steps, in order, used by the aroUpgrader
+var aroUpgradeSteps = []upgradev1alpha1.UpgradeConditionType{
+	upgradev1alpha1.SendStartedNotification,
+	upgradev1alpha1.UpgradePreHealthCheck,
+	upgradev1alpha1.ExtDepAvailabilityCheck,
+	upgradev1alpha1.UpgradeScaleUpExtraNodes,
+	upgradev1alpha1.ControlPlaneMaintWindow,
+	upgradev1alpha1.PreUpgradeHooks,
+	upgradev1alpha1.CommenceUpgrade,
+	upgradev1alpha1.ControlPlaneUpgraded,
+	upgradev1alpha1.ControlPlaneUpgradedHooks,
+	upgradev1alpha1.RemoveControlPlaneMaintWindow,
+	upgradev1alpha1.WorkersMaintWindow,
+	upgradev1alpha1.CanaryWorkersUpgraded,
+	upgradev1alpha1.AllWorkerNodesUpgraded,
+	upgradev1alpha1.PostUpgradeHooks,
+	upgradev1alpha1.RemoveExtraScaledNodes,
+	upgradev1alpha1.RemoveMaintWindow,
+	upgradev1alpha1.PostClusterHealthCheck,
+	upgradev1alpha1.SendCompletedNotification,
+}
+
 // NewAROUpgrader creates a new instance of an aroUpgrader
 func NewAROUpgrader(c client.Client, cfm configmanager.ConfigManager, mc metrics.Metrics, notifier eventmanager.EventManager) (*aroUpgrader, error) {
 	cfg := &upgraderConfig{}
@@ -58,21 +79,9 @@ func NewAROUpgrader(c client.Client, cfm configmanager.ConfigManager, mc metrics
 		},
 	}
 
-	steps := []upgradesteps.UpgradeStep{
-		upgradesteps.Action(string(upgradev1alpha1.SendStartedNotification), au.SendStartedNotification),
-		upgradesteps.Action(string(upgradev1alpha1.UpgradePreHealthCheck), au.PreUpgradeHealthCheck),
-		upgradesteps.Action(string(upgradev1alpha1.ExtDepAvailabilityCheck), au.ExternalDependencyAvailabilityCheck),
-		upgradesteps.Action(string(upgradev1alpha1.UpgradeScaleUpExtraNodes), au.EnsureExtraUpgradeWorkers),
-		upgradesteps.Action(string(upgradev1alpha1.ControlPlaneMaintWindow), au.CreateControlPlaneMaintWindow),
-		upgradesteps.Action(string(upgradev1alpha1.CommenceUpgrade), au.CommenceUpgrade),
-		upgradesteps.Action(string(upgradev1alpha1.ControlPlaneUpgraded), au.ControlPlaneUpgraded),
-		upgradesteps.Action(string(upgradev1alpha1.RemoveControlPlaneMaintWindow), au.RemoveControlPlaneMaintWindow),
-		upgradesteps.Action(string(upgradev1alpha1.WorkersMaintWindow), au.CreateWorkerMaintWindow),
-		upgradesteps.Action(string(upgradev1alpha1.AllWorkerNodesUpgraded), au.AllWorkersUpgraded),
-		upgradesteps.Action(string(upgradev1alpha1.RemoveExtraScaledNodes), au.RemoveExtraScaledNodes),
-		upgradesteps.Action(string(upgradev1alpha1.RemoveMaintWindow), au.RemoveMaintWindow),
-		upgradesteps.Action(string(upgradev1alpha1.PostClusterHealthCheck), au.PostUpgradeHealthCheck),
-		upgradesteps.Action(string(upgradev1alpha1.SendCompletedNotification), au.SendCompletedNotification),
+	steps, err := au.buildSteps(aroUpgradeSteps)
+	if err != nil {
+		return nil, err
 	}
 	au.steps = steps
 
@@ -19,6 +19,8 @@ import (
 type ClusterUpgrader interface {
 	HealthCheck(ctx context.Context, upgradeConfig *upgradev1alpha1.UpgradeConfig, logger logr.Logger) (bool, error)
 	UpgradeCluster(ctx context.Context, upgradeConfig *upgradev1alpha1.UpgradeConfig, logger logr.Logger) (upgradev1alpha1.UpgradePhase, error)
+	CancelUpgrade(ctx context.Context, upgradeConfig *upgradev1alpha1.UpgradeConfig, logger logr.Logger) (upgradev1alpha1.UpgradePhase, error)
+	Rehearse(ctx context.Context, upgradeConfig *upgradev1alpha1.UpgradeConfig, logger logr.Logger) ([]upgradev1alpha1.RehearsalCheck, error)
 }
 
 // ClusterUpgraderBuilder enables an implementation of a ClusterUpgraderBuilder
@@ -49,6 +51,18 @@ func (cub *clusterUpgraderBuilder) NewClient(c client.Client, cfm configmanager.
 			return nil, err
 		}
 		return cu, nil
+	case upgradev1alpha1.HCP:
+		cu, err := NewHCPUpgrader(c, cfm, mc, nc)
+		if err != nil {
+			return nil, err
+		}
+		return cu, nil
+	case upgradev1alpha1.Standard:
+		cu, err := NewStandardUpgrader(c, cfm, mc, nc)
+		if err != nil {
+			return nil, err
+		}
+		return cu, nil
 	default:
 		cu, err := NewOSDUpgrader(c, cfm, mc, nc)
 		if err != nil {
@@ -0,0 +1,200 @@
+package upgraders
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	machineconfigapi "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+)
+
+const (
+	// nodeRoleLabelPrefix prefixes the labels that assign roles to a node
+	nodeRoleLabelPrefix = "node-role.kubernetes.io/"
+	// workerRoleLabel is the label of a node with the worker role
+	workerRoleLabel = nodeRoleLabelPrefix + "worker"
+	// machineConfigRoleLabel is the label of the role a MachineConfig applies to
+	machineConfigRoleLabel = "machineconfiguration.openshift.io/role"
+)
+
+// CanaryWorkersUpgraded upgrades a canary pool of workers ahead of the remaining workers, which are
+// held paused until the canary workers have upgraded and the cluster is healthy. The canary pool
+// and the role labels of its workers are left in place after the upgrade, to be reused by later
+// upgrades, as moving the workers back to the worker pool would have them rendered anew.
+func (c *clusterUpgrader) CanaryWorkersUpgraded(ctx context.Context, logger logr.Logger) (bool, error) {
+	if !c.config.Canary.IsEnabled() {
+		return true, nil
+	}
+	poolName := c.config.Canary.GetPoolName()
+
+	pool, err := c.ensureCanaryPool(ctx, poolName, logger)
+	if err != nil {
+		return false, err
+	}
+
+	canaryNodes, err := c.ensureCanaryNodes(ctx, poolName, logger)
+	if err != nil {
+		return false, err
+	}
+	if canaryNodes == 0 {
+		logger.Info("too few workers to upgrade a canary pool, upgrading all workers together")
+		return true, nil
+	}
+
+	if !isCanaryPoolUpdated(pool, canaryNodes) {
+		logger.Info(fmt.Sprintf("not all canary workers are upgraded, upgraded: %v, total: %v", pool.Status.UpdatedMachineCount, canaryNodes))
+		return false, nil
+	}
+	if pool.Status.DegradedMachineCount > 0 {
+		logger.Info(fmt.Sprintf("%v canary workers are degraded, holding the remaining workers", pool.Status.DegradedMachineCount))
+		return false, nil
+	}
+
+	// The post-upgrade checks are run without recording their report, which belongs to the
+	// PostUpgradeHealthCheck step and its soak period
+	if _, failures := c.checkHealth(healthCheckPostUpgrade, logger); len(failures) > 0 {
+		logger.Info("cluster is not healthy after upgrading the canary workers, holding the remaining workers")
+		return false, failures[0].err
+	}
+
+	logger.Info("canary workers are upgraded, releasing the remaining workers")
+	return true, nil
+}
+
+// ensureCanaryPool returns the canary MachineConfigPool, creating it if it doesn't exist. The pool
+// renders the worker MachineConfigs, along with any of its own, for the nodes with its role.
+func (c *clusterUpgrader) ensureCanaryPool(ctx context.Context, poolName string, logger logr.Logger) (*machineconfigapi.MachineConfigPool, error) {
+	pool := &machineconfigapi.MachineConfigPool{}
+	err := c.client.Get(ctx, types.NamespacedName{Name: poolName}, pool)
+	if err == nil {
+		return pool, nil
+	}
+	if !errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	pool = &machineconfigapi.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: poolName},
+		Spec: machineconfigapi.MachineConfigPoolSpec{
+			MachineConfigSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key:      machineConfigRoleLabel,
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   []string{"worker", poolName},
+				}},
+			},
+			NodeSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{nodeRoleLabelPrefix + poolName: ""},
+			},
+		},
+	}
+	err = c.client.Create(ctx, pool)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create canary machineconfigpool %s: %v", poolName, err)
+	}
+	logger.Info(fmt.Sprintf("created canary machineconfigpool %s", poolName))
+	return pool, nil
+}
+
+// ensureCanaryNodes assigns the canary role to workers until the configured number of workers are
+// in the canary pool, and returns the number of canary workers. At least one worker is always
+// left outside the canary pool.
+func (c *clusterUpgrader) ensureCanaryNodes(ctx context.Context, poolName string, logger logr.Logger) (int, error) {
+	nodes := &corev1.NodeList{}
+	err := c.client.List(ctx, nodes, client.HasLabels{workerRoleLabel})
+	if err != nil {
+		return 0, err
+	}
+
+	canaryLabel := nodeRoleLabelPrefix + poolName
+	var canaries int
+	var candidates []corev1.Node
+	for _, node := range nodes.Items {
+		if _, ok := node.Labels[canaryLabel]; ok {
+			canaries++
+		} else if isCanaryCandidate(node) {
+			candidates = append(candidates, node)
+		}
+	}
+
+	desired := c.config.Canary.GetNodeCount(len(nodes.Items))
+	if desired > len(nodes.Items)-1 {
+		desired = len(nodes.Items) - 1
+	}
+	if canaries >= desired {
+		return canaries, nil
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Name < candidates[j].Name })
+	for i := range candidates {
+		if canaries >= desired {
+			break
+		}
+		node := &candidates[i]
+		patch := client.MergeFrom(node.DeepCopy())
+		node.Labels[canaryLabel] = ""
+		err := c.client.Patch(ctx, node, patch)
+		if err != nil {
+			return canaries, fmt.Errorf("failed to add node %s to canary machineconfigpool %s: %v", node.Name, poolName, err)
+		}
+		logger.Info(fmt.Sprintf("added node %s to canary machineconfigpool %s", node.Name, poolName))
+		canaries++
+	}
+	return canaries, nil
+}
+
+// isCanaryCandidate returns whether a worker can be moved to the canary pool. Only schedulable
+// workers without any other role are moved, so no other custom pool is disturbed.
+func isCanaryCandidate(node corev1.Node) bool {
+	if node.Spec.Unschedulable {
+		return false
+	}
+	for label := range node.Labels {
+		if strings.HasPrefix(label, nodeRoleLabelPrefix) && label != workerRoleLabel {
+			return false
+		}
+	}
+	return true
+}
+
+// isCanaryPoolUpdated returns whether all the canary workers have joined the pool and been
+// updated to its latest configuration
+func isCanaryPoolUpdated(pool *machineconfigapi.MachineConfigPool, canaryNodes int) bool {
+	if pool.Status.ObservedGeneration != pool.Generation {
+		return false
+	}
+	if pool.Status.MachineCount != int32(canaryNodes) || pool.Status.UpdatedMachineCount != pool.Status.MachineCount {
+		return false
+	}
+	for _, condition := range pool.Status.Conditions {
+		if condition.Type == machineconfigapi.MachineConfigPoolUpdated {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// isHoldingPoolForCanary returns whether the named worker pool is held paused while the
+// canary workers of the current upgrade are upgraded
+func (c *clusterUpgrader) isHoldingPoolForCanary(name string) bool {
+	if !c.config.Canary.IsEnabled() || !c.hasStep(upgradev1alpha1.CanaryWorkersUpgraded) {
+		return false
+	}
+	if name == c.config.Canary.GetPoolName() {
+		return false
+	}
+	history := c.upgradeConfig.Status.History.GetHistory(c.upgradeConfig.Spec.Desired.Version)
+	if history == nil {
+		return true
+	}
+	return !history.Conditions.IsTrueFor(upgradev1alpha1.CanaryWorkersUpgraded)
+}
@@ -0,0 +1,285 @@
+package upgraders
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	machineconfigapi "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/clusterversion"
+	cvMocks "github.com/openshift/managed-upgrade-operator/pkg/clusterversion/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
+	mockMachinery "github.com/openshift/managed-upgrade-operator/pkg/machinery/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+	mockMetrics "github.com/openshift/managed-upgrade-operator/pkg/metrics/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/upgradesteps"
+	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
+)
+
+var _ = Describe("Canary workers step", func() {
+	const poolName = "worker-canary"
+
+	var (
+		logger              logr.Logger
+		mockCtrl            *gomock.Controller
+		mockMetricsClient   *mockMetrics.MockMetrics
+		mockCVClient        *cvMocks.MockClusterVersion
+		mockMachineryClient *mockMachinery.MockMachinery
+		kubeClient          client.Client
+		upgradeConfig       *upgradev1alpha1.UpgradeConfig
+		upgrader            *clusterUpgrader
+
+		newWorker = func(name string, labels map[string]string) *corev1.Node {
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{workerRoleLabel: ""}}}
+			for k, v := range labels {
+				node.Labels[k] = v
+			}
+			return node
+		}
+		newCanaryPool = func(machines int32, updated int32, degraded int32) *machineconfigapi.MachineConfigPool {
+			return &machineconfigapi.MachineConfigPool{
+				ObjectMeta: metav1.ObjectMeta{Name: poolName},
+				Status: machineconfigapi.MachineConfigPoolStatus{
+					MachineCount:         machines,
+					UpdatedMachineCount:  updated,
+					DegradedMachineCount: degraded,
+					Conditions: []machineconfigapi.MachineConfigPoolCondition{
+						{Type: machineconfigapi.MachineConfigPoolUpdated, Status: corev1.ConditionTrue},
+					},
+				},
+			}
+		}
+		build = func(objs ...client.Object) {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(machineconfigapi.AddToScheme(scheme)).To(Succeed())
+			kubeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+			upgrader.client = kubeClient
+		}
+		canaryNodeNames = func() []string {
+			nodes := &corev1.NodeList{}
+			Expect(kubeClient.List(context.TODO(), nodes, client.HasLabels{nodeRoleLabelPrefix + poolName})).To(Succeed())
+			names := []string{}
+			for _, node := range nodes.Items {
+				names = append(names, node.Name)
+			}
+			return names
+		}
+	)
+
+	BeforeEach(func() {
+		logger = logf.Log.WithName("canary step test logger")
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockMetricsClient = mockMetrics.NewMockMetrics(mockCtrl)
+		mockCVClient = cvMocks.NewMockClusterVersion(mockCtrl)
+		mockMachineryClient = mockMachinery.NewMockMachinery(mockCtrl)
+		upgradeConfig = testStructs.NewUpgradeConfigBuilder().WithPhase(upgradev1alpha1.UpgradePhaseUpgrading).GetUpgradeConfig()
+		upgrader = &clusterUpgrader{
+			metrics:       mockMetricsClient,
+			cvClient:      mockCVClient,
+			machinery:     mockMachineryClient,
+			config:        &upgraderConfig{Canary: canaryConfig{Nodes: 2}},
+			upgradeConfig: upgradeConfig,
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("When no canary is configured", func() {
+		It("has nothing to upgrade", func() {
+			upgrader.config.Canary = canaryConfig{}
+			build()
+			ok, err := upgrader.CanaryWorkersUpgraded(context.TODO(), logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			pools := &machineconfigapi.MachineConfigPoolList{}
+			Expect(kubeClient.List(context.TODO(), pools)).To(Succeed())
+			Expect(pools.Items).To(BeEmpty())
+		})
+	})
+
+	Context("When the canary pool doesn't exist", func() {
+		It("creates the pool and adds the configured number of workers to it", func() {
+			build(
+				newWorker("worker-c", nil),
+				newWorker("worker-a", nil),
+				newWorker("worker-b", nil),
+				newWorker("infra-a", map[string]string{nodeRoleLabelPrefix + "infra": ""}),
+			)
+			ok, err := upgrader.CanaryWorkersUpgraded(context.TODO(), logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeFalse())
+
+			pool := &machineconfigapi.MachineConfigPool{}
+			Expect(kubeClient.Get(context.TODO(), types.NamespacedName{Name: poolName}, pool)).To(Succeed())
+			Expect(pool.Spec.NodeSelector.MatchLabels).To(HaveKey(nodeRoleLabelPrefix + poolName))
+			Expect(pool.Spec.MachineConfigSelector.MatchExpressions[0].Values).To(ConsistOf("worker", poolName))
+			Expect(canaryNodeNames()).To(ConsistOf("worker-a", "worker-b"))
+		})
+		It("always leaves a worker outside the canary pool", func() {
+			upgrader.config.Canary = canaryConfig{Percentage: 100}
+			build(newWorker("worker-a", nil), newWorker("worker-b", nil))
+			_, err := upgrader.CanaryWorkersUpgraded(context.TODO(), logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(canaryNodeNames()).To(ConsistOf("worker-a"))
+		})
+		It("skips the canary when there is only one worker", func() {
+			build(newWorker("worker-a", nil))
+			ok, err := upgrader.CanaryWorkersUpgraded(context.TODO(), logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(canaryNodeNames()).To(BeEmpty())
+		})
+	})
+
+	Context("When the canary workers are upgrading", func() {
+		It("holds the remaining workers", func() {
+			build(
+				newCanaryPool(2, 1, 0),
+				newWorker("worker-a", map[string]string{nodeRoleLabelPrefix + poolName: ""}),
+				newWorker("worker-b", map[string]string{nodeRoleLabelPrefix + poolName: ""}),
+				newWorker("worker-c", nil),
+			)
+			ok, err := upgrader.CanaryWorkersUpgraded(context.TODO(), logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Context("When the canary workers are upgraded", func() {
+		BeforeEach(func() {
+			build(
+				newCanaryPool(2, 2, 0),
+				newWorker("worker-a", map[string]string{nodeRoleLabelPrefix + poolName: ""}),
+				newWorker("worker-b", map[string]string{nodeRoleLabelPrefix + poolName: ""}),
+				newWorker("worker-c", nil),
+			)
+		})
+		It("releases the remaining workers once the cluster is healthy", func() {
+			gomock.InOrder(
+				mockMetricsClient.EXPECT().Query(gomock.Any()).Return(&metrics.AlertResponse{}, nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.MetricsQueryFailed),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.CriticalAlertsFiring),
+				mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterOperatorsStatusFailed),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterOperatorsDegraded),
+			)
+			ok, err := upgrader.CanaryWorkersUpgraded(context.TODO(), logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+		})
+		It("does not record the post-upgrade health check report", func() {
+			postReport := upgradev1alpha1.HealthCheckReport{Stage: upgradev1alpha1.HealthCheckStagePost, Version: "4.15.9", Passed: true}
+			upgradeConfig.Status.HealthCheckReports.SetReport(postReport)
+			gomock.InOrder(
+				mockMetricsClient.EXPECT().Query(gomock.Any()).Return(&metrics.AlertResponse{}, nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.MetricsQueryFailed),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.CriticalAlertsFiring),
+				mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{"dns"}}, nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.ClusterOperatorsDegraded),
+			)
+			_, err := upgrader.CanaryWorkersUpgraded(context.TODO(), logger)
+			Expect(err).To(HaveOccurred())
+			Expect(upgradeConfig.Status.HealthCheckReports).To(Equal(upgradev1alpha1.HealthCheckReports{postReport}))
+		})
+		It("holds the remaining workers while operators are degraded", func() {
+			gomock.InOrder(
+				mockMetricsClient.EXPECT().Query(gomock.Any()).Return(&metrics.AlertResponse{}, nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.MetricsQueryFailed),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.CriticalAlertsFiring),
+				mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{"dns"}}, nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.ClusterOperatorsDegraded),
+			)
+			ok, err := upgrader.CanaryWorkersUpgraded(context.TODO(), logger)
+			Expect(err).To(MatchError(ContainSubstring("degraded operators")))
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Context("When a canary worker is degraded", func() {
+		It("holds the remaining workers", func() {
+			build(
+				newCanaryPool(2, 2, 1),
+				newWorker("worker-a", map[string]string{nodeRoleLabelPrefix + poolName: ""}),
+				newWorker("worker-b", map[string]string{nodeRoleLabelPrefix + poolName: ""}),
+				newWorker("worker-c", nil),
+			)
+			ok, err := upgrader.CanaryWorkersUpgraded(context.TODO(), logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Context("When running the upgrade steps", func() {
+		BeforeEach(func() {
+			upgrader.steps = []upgradesteps.UpgradeStep{
+				upgradesteps.Action(string(upgradev1alpha1.CanaryWorkersUpgraded), func(context.Context, logr.Logger) (bool, error) { return false, nil }),
+			}
+		})
+		It("pauses the worker pools until the canary workers are upgraded", func() {
+			gomock.InOrder(
+				mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), machinery.WorkerNodeType).Return(&machinery.UpgradingResult{
+					Pools: []machinery.PoolUpgradingResult{{Name: "infra"}, {Name: "worker"}, {Name: poolName}},
+				}, nil),
+				mockMachineryClient.EXPECT().SetPoolPaused(gomock.Any(), "infra", true).Return(nil),
+				mockMachineryClient.EXPECT().SetPoolPaused(gomock.Any(), "worker", true).Return(nil),
+				mockMachineryClient.EXPECT().SetPoolPaused(gomock.Any(), poolName, false).Return(nil),
+			)
+			_, err := upgrader.runSteps(context.TODO(), logger, upgrader.steps)
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("skips the canary pool until it is created", func() {
+			gomock.InOrder(
+				mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), machinery.WorkerNodeType).Return(&machinery.UpgradingResult{
+					Pools: []machinery.PoolUpgradingResult{{Name: "worker"}},
+				}, nil),
+				mockMachineryClient.EXPECT().SetPoolPaused(gomock.Any(), "worker", true).Return(nil),
+			)
+			_, err := upgrader.runSteps(context.TODO(), logger, upgrader.steps)
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("unpauses the worker pool once the canary workers are upgraded", func() {
+			upgradeConfig.Status.History = upgradev1alpha1.UpgradeHistories{{
+				Version: upgradeConfig.Spec.Desired.Version,
+				Conditions: upgradev1alpha1.Conditions{
+					{Type: upgradev1alpha1.CanaryWorkersUpgraded, Status: corev1.ConditionTrue},
+				},
+			}}
+			Expect(upgrader.isHoldingPoolForCanary("worker")).To(BeFalse())
+		})
+		It("does not hold the worker pool without a canary", func() {
+			upgrader.config.Canary = canaryConfig{}
+			Expect(upgrader.isHoldingPoolForCanary("worker")).To(BeFalse())
+		})
+	})
+
+	Context("When validating the canary config", func() {
+		It("sizes the canary pool by percentage", func() {
+			cfg := canaryConfig{Percentage: 10}
+			Expect(cfg.GetNodeCount(25)).To(Equal(3))
+			Expect(cfg.GetNodeCount(3)).To(Equal(1))
+		})
+		It("rejects both a number and a percentage of workers", func() {
+			cfg := canaryConfig{Nodes: 1, Percentage: 10}
+			Expect(cfg.IsValid()).To(MatchError(ContainSubstring("can't set both")))
+		})
+		It("rejects the worker pool as the canary pool", func() {
+			cfg := canaryConfig{Nodes: 1, PoolName: "worker"}
+			Expect(cfg.IsValid()).NotTo(Succeed())
+		})
+	})
+})
@@ -0,0 +1,80 @@
+package upgraders
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
+)
+
+// CancelUpgrade cancels an upgrade that has not yet commenced and returns the resulting
+// upgrade phase and any error encountered.
+// Extra compute capacity and maintenance windows created for the upgrade are removed before
+// the cancellation is notified. Callers must ensure the upgrade has not commenced, as an
+// in-progress ClusterVersion update can't be rolled back.
+func (c *clusterUpgrader) CancelUpgrade(ctx context.Context, upgradeConfig *upgradev1alpha1.UpgradeConfig, logger logr.Logger) (upgradev1alpha1.UpgradePhase, error) {
+	c.upgradeConfig = upgradeConfig
+	h := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
+	if h == nil {
+		return upgradev1alpha1.UpgradePhaseUnknown, nil
+	}
+
+	// Set up return condition
+	condition := &upgradev1alpha1.UpgradeCondition{
+		Type:    "CancelledUpgrade",
+		Status:  corev1.ConditionFalse,
+		Reason:  "Upgrade cancelled",
+		Message: "Cleaning up after the cancelled upgrade",
+	}
+	setCondition := func() {
+		h.Conditions.SetCondition(*condition)
+		upgradeConfig.Status.History.SetHistory(*h)
+	}
+
+	// Release the worker pools if the upgrade was paused
+	err := c.resumeWorkerPools()
+	if err != nil {
+		logger.Error(err, "Failed to resume the worker machineconfigpools when upgrade cancelled")
+		setCondition()
+		return h.Phase, err
+	}
+
+	// TearDown the extra machineset
+	scaledDown, err := c.scaler.EnsureScaleDownNodes(c.client, nil, logger)
+	if err != nil {
+		logger.Error(err, "Failed to scale down the temporary upgrade machine when upgrade cancelled")
+		setCondition()
+		return h.Phase, err
+	}
+	if !scaledDown {
+		logger.Info("Waiting for the temporary upgrade machine to be removed")
+		setCondition()
+		return h.Phase, nil
+	}
+
+	// End all maintenance windows created for the upgrade
+	err = c.maintenance.EndSilences("")
+	if err != nil {
+		logger.Error(err, "Failed to end maintenance windows when upgrade cancelled")
+		setCondition()
+		return h.Phase, err
+	}
+
+	// Notify of cancellation
+	err = c.notifier.Notify(notifier.MuoStateCancelled)
+	if err != nil {
+		logger.Error(err, "Failed to notify of upgrade cancellation")
+		setCondition()
+		return h.Phase, err
+	}
+
+	// Update condition state to successful
+	condition.Status = corev1.ConditionTrue
+	condition.Message = "CancelledUpgrade notification sent"
+	setCondition()
+
+	return upgradev1alpha1.UpgradePhaseCancelled, nil
+}
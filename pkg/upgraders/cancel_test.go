@@ -0,0 +1,139 @@
+package upgraders
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	"go.uber.org/mock/gomock"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	emMocks "github.com/openshift/managed-upgrade-operator/pkg/eventmanager/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
+	mockMachinery "github.com/openshift/managed-upgrade-operator/pkg/machinery/mocks"
+	mockMaintenance "github.com/openshift/managed-upgrade-operator/pkg/maintenance/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
+	mockScaler "github.com/openshift/managed-upgrade-operator/pkg/scaler/mocks"
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
+)
+
+var _ = Describe("CancelUpgrade", func() {
+	var (
+		logger logr.Logger
+		// mocks
+		mockKubeClient      *mocks.MockClient
+		mockCtrl            *gomock.Controller
+		mockMaintClient     *mockMaintenance.MockMaintenance
+		mockScalerClient    *mockScaler.MockScaler
+		mockMachineryClient *mockMachinery.MockMachinery
+		mockEMClient        *emMocks.MockEventManager
+		// upgradeconfig to be used during tests
+		upgradeConfigName types.NamespacedName
+		upgradeConfig     *upgradev1alpha1.UpgradeConfig
+
+		// upgrader to be used during tests
+		upgrader *clusterUpgrader
+	)
+
+	BeforeEach(func() {
+		upgradeConfigName = types.NamespacedName{
+			Name:      "test-upgradeconfig",
+			Namespace: "test-namespace",
+		}
+		upgradeConfig = testStructs.NewUpgradeConfigBuilder().WithNamespacedName(upgradeConfigName).WithPhase(upgradev1alpha1.UpgradePhasePending).GetUpgradeConfig()
+		upgradeConfig.Spec.Cancel = true
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		mockMaintClient = mockMaintenance.NewMockMaintenance(mockCtrl)
+		mockScalerClient = mockScaler.NewMockScaler(mockCtrl)
+		mockMachineryClient = mockMachinery.NewMockMachinery(mockCtrl)
+		mockEMClient = emMocks.NewMockEventManager(mockCtrl)
+		logger = logf.Log.WithName("cluster upgrader test logger")
+		upgrader = &clusterUpgrader{
+			client:      mockKubeClient,
+			notifier:    mockEMClient,
+			scaler:      mockScalerClient,
+			maintenance: mockMaintClient,
+			machinery:   mockMachineryClient,
+			config:      &upgraderConfig{},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("When the clean-up succeeds", func() {
+		It("removes extra capacity and silences and notifies of the cancellation", func() {
+			gomock.InOrder(
+				mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), machinery.WorkerNodeType).Return(&machinery.UpgradingResult{Pools: []machinery.PoolUpgradingResult{{Name: "worker"}}}, nil),
+				mockMachineryClient.EXPECT().SetPoolPaused(gomock.Any(), "worker", false).Return(nil),
+				mockScalerClient.EXPECT().EnsureScaleDownNodes(gomock.Any(), nil, gomock.Any()).Return(true, nil),
+				mockMaintClient.EXPECT().EndSilences("").Return(nil),
+				mockEMClient.EXPECT().Notify(notifier.MuoStateCancelled).Return(nil),
+			)
+			phase, err := upgrader.CancelUpgrade(context.TODO(), upgradeConfig, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(phase).To(Equal(upgradev1alpha1.UpgradePhaseCancelled))
+			history := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
+			Expect(history.Conditions.IsTrueFor("CancelledUpgrade")).To(BeTrue())
+		})
+	})
+
+	Context("When the extra capacity is still being removed", func() {
+		It("does not end the silences or notify", func() {
+			gomock.InOrder(
+				mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), machinery.WorkerNodeType).Return(&machinery.UpgradingResult{Pools: []machinery.PoolUpgradingResult{{Name: "worker"}}}, nil),
+				mockMachineryClient.EXPECT().SetPoolPaused(gomock.Any(), "worker", false).Return(nil),
+				mockScalerClient.EXPECT().EnsureScaleDownNodes(gomock.Any(), nil, gomock.Any()).Return(false, nil),
+			)
+			mockMaintClient.EXPECT().EndSilences(gomock.Any()).Times(0)
+			mockEMClient.EXPECT().Notify(gomock.Any()).Times(0)
+			phase, err := upgrader.CancelUpgrade(context.TODO(), upgradeConfig, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(phase).To(Equal(upgradev1alpha1.UpgradePhasePending))
+			history := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
+			Expect(history.Conditions.IsFalseFor("CancelledUpgrade")).To(BeTrue())
+		})
+	})
+
+	Context("When the silences can't be ended", func() {
+		var fakeError = fmt.Errorf("fake alertmanager error")
+		It("returns the error and does not notify", func() {
+			gomock.InOrder(
+				mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), machinery.WorkerNodeType).Return(&machinery.UpgradingResult{Pools: []machinery.PoolUpgradingResult{{Name: "worker"}}}, nil),
+				mockMachineryClient.EXPECT().SetPoolPaused(gomock.Any(), "worker", false).Return(nil),
+				mockScalerClient.EXPECT().EnsureScaleDownNodes(gomock.Any(), nil, gomock.Any()).Return(true, nil),
+				mockMaintClient.EXPECT().EndSilences("").Return(fakeError),
+			)
+			mockEMClient.EXPECT().Notify(gomock.Any()).Times(0)
+			phase, err := upgrader.CancelUpgrade(context.TODO(), upgradeConfig, logger)
+			Expect(err).To(Equal(fakeError))
+			Expect(phase).To(Equal(upgradev1alpha1.UpgradePhasePending))
+		})
+	})
+
+	Context("When the cancellation can't be notified", func() {
+		var fakeError = fmt.Errorf("fake notifier error")
+		It("returns the error and leaves the upgrade pending", func() {
+			gomock.InOrder(
+				mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), machinery.WorkerNodeType).Return(&machinery.UpgradingResult{Pools: []machinery.PoolUpgradingResult{{Name: "worker"}}}, nil),
+				mockMachineryClient.EXPECT().SetPoolPaused(gomock.Any(), "worker", false).Return(nil),
+				mockScalerClient.EXPECT().EnsureScaleDownNodes(gomock.Any(), nil, gomock.Any()).Return(true, nil),
+				mockMaintClient.EXPECT().EndSilences("").Return(nil),
+				mockEMClient.EXPECT().Notify(notifier.MuoStateCancelled).Return(fakeError),
+			)
+			phase, err := upgrader.CancelUpgrade(context.TODO(), upgradeConfig, logger)
+			Expect(err).To(Equal(fakeError))
+			Expect(phase).To(Equal(upgradev1alpha1.UpgradePhasePending))
+			history := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
+			Expect(history.Conditions.IsFalseFor("CancelledUpgrade")).To(BeTrue())
+		})
+	})
+})
@@ -2,10 +2,19 @@ package upgraders
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	k8syaml "sigs.k8s.io/yaml"
+
 	ac "github.com/openshift/managed-upgrade-operator/pkg/availabilitychecks"
 	"github.com/openshift/managed-upgrade-operator/pkg/drain"
+	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
+	"github.com/openshift/managed-upgrade-operator/pkg/upgradesteps"
 )
 
 type upgraderConfig struct {
@@ -16,6 +25,141 @@ type upgraderConfig struct {
 	ExtDependencyAvailabilityCheck ac.ExtDependencyAvailabilityCheck `yaml:"extDependencyAvailabilityChecks"`
 	UpgradeWindow                  upgradeWindow                     `yaml:"upgradeWindow"`
 	Environment                    environment                       `yaml:"environment"`
+	UpgradeSteps                   []upgradeStepConfig               `yaml:"upgradeSteps"`
+	Hooks                          upgradeHooks                      `yaml:"hooks"`
+	HostedCluster                  hostedClusterConfig               `yaml:"hostedCluster"`
+	Canary                         canaryConfig                      `yaml:"canary"`
+	WorkerPools                    workerPoolsConfig                 `yaml:"workerPools"`
+}
+
+// workerPoolsConfig configures the order in which the worker MachineConfigPools are upgraded
+type workerPoolsConfig struct {
+	// Pools upgraded one at a time, in order, before any pool that is not listed
+	Order []string `yaml:"order"`
+}
+
+// GetPosition returns the position of the named pool in the upgrade order. Pools that
+// are not listed share the position after the last listed pool.
+func (cfg *workerPoolsConfig) GetPosition(pool string) int {
+	for i, name := range cfg.Order {
+		if name == pool {
+			return i
+		}
+	}
+	return len(cfg.Order)
+}
+
+func (cfg *workerPoolsConfig) IsValid() error {
+	seen := make(map[string]bool, len(cfg.Order))
+	for _, name := range cfg.Order {
+		if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+			return fmt.Errorf("config workerPools order has invalid pool %q: %s", name, strings.Join(errs, ", "))
+		}
+		if name == machinery.MasterNodeType {
+			return fmt.Errorf("config workerPools order can't include the %q pool", name)
+		}
+		if seen[name] {
+			return fmt.Errorf("config workerPools order has duplicate pool %q", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// defaultCanaryPoolName is the name of the canary MachineConfigPool if none is configured
+const defaultCanaryPoolName = "worker-canary"
+
+// canaryConfig configures a canary MachineConfigPool of workers that are upgraded, and
+// health checked, before the remaining workers
+type canaryConfig struct {
+	PoolName   string `yaml:"poolName"`
+	Nodes      int    `yaml:"nodes"`
+	Percentage int    `yaml:"percentage"`
+}
+
+// IsEnabled returns whether the workers are upgraded through a canary pool
+func (cfg *canaryConfig) IsEnabled() bool {
+	return cfg.Nodes > 0 || cfg.Percentage > 0
+}
+
+// GetPoolName returns the name of the canary MachineConfigPool
+func (cfg *canaryConfig) GetPoolName() string {
+	if cfg.PoolName == "" {
+		return defaultCanaryPoolName
+	}
+	return cfg.PoolName
+}
+
+// GetNodeCount returns the number of workers, out of the given total, to place in the canary pool
+func (cfg *canaryConfig) GetNodeCount(workers int) int {
+	if cfg.Nodes > 0 {
+		return cfg.Nodes
+	}
+	count := (workers*cfg.Percentage + 99) / 100
+	if count < 1 {
+		count = 1
+	}
+	return count
+}
+
+func (cfg *canaryConfig) IsValid() error {
+	if cfg.Nodes < 0 {
+		return fmt.Errorf("config canary nodes is invalid")
+	}
+	if cfg.Percentage < 0 || cfg.Percentage > 100 {
+		return fmt.Errorf("config canary percentage is invalid (Requires int between 0 - 100 inclusive)")
+	}
+	if cfg.Nodes > 0 && cfg.Percentage > 0 {
+		return fmt.Errorf("config canary can't set both nodes and percentage")
+	}
+	if errs := validation.IsDNS1123Label(cfg.GetPoolName()); len(errs) > 0 {
+		return fmt.Errorf("config canary poolName %q is invalid: %s", cfg.PoolName, strings.Join(errs, ", "))
+	}
+	if cfg.PoolName == "worker" || cfg.PoolName == "master" {
+		return fmt.Errorf("config canary poolName can't be %q", cfg.PoolName)
+	}
+	return nil
+}
+
+// hostedClusterConfig identifies the HostedCluster of a cluster with a hosted control plane
+type hostedClusterConfig struct {
+	Namespace string `yaml:"namespace"`
+	Name      string `yaml:"name"`
+	// Name of a Secret in the operator namespace holding a kubeconfig for the management cluster.
+	// The operator's own client is used if it is not set.
+	KubeconfigSecret string `yaml:"kubeconfigSecret"`
+}
+
+func (cfg *hostedClusterConfig) IsValid() error {
+	if cfg.Namespace == "" || cfg.Name == "" {
+		return fmt.Errorf("config hostedCluster namespace and name are required")
+	}
+	return nil
+}
+
+// upgradeStepConfig configures a step of the upgrade pipeline
+type upgradeStepConfig struct {
+	Name        string `yaml:"name"`
+	Enabled     *bool  `yaml:"enabled"`
+	TimeOut     int    `yaml:"timeOut"`
+	MaxAttempts int    `yaml:"maxAttempts"`
+	Backoff     int    `yaml:"backoff"`
+	OnExhausted string `yaml:"onExhausted"`
+}
+
+// IsEnabled returns whether the step should be run, which it is unless explicitly disabled
+func (cfg upgradeStepConfig) IsEnabled() bool {
+	return cfg.Enabled == nil || *cfg.Enabled
+}
+
+// GetPolicy returns the retry budget and escalation policy of the step
+func (cfg upgradeStepConfig) GetPolicy() upgradesteps.StepPolicy {
+	return upgradesteps.StepPolicy{
+		Timeout:     time.Duration(cfg.TimeOut) * time.Minute,
+		MaxAttempts: cfg.MaxAttempts,
+		Backoff:     time.Duration(cfg.Backoff) * time.Minute,
+		OnExhausted: upgradesteps.ExhaustedAction(cfg.OnExhausted),
+	}
 }
 
 type maintenanceConfig struct {
@@ -68,8 +212,125 @@ type scaleConfig struct {
 }
 
 type healthCheck struct {
-	IgnoredCriticals  []string `yaml:"ignoredCriticals"`
-	IgnoredNamespaces []string `yaml:"ignoredNamespaces"`
+	IgnoredCriticals  []string            `yaml:"ignoredCriticals"`
+	IgnoredNamespaces []string            `yaml:"ignoredNamespaces"`
+	Checks            []promQLHealthCheck `yaml:"checks"`
+	RemovedAPIs       removedAPIsConfig   `yaml:"removedAPIs"`
+	// Minutes the cluster must stay healthy for after the upgrade, default is no soak period
+	SoakPeriod int `yaml:"soakPeriod"`
+}
+
+// GetSoakPeriodDuration returns how long the cluster must stay healthy for after the upgrade
+func (cfg *healthCheck) GetSoakPeriodDuration() time.Duration {
+	return time.Duration(cfg.SoakPeriod) * time.Minute
+}
+
+func (cfg *healthCheck) IsValid() error {
+	if cfg.SoakPeriod < 0 {
+		return fmt.Errorf("config healthCheck soakPeriod is invalid")
+	}
+	if err := cfg.RemovedAPIs.IsValid(); err != nil {
+		return err
+	}
+	seen := make(map[string]bool, len(cfg.Checks))
+	for _, check := range cfg.Checks {
+		if err := check.IsValid(); err != nil {
+			return err
+		}
+		if seen[check.Name] {
+			return fmt.Errorf("config healthCheck checks has duplicate check %q", check.Name)
+		}
+		seen[check.Name] = true
+	}
+	return nil
+}
+
+// removedAPIsConfig configures the health check for APIs in use that the target release of a
+// y-stream upgrade removes
+type removedAPIsConfig struct {
+	// Block or Warn, default is Block
+	Severity string `yaml:"severity"`
+	// Patterns of the users whose requests to removed APIs are ignored
+	IgnoredUsers []string `yaml:"ignoredUsers"`
+}
+
+// GetSeverity returns whether a failure of the check holds up the upgrade
+func (cfg *removedAPIsConfig) GetSeverity() healthCheckSeverity {
+	if cfg.Severity == "" {
+		return healthCheckBlock
+	}
+	return healthCheckSeverity(cfg.Severity)
+}
+
+func (cfg *removedAPIsConfig) IsValid() error {
+	switch cfg.GetSeverity() {
+	case healthCheckBlock, healthCheckWarn:
+	default:
+		return fmt.Errorf("config healthCheck removedAPIs severity %q is invalid", cfg.Severity)
+	}
+	for _, u := range cfg.IgnoredUsers {
+		if _, err := regexp.Compile(u); err != nil {
+			return fmt.Errorf("config healthCheck removedAPIs ignoredUsers pattern %q is invalid: %v", u, err)
+		}
+	}
+	return nil
+}
+
+// promQLHealthCheck configures a health check that compares the result of a PromQL
+// query with a threshold
+type promQLHealthCheck struct {
+	Name  string `yaml:"name"`
+	Query string `yaml:"query"`
+	// Comparison that each sample of the query result must satisfy against the threshold
+	Comparison string  `yaml:"comparison"`
+	Threshold  float64 `yaml:"threshold"`
+	// Block or Warn, default is Block
+	Severity string `yaml:"severity"`
+	// Pre and/or Post, default is both
+	Stages []string `yaml:"stages"`
+}
+
+// GetSeverity returns whether a failure of the check holds up the upgrade
+func (cfg *promQLHealthCheck) GetSeverity() healthCheckSeverity {
+	if cfg.Severity == "" {
+		return healthCheckBlock
+	}
+	return healthCheckSeverity(cfg.Severity)
+}
+
+// GetStages returns the stages of the upgrade at which the check is run
+func (cfg *promQLHealthCheck) GetStages() []healthCheckStage {
+	if len(cfg.Stages) == 0 {
+		return []healthCheckStage{healthCheckPreUpgrade, healthCheckPostUpgrade}
+	}
+	stages := make([]healthCheckStage, 0, len(cfg.Stages))
+	for _, s := range cfg.Stages {
+		stages = append(stages, healthCheckStage(s))
+	}
+	return stages
+}
+
+func (cfg *promQLHealthCheck) IsValid() error {
+	if cfg.Name == "" {
+		return fmt.Errorf("config healthCheck checks name is required")
+	}
+	if cfg.Query == "" {
+		return fmt.Errorf("config healthCheck check %q query is required", cfg.Name)
+	}
+	if _, ok := promQLComparisons[cfg.Comparison]; !ok {
+		return fmt.Errorf("config healthCheck check %q comparison %q is invalid", cfg.Name, cfg.Comparison)
+	}
+	switch cfg.GetSeverity() {
+	case healthCheckBlock, healthCheckWarn:
+	default:
+		return fmt.Errorf("config healthCheck check %q severity %q is invalid", cfg.Name, cfg.Severity)
+	}
+	for _, s := range cfg.GetStages() {
+		if s != healthCheckPreUpgrade && s != healthCheckPostUpgrade {
+			return fmt.Errorf("config healthCheck check %q stage %q is invalid", cfg.Name, s)
+		}
+	}
+	return nil
 }
 
 func (cfg *upgraderConfig) IsValid() error {
@@ -82,6 +343,9 @@ func (cfg *upgraderConfig) IsValid() error {
 	if cfg.NodeDrain.ExpectedNodeDrainTime <= 0 {
 		return fmt.Errorf("config nodeDrain expectedNodeDrainTime is invalid")
 	}
+	if err := cfg.NodeDrain.IsValid(); err != nil {
+		return err
+	}
 	if cfg.UpgradeWindow.DelayTrigger < 0 {
 		return fmt.Errorf("config upgrade window delay trigger is invalid")
 	}
@@ -91,6 +355,21 @@ func (cfg *upgraderConfig) IsValid() error {
 	if len(cfg.ExtDependencyAvailabilityCheck.HTTP.URLS) > 0 && cfg.ExtDependencyAvailabilityCheck.HTTP.Timeout <= 0 || cfg.ExtDependencyAvailabilityCheck.HTTP.Timeout > 60 {
 		return fmt.Errorf("config HTTP timeout is invalid (Requires int between 1 - 60 inclusive)")
 	}
+	if err := validateUpgradeSteps(cfg.UpgradeSteps); err != nil {
+		return err
+	}
+	if err := cfg.Hooks.IsValid(); err != nil {
+		return err
+	}
+	if err := cfg.HealthCheck.IsValid(); err != nil {
+		return err
+	}
+	if err := cfg.Canary.IsValid(); err != nil {
+		return err
+	}
+	if err := cfg.WorkerPools.IsValid(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -105,3 +384,95 @@ type environment struct {
 func (cfg *environment) IsFedramp() bool {
 	return cfg.Fedramp
 }
+
+// hookFailurePolicy determines how a failing hook affects the upgrade
+type hookFailurePolicy string
+
+const (
+	// hookFailureBlock stops the upgrade from progressing until the hook succeeds
+	hookFailureBlock hookFailurePolicy = "Block"
+	// hookFailureWarn records the failure and lets the upgrade continue
+	hookFailureWarn hookFailurePolicy = "Warn"
+
+	// maxHookNameLength leaves room in the hook's Job name for its hook point and the upgrade version
+	maxHookNameLength = 32
+)
+
+// upgradeHooks holds the hooks to run at each hook point of the upgrade
+type upgradeHooks struct {
+	PreUpgrade           []upgradeHook `yaml:"preUpgrade"`
+	ControlPlaneUpgraded []upgradeHook `yaml:"controlPlaneUpgraded"`
+	WorkersUpgraded      []upgradeHook `yaml:"workersUpgraded"`
+}
+
+// upgradeHook is a Job that is run, and waited on, at a hook point of the upgrade
+type upgradeHook struct {
+	Name          string                 `yaml:"name"`
+	Namespace     string                 `yaml:"namespace"`
+	FailurePolicy hookFailurePolicy      `yaml:"failurePolicy"`
+	Template      corev1.PodTemplateSpec `yaml:"-"`
+}
+
+// UnmarshalYAML decodes the hook's pod template using its Kubernetes (JSON) field names
+func (h *upgradeHook) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plainHook upgradeHook
+	if err := unmarshal((*plainHook)(h)); err != nil {
+		return err
+	}
+
+	var raw struct {
+		Template interface{} `yaml:"template"`
+	}
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+	if raw.Template == nil {
+		return nil
+	}
+	b, err := yaml.Marshal(raw.Template)
+	if err != nil {
+		return err
+	}
+	if err := k8syaml.UnmarshalStrict(b, &h.Template); err != nil {
+		return fmt.Errorf("hook %s template is invalid: %v", h.Name, err)
+	}
+	return nil
+}
+
+// BlocksUpgrade returns whether a failure of the hook should stop the upgrade from progressing
+func (h upgradeHook) BlocksUpgrade() bool {
+	return h.FailurePolicy != hookFailureWarn
+}
+
+func (cfg *upgradeHooks) IsValid() error {
+	for _, p := range []struct {
+		point string
+		hooks []upgradeHook
+	}{
+		{"preUpgrade", cfg.PreUpgrade},
+		{"controlPlaneUpgraded", cfg.ControlPlaneUpgraded},
+		{"workersUpgraded", cfg.WorkersUpgraded},
+	} {
+		point, hooks := p.point, p.hooks
+		seen := make(map[string]bool, len(hooks))
+		for _, h := range hooks {
+			if errs := validation.IsDNS1123Label(h.Name); len(errs) > 0 || len(h.Name) > maxHookNameLength {
+				return fmt.Errorf("config hooks %s hook name %q must be a DNS label of at most %d characters", point, h.Name, maxHookNameLength)
+			}
+			if seen[h.Name] {
+				return fmt.Errorf("config hooks %s has duplicate hook %q", point, h.Name)
+			}
+			seen[h.Name] = true
+			if h.Namespace == "" {
+				return fmt.Errorf("config hooks %s hook %q has no namespace", point, h.Name)
+			}
+			if h.FailurePolicy != "" && h.FailurePolicy != hookFailureBlock && h.FailurePolicy != hookFailureWarn {
+				return fmt.Errorf("config hooks %s hook %q failurePolicy must be %s or %s", point, h.Name, hookFailureBlock, hookFailureWarn)
+			}
+			if len(h.Template.Spec.Containers) == 0 {
+				return fmt.Errorf("config hooks %s hook %q template has no containers", point, h.Name)
+			}
+		}
+	}
+	return nil
+}
@@ -12,7 +12,7 @@ import (
 
 // UpgradeDelayedCheck will raise a 'delayed' event if the cluster has not commenced
 // upgrade within a configurable amount of time.
-func (c *osdUpgrader) UpgradeDelayedCheck(ctx context.Context, logger logr.Logger) (bool, error) {
+func (c *clusterUpgrader) UpgradeDelayedCheck(ctx context.Context, logger logr.Logger) (bool, error) {
 
 	upgradeCommenced, err := c.cvClient.HasUpgradeCommenced(c.upgradeConfig)
 	if err != nil {
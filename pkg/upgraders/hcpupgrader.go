@@ -0,0 +1,235 @@
+package upgraders
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	configv1 "github.com/openshift/api/config/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	ac "github.com/openshift/managed-upgrade-operator/pkg/availabilitychecks"
+	cv "github.com/openshift/managed-upgrade-operator/pkg/clusterversion"
+	"github.com/openshift/managed-upgrade-operator/pkg/configmanager"
+	"github.com/openshift/managed-upgrade-operator/pkg/drain"
+	"github.com/openshift/managed-upgrade-operator/pkg/eventmanager"
+	"github.com/openshift/managed-upgrade-operator/pkg/hostedcluster"
+	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
+	"github.com/openshift/managed-upgrade-operator/pkg/maintenance"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+	"github.com/openshift/managed-upgrade-operator/util"
+)
+
+// hcpUpgrader is a cluster upgrader suitable for clusters with a hosted control plane, whose
+// control plane release is driven by a HostedCluster and whose workers are driven by NodePools.
+// It inherits from the base clusterUpgrader.
+type hcpUpgrader struct {
+	*clusterUpgrader
+}
+
+// hcpUpgradeSteps are the default steps, in order, used by the hcpUpgrader
+var hcpUpgradeSteps = []upgradev1alpha1.UpgradeConditionType{
+	upgradev1alpha1.SendStartedNotification,
+	upgradev1alpha1.UpgradePreHealthCheck,
+	upgradev1alpha1.ExtDepAvailabilityCheck,
+	upgradev1alpha1.ControlPlaneMaintWindow,
+	upgradev1alpha1.PreUpgradeHooks,
+	upgradev1alpha1.CommenceUpgrade,
+	upgradev1alpha1.ControlPlaneUpgraded,
+	upgradev1alpha1.ControlPlaneUpgradedHooks,
+	upgradev1alpha1.RemoveControlPlaneMaintWindow,
+	upgradev1alpha1.CommenceNodePoolsUpgrade,
+	upgradev1alpha1.WorkersMaintWindow,
+	upgradev1alpha1.AllWorkerNodesUpgraded,
+	upgradev1alpha1.PostUpgradeHooks,
+	upgradev1alpha1.RemoveMaintWindow,
+	upgradev1alpha1.PostClusterHealthCheck,
+	upgradev1alpha1.SendCompletedNotification,
+}
+
+// NewHCPUpgrader creates a new instance of an hcpUpgrader
+func NewHCPUpgrader(c client.Client, cfm configmanager.ConfigManager, mc metrics.Metrics, notifier eventmanager.EventManager) (*hcpUpgrader, error) {
+	cfg := &upgraderConfig{}
+	err := cfm.Into(cfg)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.HostedCluster.IsValid()
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := maintenance.NewBuilder().NewClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	acs, err := ac.GetAvailabilityCheckers(&cfg.ExtDependencyAvailabilityCheck)
+	if err != nil {
+		return nil, err
+	}
+
+	// The HostedCluster and its NodePools may be on a separate management cluster
+	managementClient := c
+	if cfg.HostedCluster.KubeconfigSecret != "" {
+		ns, err := util.GetOperatorNamespace()
+		if err != nil {
+			return nil, err
+		}
+		managementClient, err = hostedcluster.NewManagementClient(c, ns, cfg.HostedCluster.KubeconfigSecret)
+		if err != nil {
+			return nil, err
+		}
+	}
+	hc := hostedcluster.NewHostedClusterClient(managementClient, cfg.HostedCluster.Namespace, cfg.HostedCluster.Name)
+
+	hu := hcpUpgrader{
+		clusterUpgrader: &clusterUpgrader{
+			client:               c,
+			metrics:              mc,
+			cvClient:             hostedClusterVersion{ClusterVersion: cv.NewCVClient(c), hostedCluster: hc},
+			notifier:             notifier,
+			config:               cfg,
+			scaler:               hostedScaler{},
+			drainstrategyBuilder: drain.NewBuilder(),
+			maintenance:          m,
+			machinery:            hostedMachinery{Machinery: machinery.NewMachinery(), hostedCluster: hc},
+			availabilityCheckers: acs,
+			hostedCluster:        hc,
+		},
+	}
+
+	steps, err := hu.buildSteps(hcpUpgradeSteps)
+	if err != nil {
+		return nil, err
+	}
+	hu.steps = steps
+
+	return &hu, nil
+}
+
+// UpgradeCluster performs the upgrade of the cluster and returns an indication of the
+// last-executed upgrade phase and any error associated with the phase execution.
+func (u *hcpUpgrader) UpgradeCluster(ctx context.Context, upgradeConfig *upgradev1alpha1.UpgradeConfig, logger logr.Logger) (upgradev1alpha1.UpgradePhase, error) {
+	u.upgradeConfig = upgradeConfig
+	return u.runSteps(ctx, logger, u.steps)
+}
+
+// HealthCheck performs a pre-upgrade healthcheck when an upgrade is scheduled in advance mainly
+// to highlight and notify of issues which could get fixed before the upgrade begins.
+func (u *hcpUpgrader) HealthCheck(ctx context.Context, upgradeConfig *upgradev1alpha1.UpgradeConfig, logger logr.Logger) (bool, error) {
+	u.upgradeConfig = upgradeConfig
+	ok, err := u.PreUpgradeHealthCheck(ctx, logger)
+	return ok, err
+}
+
+// hostedClusterVersion is a ClusterVersion client for clusters with a hosted control plane. The
+// cluster's ClusterVersion reports its state, but its release is set through the HostedCluster.
+type hostedClusterVersion struct {
+	cv.ClusterVersion
+	hostedCluster hostedcluster.HostedCluster
+}
+
+// HasUpgradeCommenced checks if the HostedCluster's release has been set to the desired version
+func (h hostedClusterVersion) HasUpgradeCommenced(uc *upgradev1alpha1.UpgradeConfig) (bool, error) {
+	return h.hostedCluster.HasUpgradeCommenced(uc)
+}
+
+// EnsureDesiredConfig sets the HostedCluster's release to the desired version
+func (h hostedClusterVersion) EnsureDesiredConfig(uc *upgradev1alpha1.UpgradeConfig) (bool, error) {
+	image, err := h.releaseImage(uc)
+	if err != nil {
+		return false, err
+	}
+	return h.hostedCluster.EnsureDesiredRelease(uc, image)
+}
+
+// HasUpgradeCompleted checks if the HostedCluster reports the control plane upgrade as completed.
+// A failure to get the HostedCluster is treated as the upgrade not having completed.
+func (h hostedClusterVersion) HasUpgradeCompleted(_ *configv1.ClusterVersion, uc *upgradev1alpha1.UpgradeConfig) bool {
+	completed, err := h.hostedCluster.HasUpgradeCompleted(uc)
+	return err == nil && completed
+}
+
+// releaseImage returns the release image of the desired version. A HostedCluster's release can
+// only be set by image, so when the upgrade is by version the image is found in the available
+// updates reported by the cluster's ClusterVersion.
+func (h hostedClusterVersion) releaseImage(uc *upgradev1alpha1.UpgradeConfig) (string, error) {
+	if uc.Spec.Desired.Image != "" {
+		return uc.Spec.Desired.Image, nil
+	}
+	clusterVersion, err := h.GetClusterVersion()
+	if err != nil {
+		return "", err
+	}
+	for _, update := range clusterVersion.Status.AvailableUpdates {
+		if update.Version == uc.Spec.Desired.Version && update.Image != "" {
+			return update.Image, nil
+		}
+	}
+	return "", fmt.Errorf("no release image found for version %s in the cluster's available updates", uc.Spec.Desired.Version)
+}
+
+// hostedMachinery is a Machinery client for clusters with a hosted control plane, whose workers are
+// upgraded through their NodePools rather than a MachineConfigPool
+type hostedMachinery struct {
+	machinery.Machinery
+	hostedCluster hostedcluster.HostedCluster
+}
+
+// IsUpgrading determines if the workers are upgrading by whether their NodePools are still rolling
+// out the desired release. The control plane has no nodes in the cluster, so is never upgrading.
+func (h hostedMachinery) IsUpgrading(c client.Client, nodeType string) (*machinery.UpgradingResult, error) {
+	if nodeType != "worker" {
+		return &machinery.UpgradingResult{}, nil
+	}
+	result, err := h.hostedCluster.IsNodePoolsUpgrading()
+	if err != nil {
+		return nil, err
+	}
+	return &machinery.UpgradingResult{
+		IsUpgrading:  result.IsUpgrading,
+		UpdatedCount: result.UpdatedCount,
+		MachineCount: result.MachineCount,
+		Pools: []machinery.PoolUpgradingResult{{
+			Name:         nodeType,
+			IsUpgrading:  result.IsUpgrading,
+			UpdatedCount: result.UpdatedCount,
+			MachineCount: result.MachineCount,
+		}},
+	}, nil
+}
+
+// SetPoolPaused pauses or unpauses the workers' NodePools
+func (h hostedMachinery) SetPoolPaused(c client.Client, nodeType string, paused bool) error {
+	if nodeType != "worker" {
+		return nil
+	}
+	return h.hostedCluster.SetNodePoolsPaused(paused)
+}
+
+// hostedScaler is a Scaler for clusters with a hosted control plane, whose workers are scaled
+// through their NodePools so can't have extra capacity reserved by the operator
+type hostedScaler struct{}
+
+// CanScale reports that extra capacity can't be reserved
+func (hostedScaler) CanScale(client.Client, logr.Logger) (bool, error) {
+	return false, nil
+}
+
+// ScaleUpNodeCount reports that no extra nodes are ever added
+func (hostedScaler) ScaleUpNodeCount(client.Client) (int, error) {
+	return 0, nil
+}
+
+// EnsureScaleUpNodes returns an error as extra capacity can't be reserved
+func (hostedScaler) EnsureScaleUpNodes(client.Client, time.Duration, logr.Logger) (bool, error) {
+	return false, fmt.Errorf("compute capacity reservation is not supported on clusters with a hosted control plane")
+}
+
+// EnsureScaleDownNodes has nothing to remove, as no extra capacity is ever reserved
+func (hostedScaler) EnsureScaleDownNodes(client.Client, drain.NodeDrainStrategy, logr.Logger) (bool, error) {
+	return true, nil
+}
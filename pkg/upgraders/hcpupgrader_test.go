@@ -0,0 +1,121 @@
+package upgraders
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+	"go.uber.org/mock/gomock"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	cvMocks "github.com/openshift/managed-upgrade-operator/pkg/clusterversion/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/hostedcluster"
+	hcMocks "github.com/openshift/managed-upgrade-operator/pkg/hostedcluster/mocks"
+	mockMachinery "github.com/openshift/managed-upgrade-operator/pkg/machinery/mocks"
+	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
+)
+
+var _ = Describe("HCP upgrader", func() {
+	var (
+		logger              logr.Logger
+		mockCtrl            *gomock.Controller
+		mockCVClient        *cvMocks.MockClusterVersion
+		mockHostedCluster   *hcMocks.MockHostedCluster
+		mockMachineryClient *mockMachinery.MockMachinery
+		upgradeConfig       *upgradev1alpha1.UpgradeConfig
+	)
+
+	BeforeEach(func() {
+		logger = logf.Log.WithName("hcp upgrader test logger")
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockCVClient = cvMocks.NewMockClusterVersion(mockCtrl)
+		mockHostedCluster = hcMocks.NewMockHostedCluster(mockCtrl)
+		mockMachineryClient = mockMachinery.NewMockMachinery(mockCtrl)
+		upgradeConfig = testStructs.NewUpgradeConfigBuilder().GetUpgradeConfig()
+		upgradeConfig.Spec.Type = upgradev1alpha1.HCP
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("When commencing the upgrade", func() {
+		var cvClient hostedClusterVersion
+
+		BeforeEach(func() {
+			cvClient = hostedClusterVersion{ClusterVersion: mockCVClient, hostedCluster: mockHostedCluster}
+		})
+
+		It("sets the HostedCluster's release to the desired image", func() {
+			upgradeConfig.Spec.Desired.Image = "quay.io/test/release@sha256:abc"
+			mockHostedCluster.EXPECT().EnsureDesiredRelease(upgradeConfig, "quay.io/test/release@sha256:abc").Return(true, nil)
+			ok, err := cvClient.EnsureDesiredConfig(upgradeConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+		})
+		It("finds the release image of the desired version in the cluster's available updates", func() {
+			mockCVClient.EXPECT().GetClusterVersion().Return(&configv1.ClusterVersion{
+				Status: configv1.ClusterVersionStatus{AvailableUpdates: []configv1.Release{
+					{Version: "4.99.0", Image: "quay.io/test/release:4.99.0"},
+					{Version: upgradeConfig.Spec.Desired.Version, Image: "quay.io/test/release:desired"},
+				}},
+			}, nil)
+			mockHostedCluster.EXPECT().EnsureDesiredRelease(upgradeConfig, "quay.io/test/release:desired").Return(true, nil)
+			ok, err := cvClient.EnsureDesiredConfig(upgradeConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+		})
+		It("does not commence the upgrade if the desired version is not an available update", func() {
+			mockCVClient.EXPECT().GetClusterVersion().Return(&configv1.ClusterVersion{}, nil)
+			mockHostedCluster.EXPECT().EnsureDesiredRelease(gomock.Any(), gomock.Any()).Times(0)
+			ok, err := cvClient.EnsureDesiredConfig(upgradeConfig)
+			Expect(err).To(MatchError(ContainSubstring("no release image found")))
+			Expect(ok).To(BeFalse())
+		})
+		It("reports the control plane upgrade as incomplete if the HostedCluster can't be read", func() {
+			mockHostedCluster.EXPECT().HasUpgradeCompleted(upgradeConfig).Return(false, fmt.Errorf("fake error"))
+			Expect(cvClient.HasUpgradeCompleted(nil, upgradeConfig)).To(BeFalse())
+		})
+	})
+
+	Context("When upgrading the workers", func() {
+		var m hostedMachinery
+
+		BeforeEach(func() {
+			m = hostedMachinery{Machinery: mockMachineryClient, hostedCluster: mockHostedCluster}
+		})
+
+		It("reports the progress of the NodePools' rollout", func() {
+			mockHostedCluster.EXPECT().IsNodePoolsUpgrading().Return(&hostedcluster.NodePoolsUpgradingResult{
+				IsUpgrading: true, UpdatedCount: 1, MachineCount: 3,
+			}, nil)
+			result, err := m.IsUpgrading(nil, "worker")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.IsUpgrading).To(BeTrue())
+			Expect(result.UpdatedCount).To(Equal(int32(1)))
+			Expect(result.MachineCount).To(Equal(int32(3)))
+		})
+		It("pauses the NodePools rather than a MachineConfigPool", func() {
+			mockHostedCluster.EXPECT().SetNodePoolsPaused(true).Return(nil)
+			mockMachineryClient.EXPECT().SetPoolPaused(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			Expect(m.SetPoolPaused(nil, "worker", true)).To(Succeed())
+		})
+		It("sets the NodePools' release", func() {
+			upgrader := &clusterUpgrader{upgradeConfig: upgradeConfig, hostedCluster: mockHostedCluster}
+			mockHostedCluster.EXPECT().EnsureNodePoolsRelease().Return(true, nil)
+			ok, err := upgrader.CommenceNodePoolsUpgrade(context.TODO(), logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+		})
+		It("can't set the NodePools' release of a cluster without a hosted control plane", func() {
+			upgrader := &clusterUpgrader{upgradeConfig: upgradeConfig}
+			ok, err := upgrader.CommenceNodePoolsUpgrade(context.TODO(), logger)
+			Expect(err).To(MatchError(ContainSubstring("only supported on clusters with a hosted control plane")))
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
@@ -53,7 +53,7 @@ func CriticalAlerts(metricsClient metrics.Metrics, cfg *upgraderConfig, ug *upgr
 		logger.Info(fmt.Sprintf("Critical alert(s) firing: %s. Cannot continue upgrade", strings.Join(alert, ", ")))
 		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.CriticalAlertsFiring)
 
-		return false, fmt.Errorf("critical alert(s) firing: %s", strings.Join(alert, ", "))
+		return false, &healthCheckFailure{reason: "critical alert(s) firing", objects: alert}
 	}
 
 	logger.Info("Prehealth check for critical alerts passed")
@@ -0,0 +1,26 @@
+package upgraders
+
+import (
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+	"github.com/openshift/managed-upgrade-operator/pkg/scaler"
+)
+
+// CapacityReservation checks that extra compute capacity can be reserved for the upgrade, when
+// the UpgradeConfig asks for it
+func CapacityReservation(metricsClient metrics.Metrics, s scaler.Scaler, c client.Client, ug *upgradev1alpha1.UpgradeConfig, logger logr.Logger) (bool, error) {
+	if !ug.Spec.CapacityReservation {
+		return true, nil
+	}
+
+	ok, err := s.CanScale(c, logger)
+	if !ok || err != nil {
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.DefaultWorkerMachinepoolNotFound)
+		return false, err
+	}
+	metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.DefaultWorkerMachinepoolNotFound)
+	return true, nil
+}
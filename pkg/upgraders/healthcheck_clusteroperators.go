@@ -23,7 +23,7 @@ func ClusterOperators(metricsClient metrics.Metrics, cvClient cv.ClusterVersion,
 	if len(result.Degraded) > 0 {
 		logger.Info(fmt.Sprintf("Degraded operators: %s", strings.Join(result.Degraded, ", ")))
 		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.ClusterOperatorsDegraded)
-		return false, fmt.Errorf("degraded operators: %s", strings.Join(result.Degraded, ", "))
+		return false, &healthCheckFailure{reason: "degraded operators", objects: result.Degraded}
 	}
 	logger.Info("Prehealth check for clusteroperators passed")
 	metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.ClusterOperatorsStatusFailed)
@@ -0,0 +1,209 @@
+package upgraders
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/drain"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+	"github.com/openshift/managed-upgrade-operator/pkg/pod"
+)
+
+// computeResources are the CPU, in millicores, and memory, in bytes, requested by pods or
+// available on a node
+type computeResources struct {
+	cpu    int64
+	memory int64
+}
+
+func (r computeResources) fits(request computeResources) bool {
+	return request.cpu <= r.cpu && request.memory <= r.memory
+}
+
+func (r computeResources) add(o computeResources) computeResources {
+	return computeResources{cpu: r.cpu + o.cpu, memory: r.memory + o.memory}
+}
+
+func (r computeResources) sub(o computeResources) computeResources {
+	return computeResources{cpu: r.cpu - o.cpu, memory: r.memory - o.memory}
+}
+
+// schedulingTarget is a node onto which the simulation schedules the pods of the drained worker
+type schedulingTarget struct {
+	labels labels.Set
+	free   computeResources
+}
+
+// DrainCapacity simulates the drain of the worker whose pods request the most memory, and checks
+// that the other workers, together with the surge nodes added by capacity reservation, have
+// enough allocatable CPU and memory for its pods. The simulation only takes the resource
+// requests and node selectors of the pods into account, and expects the surge nodes to be the
+// size of the drained worker.
+func DrainCapacity(metricsClient metrics.Metrics, c client.Client, surgeNodes int, ug *upgradev1alpha1.UpgradeConfig, logger logr.Logger) (bool, error) {
+	nodes := &corev1.NodeList{}
+	cops := &client.ListOptions{
+		Raw: &metav1.ListOptions{
+			LabelSelector: "node-role.kubernetes.io/worker, !node-role.kubernetes.io/infra",
+		},
+	}
+	err := c.List(context.TODO(), nodes, cops)
+	if err != nil {
+		logger.Info("Unable to fetch node list")
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.DrainCapacityQueryFailed)
+		return false, err
+	}
+	pods := &corev1.PodList{}
+	err = c.List(context.TODO(), pods)
+	if err != nil {
+		logger.Info("Unable to fetch pod list")
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.DrainCapacityQueryFailed)
+		return false, err
+	}
+	metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.DrainCapacityQueryFailed)
+
+	// Pick the worker whose drain needs the most capacity elsewhere
+	var drained *corev1.Node
+	var drainedPods []corev1.Pod
+	var drainedRequests computeResources
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		evicted := pod.FilterPods(pods, drain.DrainedPodPredicates(node)...).Items
+		requests := podsRequests(evicted)
+		if drained == nil || requests.memory > drainedRequests.memory ||
+			(requests.memory == drainedRequests.memory && requests.cpu > drainedRequests.cpu) {
+			drained, drainedPods, drainedRequests = node, evicted, requests
+		}
+	}
+	if drained == nil || len(drainedPods) == 0 {
+		logger.Info("Prehealth check for drain capacity passed")
+		metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.DrainCapacityInsufficient)
+		return true, nil
+	}
+
+	var targets []*schedulingTarget
+	for _, node := range nodes.Items {
+		if node.Name == drained.Name || node.Spec.Unschedulable {
+			continue
+		}
+		var requested computeResources
+		for _, p := range pods.Items {
+			if p.Spec.NodeName == node.Name && p.Status.Phase != corev1.PodSucceeded && p.Status.Phase != corev1.PodFailed {
+				requested = requested.add(podRequests(p))
+			}
+		}
+		targets = append(targets, &schedulingTarget{
+			labels: node.Labels,
+			free:   allocatable(node).sub(requested),
+		})
+	}
+	for i := 0; i < surgeNodes; i++ {
+		targets = append(targets, &schedulingTarget{
+			labels: drained.Labels,
+			free:   allocatable(*drained),
+		})
+	}
+
+	// Schedule the largest pods first, each onto the first node it fits
+	sort.SliceStable(drainedPods, func(i, j int) bool {
+		return podRequests(drainedPods[i]).memory > podRequests(drainedPods[j]).memory
+	})
+	var pending []string
+	var shortfall computeResources
+	for _, p := range drainedPods {
+		request := podRequests(p)
+		scheduled := false
+		for _, t := range targets {
+			if t.free.fits(request) && labels.SelectorFromSet(p.Spec.NodeSelector).Matches(t.labels) {
+				t.free = t.free.sub(request)
+				scheduled = true
+				break
+			}
+		}
+		if !scheduled {
+			pending = append(pending, p.Namespace+"/"+p.Name)
+			shortfall = shortfall.add(request)
+		}
+	}
+
+	if len(pending) > 0 {
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.DrainCapacityInsufficient)
+		failure := &healthCheckFailure{
+			reason:  fmt.Sprintf("draining node %s would leave pods pending", drained.Name),
+			objects: pending,
+		}
+		if ug.Spec.CapacityReservation {
+			failure.detail = fmt.Sprintf("%d more surge node(s) recommended", recommendedSurgeNodes(shortfall, allocatable(*drained)))
+		}
+		return false, failure
+	}
+	logger.Info("Prehealth check for drain capacity passed")
+	metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.DrainCapacityInsufficient)
+	return true, nil
+}
+
+// surgeNodeCount returns how many surge nodes the upgrade adds to the cluster before the
+// workers are upgraded
+func (c *clusterUpgrader) surgeNodeCount(ug *upgradev1alpha1.UpgradeConfig) (int, error) {
+	if !ug.Spec.CapacityReservation || !c.hasStep(upgradev1alpha1.UpgradeScaleUpExtraNodes) {
+		return 0, nil
+	}
+	return c.scaler.ScaleUpNodeCount(c.client)
+}
+
+// recommendedSurgeNodes returns how many nodes the size of the drained worker are needed to
+// schedule the pods that would otherwise be pending
+func recommendedSurgeNodes(shortfall, nodeSize computeResources) int {
+	nodes := 1.0
+	if nodeSize.cpu > 0 {
+		nodes = math.Max(nodes, math.Ceil(float64(shortfall.cpu)/float64(nodeSize.cpu)))
+	}
+	if nodeSize.memory > 0 {
+		nodes = math.Max(nodes, math.Ceil(float64(shortfall.memory)/float64(nodeSize.memory)))
+	}
+	return int(nodes)
+}
+
+func allocatable(node corev1.Node) computeResources {
+	return computeResources{
+		cpu:    node.Status.Allocatable.Cpu().MilliValue(),
+		memory: node.Status.Allocatable.Memory().Value(),
+	}
+}
+
+func podsRequests(pods []corev1.Pod) computeResources {
+	var r computeResources
+	for _, p := range pods {
+		r = r.add(podRequests(p))
+	}
+	return r
+}
+
+// podRequests returns the compute the scheduler reserves for the pod: the requests of its
+// containers, or of its largest init container if that is more, plus its overhead
+func podRequests(p corev1.Pod) computeResources {
+	var r computeResources
+	for _, ctr := range p.Spec.Containers {
+		r.cpu += ctr.Resources.Requests.Cpu().MilliValue()
+		r.memory += ctr.Resources.Requests.Memory().Value()
+	}
+	for _, ctr := range p.Spec.InitContainers {
+		if cpu := ctr.Resources.Requests.Cpu().MilliValue(); cpu > r.cpu {
+			r.cpu = cpu
+		}
+		if memory := ctr.Resources.Requests.Memory().Value(); memory > r.memory {
+			r.memory = memory
+		}
+	}
+	r.cpu += p.Spec.Overhead.Cpu().MilliValue()
+	r.memory += p.Spec.Overhead.Memory().Value()
+	return r
+}
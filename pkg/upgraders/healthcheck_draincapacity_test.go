@@ -0,0 +1,185 @@
+package upgraders
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+	mockMetrics "github.com/openshift/managed-upgrade-operator/pkg/metrics/mocks"
+	mockScaler "github.com/openshift/managed-upgrade-operator/pkg/scaler/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/upgradesteps"
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
+)
+
+var _ = Describe("HealthCheck drain capacity", func() {
+	var (
+		logger            logr.Logger
+		mockCtrl          *gomock.Controller
+		mockKubeClient    *mocks.MockClient
+		mockMetricsClient *mockMetrics.MockMetrics
+		upgradeConfig     *upgradev1alpha1.UpgradeConfig
+		nodes             *corev1.NodeList
+		pods              *corev1.PodList
+
+		node = func(name, cpu, memory string) corev1.Node {
+			return corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Status: corev1.NodeStatus{
+					Allocatable: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse(cpu),
+						corev1.ResourceMemory: resource.MustParse(memory),
+					},
+				},
+			}
+		}
+		workload = func(name, nodeName, cpu, memory string) corev1.Pod {
+			return corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: name},
+				Spec: corev1.PodSpec{
+					NodeName: nodeName,
+					Containers: []corev1.Container{{
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse(cpu),
+								corev1.ResourceMemory: resource.MustParse(memory),
+							},
+						},
+					}},
+				},
+			}
+		}
+		expectLists = func() {
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *nodes)
+			mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *pods)
+			mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DrainCapacityQueryFailed)
+		}
+	)
+
+	BeforeEach(func() {
+		upgradeConfig = testStructs.NewUpgradeConfigBuilder().GetUpgradeConfig()
+		upgradeConfig.Spec.CapacityReservation = false
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		mockMetricsClient = mockMetrics.NewMockMetrics(mockCtrl)
+		logger = logf.Log.WithName("cluster upgrader test logger")
+		nodes = &corev1.NodeList{Items: []corev1.Node{
+			node("worker-a", "4", "16Gi"),
+			node("worker-b", "4", "16Gi"),
+		}}
+		pods = &corev1.PodList{}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("When the other workers can take the pods of the largest worker", func() {
+		It("Prehealth check will pass", func() {
+			pods.Items = []corev1.Pod{
+				workload("api", "worker-a", "1", "6Gi"),
+				workload("cache", "worker-a", "1", "4Gi"),
+				workload("web", "worker-b", "1", "4Gi"),
+			}
+			expectLists()
+			mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DrainCapacityInsufficient)
+			result, err := DrainCapacity(mockMetricsClient, mockKubeClient, 0, upgradeConfig, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+		It("Prehealth check will not count finished pods", func() {
+			finished := workload("job", "worker-a", "1", "12Gi")
+			finished.Status.Phase = corev1.PodSucceeded
+			pods.Items = []corev1.Pod{finished, workload("api", "worker-b", "1", "10Gi")}
+			expectLists()
+			mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DrainCapacityInsufficient)
+			result, err := DrainCapacity(mockMetricsClient, mockKubeClient, 0, upgradeConfig, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+	})
+
+	Context("When the other workers lack the capacity for the pods of the largest worker", func() {
+		BeforeEach(func() {
+			pods.Items = []corev1.Pod{
+				workload("api", "worker-a", "1", "10Gi"),
+				workload("cache", "worker-a", "1", "4Gi"),
+				workload("web", "worker-b", "1", "8Gi"),
+			}
+		})
+		It("Prehealth check will fail and report the pods that would be pending", func() {
+			expectLists()
+			mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.DrainCapacityInsufficient)
+			result, err := DrainCapacity(mockMetricsClient, mockKubeClient, 0, upgradeConfig, logger)
+			Expect(err).To(MatchError("draining node worker-a would leave pods pending: app/api"))
+			Expect(result).To(BeFalse())
+		})
+		It("Prehealth check will recommend surge capacity when capacity is reserved", func() {
+			upgradeConfig.Spec.CapacityReservation = true
+			expectLists()
+			mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.DrainCapacityInsufficient)
+			result, err := DrainCapacity(mockMetricsClient, mockKubeClient, 0, upgradeConfig, logger)
+			Expect(err).To(MatchError(ContainSubstring("1 more surge node(s) recommended")))
+			Expect(result).To(BeFalse())
+		})
+		It("Prehealth check will schedule the pods onto surge nodes", func() {
+			expectLists()
+			mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DrainCapacityInsufficient)
+			result, err := DrainCapacity(mockMetricsClient, mockKubeClient, 1, upgradeConfig, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+	})
+
+	Context("When the pods can't be listed", func() {
+		It("Prehealth check will fail", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *nodes),
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake error")),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.DrainCapacityQueryFailed),
+			)
+			result, err := DrainCapacity(mockMetricsClient, mockKubeClient, 0, upgradeConfig, logger)
+			Expect(err).To(HaveOccurred())
+			Expect(result).To(BeFalse())
+		})
+	})
+
+	Context("When counting the surge nodes", func() {
+		var (
+			mockScalerClient *mockScaler.MockScaler
+			upgrader         *clusterUpgrader
+		)
+
+		BeforeEach(func() {
+			mockScalerClient = mockScaler.NewMockScaler(mockCtrl)
+			upgrader = &clusterUpgrader{
+				client: mockKubeClient,
+				scaler: mockScalerClient,
+				steps:  []upgradesteps.UpgradeStep{upgradesteps.Action(string(upgradev1alpha1.UpgradeScaleUpExtraNodes), nil)},
+			}
+		})
+
+		It("counts none when capacity is not reserved", func() {
+			count, err := upgrader.surgeNodeCount(upgradeConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(0))
+		})
+		It("counts the nodes the scaler adds when capacity is reserved", func() {
+			upgradeConfig.Spec.CapacityReservation = true
+			mockScalerClient.EXPECT().ScaleUpNodeCount(mockKubeClient).Return(2, nil)
+			count, err := upgrader.surgeNodeCount(upgradeConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(2))
+		})
+	})
+})
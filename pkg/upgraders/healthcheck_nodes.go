@@ -46,7 +46,7 @@ func ManuallyCordonedNodes(metricsClient metrics.Metrics, machinery machinery.Ma
 	if isHealthCheckFailed {
 		// Manually cordon node check failed, fail the healthcheck and return failed nodes
 		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.ClusterNodesManuallyCordoned)
-		return false, fmt.Errorf("cordoned nodes: %s", strings.Join(manuallyCordonNodes, ", "))
+		return false, &healthCheckFailure{reason: "cordoned nodes", objects: manuallyCordonNodes}
 	}
 	logger.Info("Prehealth check for manually cordoned node passed")
 	metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.ClusterNodeQueryFailed)
@@ -111,7 +111,7 @@ func NodeUnschedulableTaints(metricsClient metrics.Metrics, machinery machinery.
 			unschedulableNodes = append(unschedulableNodes, pidPressureNodes...)
 		}
 
-		return false, fmt.Errorf("unschedulable taints on nodes: %s", strings.Join(unschedulableNodes, ", "))
+		return false, &healthCheckFailure{reason: "unschedulable taints on nodes", objects: unschedulableNodes}
 	}
 	logger.Info("Prehealth check for unschedulable node taints passed")
 	metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.ClusterNodeQueryFailed)
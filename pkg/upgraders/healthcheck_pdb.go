@@ -0,0 +1,56 @@
+package upgraders
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	policyv1 "k8s.io/api/policy/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/drain"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+)
+
+// BlockingPodDisruptionBudgets checks that no PodDisruptionBudget will prevent the drain of the
+// nodes it protects. A PodDisruptionBudget blocks the drain if it currently allows no
+// disruptions, or if it requires every pod it selects to stay available. PodDisruptionBudgets
+// in namespaces the drain ignores are not checked.
+func BlockingPodDisruptionBudgets(metricsClient metrics.Metrics, c client.Client, ignoredNamespacePatterns []string, ug *upgradev1alpha1.UpgradeConfig, logger logr.Logger) (bool, error) {
+	pdbList := &policyv1.PodDisruptionBudgetList{}
+	err := c.List(context.TODO(), pdbList)
+	if err != nil {
+		logger.Info("Unable to fetch PodDisruptionBudget list")
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.PodDisruptionBudgetQueryFailed)
+		return false, err
+	}
+
+	var blockingPDBs []string
+	for _, pdb := range pdbList.Items {
+		if drain.IsIgnoredNamespace(pdb.Namespace, ignoredNamespacePatterns) {
+			continue
+		}
+		if isBlockingPDB(pdb) {
+			blockingPDBs = append(blockingPDBs, pdb.Namespace+"/"+pdb.Name)
+		}
+	}
+
+	if len(blockingPDBs) > 0 {
+		metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.PodDisruptionBudgetQueryFailed)
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.PodDisruptionBudgetsBlocking)
+		return false, &healthCheckFailure{reason: "blocking PodDisruptionBudgets", objects: blockingPDBs}
+	}
+	logger.Info("Prehealth check for blocking PodDisruptionBudgets passed")
+	metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.PodDisruptionBudgetQueryFailed)
+	metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.PodDisruptionBudgetsBlocking)
+	return true, nil
+}
+
+// isBlockingPDB returns whether the PodDisruptionBudget prevents the eviction of the pods it
+// selects. A PodDisruptionBudget that selects no pods blocks nothing.
+func isBlockingPDB(pdb policyv1.PodDisruptionBudget) bool {
+	if pdb.Status.ExpectedPods == 0 {
+		return false
+	}
+	return pdb.Status.DisruptionsAllowed == 0 || pdb.Status.DesiredHealthy >= pdb.Status.ExpectedPods
+}
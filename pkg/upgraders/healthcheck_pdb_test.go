@@ -0,0 +1,142 @@
+package upgraders
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	"go.uber.org/mock/gomock"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+	mockMetrics "github.com/openshift/managed-upgrade-operator/pkg/metrics/mocks"
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
+)
+
+var _ = Describe("HealthCheck PodDisruptionBudgets", func() {
+	var (
+		logger            logr.Logger
+		mockCtrl          *gomock.Controller
+		mockKubeClient    *mocks.MockClient
+		mockMetricsClient *mockMetrics.MockMetrics
+		upgradeConfig     *upgradev1alpha1.UpgradeConfig
+		pdbs              *policyv1.PodDisruptionBudgetList
+
+		pdb = func(namespace, name string, expected, desired, allowed int32) policyv1.PodDisruptionBudget {
+			return policyv1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+				Status: policyv1.PodDisruptionBudgetStatus{
+					ExpectedPods:       expected,
+					DesiredHealthy:     desired,
+					CurrentHealthy:     expected,
+					DisruptionsAllowed: allowed,
+				},
+			}
+		}
+	)
+
+	BeforeEach(func() {
+		upgradeConfig = testStructs.NewUpgradeConfigBuilder().GetUpgradeConfig()
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		mockMetricsClient = mockMetrics.NewMockMetrics(mockCtrl)
+		logger = logf.Log.WithName("cluster upgrader test logger")
+		pdbs = &policyv1.PodDisruptionBudgetList{}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("When every PodDisruptionBudget allows disruptions", func() {
+		It("Prehealth check will pass", func() {
+			pdbs.Items = []policyv1.PodDisruptionBudget{
+				pdb("app", "web", 3, 2, 1),
+				pdb("app", "unused", 0, 0, 0),
+			}
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *pdbs),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetQueryFailed),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetsBlocking),
+			)
+			result, err := BlockingPodDisruptionBudgets(mockMetricsClient, mockKubeClient, nil, upgradeConfig, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+	})
+
+	Context("When PodDisruptionBudgets block the drain", func() {
+		It("Prehealth check will fail and name the blocking PodDisruptionBudgets", func() {
+			pdbs.Items = []policyv1.PodDisruptionBudget{
+				pdb("app", "web", 3, 2, 1),
+				pdb("app", "db", 3, 3, 1),
+				pdb("cache", "redis", 2, 1, 0),
+			}
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *pdbs),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetQueryFailed),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.PodDisruptionBudgetsBlocking),
+			)
+			result, err := BlockingPodDisruptionBudgets(mockMetricsClient, mockKubeClient, nil, upgradeConfig, logger)
+			Expect(err).To(MatchError("blocking PodDisruptionBudgets: app/db, cache/redis"))
+			Expect(result).To(BeFalse())
+		})
+		It("Prehealth check will ignore PodDisruptionBudgets in namespaces that are not drained", func() {
+			pdbs.Items = []policyv1.PodDisruptionBudget{
+				pdb("openshift-etcd", "etcd-quorum-guard", 3, 2, 0),
+			}
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *pdbs),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetQueryFailed),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetsBlocking),
+			)
+			result, err := BlockingPodDisruptionBudgets(mockMetricsClient, mockKubeClient, []string{"^openshift-.*"}, upgradeConfig, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+	})
+
+	Context("When the PodDisruptionBudgets can't be listed", func() {
+		It("Prehealth check will fail", func() {
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake error")),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.PodDisruptionBudgetQueryFailed),
+			)
+			result, err := BlockingPodDisruptionBudgets(mockMetricsClient, mockKubeClient, nil, upgradeConfig, logger)
+			Expect(err).To(HaveOccurred())
+			Expect(result).To(BeFalse())
+		})
+	})
+
+	Context("When the pre-upgrade health check fails on PodDisruptionBudgets", func() {
+		It("names the blocking PodDisruptionBudgets in the notification", func() {
+			upgrader := &clusterUpgrader{
+				client:        mockKubeClient,
+				metrics:       mockMetricsClient,
+				config:        &upgraderConfig{},
+				upgradeConfig: upgradeConfig,
+			}
+			pdbs.Items = []policyv1.PodDisruptionBudget{pdb("app", "db", 3, 3, 1)}
+			gomock.InOrder(
+				mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil).SetArg(1, *pdbs),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetQueryFailed),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.PodDisruptionBudgetsBlocking),
+			)
+			var check healthCheckDefinition
+			for _, hc := range upgrader.healthChecks(healthCheckPreUpgrade) {
+				if hc.name == "PodDisruptionBudgets" {
+					check = hc
+				}
+			}
+			ok, err := check.check(upgrader, mockMetricsClient, logger)
+			Expect(ok).To(BeFalse())
+			Expect(check.failure(err)).To(Equal("PodDisruptionBudgetHealthcheckFailed (blocking PodDisruptionBudgets: app/db)"))
+		})
+	})
+})
@@ -0,0 +1,90 @@
+package upgraders
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+)
+
+// promQLComparisons are the comparisons a PromQL health check can make of a sample against its threshold
+var promQLComparisons = map[string]func(value, threshold float64) bool{
+	"<":  func(v, t float64) bool { return v < t },
+	"<=": func(v, t float64) bool { return v <= t },
+	">":  func(v, t float64) bool { return v > t },
+	">=": func(v, t float64) bool { return v >= t },
+	"==": func(v, t float64) bool { return v == t },
+	"!=": func(v, t float64) bool { return v != t },
+}
+
+// definition returns the health check that runs the configured PromQL check
+func (cfg promQLHealthCheck) definition() healthCheckDefinition {
+	return healthCheckDefinition{
+		name:           cfg.Name,
+		notification:   cfg.Name,
+		stages:         cfg.GetStages(),
+		severity:       cfg.GetSeverity(),
+		failureMessage: fmt.Sprintf("upgrade may delay due to failing health check %s", cfg.Name),
+		passedMessage:  "The configured health check passed",
+		check: func(c *clusterUpgrader, mc metrics.Metrics, logger logr.Logger) (bool, error) {
+			return PromQLCheck(mc, cfg, c.upgradeConfig, logger)
+		},
+	}
+}
+
+// PromQLCheck runs the query of a configured PromQL health check and compares each sample of
+// its result with the check's threshold. The check fails if any sample fails the comparison,
+// or if the query returns no samples.
+func PromQLCheck(metricsClient metrics.Metrics, cfg promQLHealthCheck, ug *upgradev1alpha1.UpgradeConfig, logger logr.Logger) (bool, error) {
+	reason := metrics.PromQLHealthCheckFailed + "_" + cfg.Name
+
+	result, err := metricsClient.Query(cfg.Query)
+	if err != nil {
+		logger.Info(fmt.Sprintf("Unable to query metrics for health check %s", cfg.Name))
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, reason)
+		return false, fmt.Errorf("unable to query health check %s: %s", cfg.Name, err)
+	}
+	if len(result.Data.Result) == 0 {
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, reason)
+		return false, fmt.Errorf("health check %s query returned no data", cfg.Name)
+	}
+
+	compare := promQLComparisons[cfg.Comparison]
+	failed := []string{}
+	for _, r := range result.Data.Result {
+		value, err := sampleValue(r)
+		if err != nil {
+			metricsClient.UpdateMetricHealthcheckFailed(ug.Name, reason)
+			return false, fmt.Errorf("health check %s: %s", cfg.Name, err)
+		}
+		if !compare(value, cfg.Threshold) {
+			failed = append(failed, strconv.FormatFloat(value, 'g', -1, 64))
+		}
+	}
+
+	if len(failed) > 0 {
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, reason)
+		return false, fmt.Errorf("health check %s failed: %s is not %s %v", cfg.Name, strings.Join(failed, ", "), cfg.Comparison, cfg.Threshold)
+	}
+
+	logger.Info(fmt.Sprintf("Health check %s passed", cfg.Name))
+	metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, reason)
+	return true, nil
+}
+
+// sampleValue returns the value of an instant vector sample, which Prometheus reports as
+// a [timestamp, "value"] pair
+func sampleValue(r metrics.AlertResult) (float64, error) {
+	if len(r.Value) != 2 {
+		return 0, fmt.Errorf("unexpected sample %v", r.Value)
+	}
+	s, ok := r.Value[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("unexpected sample value %v", r.Value[1])
+	}
+	return strconv.ParseFloat(s, 64)
+}
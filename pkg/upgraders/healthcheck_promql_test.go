@@ -0,0 +1,159 @@
+package upgraders
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/clusterversion"
+	cvMocks "github.com/openshift/managed-upgrade-operator/pkg/clusterversion/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+	mockMetrics "github.com/openshift/managed-upgrade-operator/pkg/metrics/mocks"
+	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
+)
+
+var _ = Describe("PromQL health checks", func() {
+	const testQuery = `sum(rate(haproxy_server_http_responses_total{code="5xx"}[5m])) / sum(rate(haproxy_server_http_responses_total[5m]))`
+
+	var (
+		logger            logr.Logger
+		mockCtrl          *gomock.Controller
+		mockMetricsClient *mockMetrics.MockMetrics
+		mockCVClient      *cvMocks.MockClusterVersion
+		upgradeConfig     *upgradev1alpha1.UpgradeConfig
+		check             promQLHealthCheck
+		reason            string
+
+		samples = func(values ...string) *metrics.AlertResponse {
+			response := &metrics.AlertResponse{}
+			for _, v := range values {
+				response.Data.Result = append(response.Data.Result, metrics.AlertResult{Value: []interface{}{1700000000.0, v}})
+			}
+			return response
+		}
+	)
+
+	BeforeEach(func() {
+		logger = logf.Log.WithName("promql health check test logger")
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockMetricsClient = mockMetrics.NewMockMetrics(mockCtrl)
+		mockCVClient = cvMocks.NewMockClusterVersion(mockCtrl)
+		upgradeConfig = testStructs.NewUpgradeConfigBuilder().GetUpgradeConfig()
+		check = promQLHealthCheck{Name: "IngressErrorRate", Query: testQuery, Comparison: "<", Threshold: 0.01}
+		reason = metrics.PromQLHealthCheckFailed + "_IngressErrorRate"
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("When evaluating a check", func() {
+		It("passes when every sample satisfies the comparison", func() {
+			gomock.InOrder(
+				mockMetricsClient.EXPECT().Query(testQuery).Return(samples("0.002", "0.009"), nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, reason),
+			)
+			ok, err := PromQLCheck(mockMetricsClient, check, upgradeConfig, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+		})
+		It("fails when a sample does not satisfy the comparison", func() {
+			gomock.InOrder(
+				mockMetricsClient.EXPECT().Query(testQuery).Return(samples("0.002", "0.05"), nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, reason),
+			)
+			ok, err := PromQLCheck(mockMetricsClient, check, upgradeConfig, logger)
+			Expect(err).To(MatchError(ContainSubstring("0.05 is not < 0.01")))
+			Expect(ok).To(BeFalse())
+		})
+		It("fails when the query returns no data", func() {
+			gomock.InOrder(
+				mockMetricsClient.EXPECT().Query(testQuery).Return(samples(), nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, reason),
+			)
+			ok, err := PromQLCheck(mockMetricsClient, check, upgradeConfig, logger)
+			Expect(err).To(MatchError(ContainSubstring("no data")))
+			Expect(ok).To(BeFalse())
+		})
+		It("fails when the query fails", func() {
+			gomock.InOrder(
+				mockMetricsClient.EXPECT().Query(testQuery).Return(nil, fmt.Errorf("fake error")),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, reason),
+			)
+			ok, err := PromQLCheck(mockMetricsClient, check, upgradeConfig, logger)
+			Expect(err).To(MatchError(ContainSubstring("fake error")))
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Context("When running the post-upgrade health check", func() {
+		var upgrader *clusterUpgrader
+
+		BeforeEach(func() {
+			upgrader = &clusterUpgrader{
+				metrics:       mockMetricsClient,
+				cvClient:      mockCVClient,
+				config:        &upgraderConfig{HealthCheck: healthCheck{Checks: []promQLHealthCheck{check}}},
+				upgradeConfig: upgradeConfig,
+			}
+			gomock.InOrder(
+				mockMetricsClient.EXPECT().Query(gomock.Any()).Return(&metrics.AlertResponse{}, nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.MetricsQueryFailed),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.CriticalAlertsFiring),
+				mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterOperatorsStatusFailed),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterOperatorsDegraded),
+				mockMetricsClient.EXPECT().Query(testQuery).Return(samples("0.05"), nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, reason),
+			)
+		})
+
+		It("is blocked by a failing check", func() {
+			ok, err := upgrader.PostUpgradeHealthCheck(context.TODO(), logger)
+			Expect(err).To(HaveOccurred())
+			Expect(ok).To(BeFalse())
+		})
+		It("is not blocked by a failing check that only warns", func() {
+			upgrader.config.HealthCheck.Checks[0].Severity = string(healthCheckWarn)
+			ok, err := upgrader.PostUpgradeHealthCheck(context.TODO(), logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+		})
+	})
+
+	Context("When a check only runs before the upgrade", func() {
+		It("is not run after the upgrade", func() {
+			check.Stages = []string{string(healthCheckPreUpgrade)}
+			upgrader := &clusterUpgrader{config: &upgraderConfig{HealthCheck: healthCheck{Checks: []promQLHealthCheck{check}}}}
+			Expect(upgrader.configuredHealthChecks(healthCheckPreUpgrade)).To(HaveLen(1))
+			Expect(upgrader.configuredHealthChecks(healthCheckPostUpgrade)).To(BeEmpty())
+		})
+	})
+
+	Context("When validating the configured checks", func() {
+		It("accepts a valid check", func() {
+			cfg := healthCheck{Checks: []promQLHealthCheck{check}}
+			Expect(cfg.IsValid()).To(Succeed())
+		})
+		It("rejects an unknown comparison", func() {
+			check.Comparison = "~"
+			cfg := healthCheck{Checks: []promQLHealthCheck{check}}
+			Expect(cfg.IsValid()).To(MatchError(ContainSubstring("comparison")))
+		})
+		It("rejects an unknown severity", func() {
+			check.Severity = "Page"
+			cfg := healthCheck{Checks: []promQLHealthCheck{check}}
+			Expect(cfg.IsValid()).To(MatchError(ContainSubstring("severity")))
+		})
+		It("rejects duplicate checks", func() {
+			cfg := healthCheck{Checks: []promQLHealthCheck{check, check}}
+			Expect(cfg.IsValid()).To(MatchError(ContainSubstring("duplicate")))
+		})
+	})
+})
@@ -0,0 +1,230 @@
+package upgraders
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+)
+
+// healthCheckStage is a point of the upgrade at which health checks are run
+type healthCheckStage string
+
+const (
+	// healthCheckPreUpgrade checks are run before the upgrade commences
+	healthCheckPreUpgrade healthCheckStage = "Pre"
+	// healthCheckPostUpgrade checks are run once the workers are upgraded
+	healthCheckPostUpgrade healthCheckStage = "Post"
+)
+
+// healthCheckSeverity decides whether a failed health check holds up the upgrade
+type healthCheckSeverity string
+
+const (
+	// healthCheckBlock checks hold up the upgrade when they fail
+	healthCheckBlock healthCheckSeverity = "Block"
+	// healthCheckWarn checks are reported when they fail, but don't hold up the upgrade
+	healthCheckWarn healthCheckSeverity = "Warn"
+)
+
+// healthCheckDefinition describes a health check of the cluster
+type healthCheckDefinition struct {
+	// Name of the check, reported in the UpgradeConfig's health check reports
+	name string
+	// Names the check in the health check notification when it fails
+	notification string
+	// Stages of the upgrade at which the check is run
+	stages []healthCheckStage
+	// Whether a failure of the check holds up the upgrade
+	severity healthCheckSeverity
+	// Returns the severity configured in the ConfigMap, for checks whose severity can be changed
+	configuredSeverity func(cfg *upgraderConfig) healthCheckSeverity
+	// Logged when the check fails
+	failureMessage string
+	// Reported by an upgrade rehearsal when the check passes
+	passedMessage string
+	// Whether the error of a failed check, which names the objects at fault, is included in
+	// the health check notification
+	reportsObjects bool
+	// The check, which returns whether the cluster passed it. Health check metrics are
+	// recorded through the given metrics client.
+	check func(c *clusterUpgrader, mc metrics.Metrics, logger logr.Logger) (bool, error)
+}
+
+// builtinHealthChecks are the health checks, in the order they are run, of every upgrader
+var builtinHealthChecks = []healthCheckDefinition{
+	{
+		name:           "CriticalAlerts",
+		notification:   "CriticalAlertsHealthcheckFailed",
+		stages:         []healthCheckStage{healthCheckPreUpgrade, healthCheckPostUpgrade},
+		severity:       healthCheckBlock,
+		failureMessage: "upgrade may delay due to firing critical alerts",
+		passedMessage:  "No critical alerts are firing",
+		check: func(c *clusterUpgrader, mc metrics.Metrics, logger logr.Logger) (bool, error) {
+			return CriticalAlerts(mc, c.config, c.upgradeConfig, logger)
+		},
+	},
+	{
+		name:           "ClusterOperators",
+		notification:   "ClusterOperatorsHealthcheckFailed",
+		stages:         []healthCheckStage{healthCheckPreUpgrade, healthCheckPostUpgrade},
+		severity:       healthCheckBlock,
+		failureMessage: "upgrade may delay due to cluster operators not ready",
+		passedMessage:  "No cluster operators are degraded",
+		check: func(c *clusterUpgrader, mc metrics.Metrics, logger logr.Logger) (bool, error) {
+			return ClusterOperators(mc, c.cvClient, c.upgradeConfig, logger)
+		},
+	},
+	{
+		name:           "CapacityReservation",
+		notification:   "CapacityReservationHealthcheckFailed",
+		stages:         []healthCheckStage{healthCheckPreUpgrade},
+		severity:       healthCheckBlock,
+		failureMessage: "upgrade may delay due to compute capacity not being reservable",
+		passedMessage:  "Extra compute capacity can be reserved for the upgrade",
+		check: func(c *clusterUpgrader, mc metrics.Metrics, logger logr.Logger) (bool, error) {
+			return CapacityReservation(mc, c.scaler, c.client, c.upgradeConfig, logger)
+		},
+	},
+	{
+		name:           "ManuallyCordonedNodes",
+		notification:   "NodeUnschedulableHealthcheckFailed",
+		stages:         []healthCheckStage{healthCheckPreUpgrade},
+		severity:       healthCheckBlock,
+		failureMessage: "upgrade may delay due to there are manually cordoned nodes",
+		passedMessage:  "No worker nodes are manually cordoned",
+		check: func(c *clusterUpgrader, mc metrics.Metrics, logger logr.Logger) (bool, error) {
+			return ManuallyCordonedNodes(mc, c.machinery, c.client, c.upgradeConfig, logger)
+		},
+	},
+	{
+		name:           "NodeUnschedulableTaints",
+		notification:   "NodeUnschedulableTaintHealthcheckFailed",
+		stages:         []healthCheckStage{healthCheckPreUpgrade},
+		severity:       healthCheckBlock,
+		failureMessage: "upgrade delayed due to there are unschedulable taints on nodes",
+		passedMessage:  "No nodes are under resource pressure",
+		check: func(c *clusterUpgrader, mc metrics.Metrics, logger logr.Logger) (bool, error) {
+			return NodeUnschedulableTaints(mc, c.machinery, c.client, c.upgradeConfig, logger)
+		},
+	},
+	{
+		name:           "PodDisruptionBudgets",
+		notification:   "PodDisruptionBudgetHealthcheckFailed",
+		stages:         []healthCheckStage{healthCheckPreUpgrade},
+		severity:       healthCheckBlock,
+		failureMessage: "upgrade may delay due to PodDisruptionBudgets that block the node drain",
+		passedMessage:  "No PodDisruptionBudgets block the node drain",
+		reportsObjects: true,
+		check: func(c *clusterUpgrader, mc metrics.Metrics, logger logr.Logger) (bool, error) {
+			return BlockingPodDisruptionBudgets(mc, c.client, c.config.NodeDrain.IgnoredNamespacePatterns, c.upgradeConfig, logger)
+		},
+	},
+	{
+		name:           "DrainCapacity",
+		notification:   "DrainCapacityHealthcheckFailed",
+		stages:         []healthCheckStage{healthCheckPreUpgrade},
+		severity:       healthCheckBlock,
+		failureMessage: "upgrade may delay due to the workers lacking the capacity to drain a node",
+		passedMessage:  "The other workers have the capacity to drain any worker",
+		reportsObjects: true,
+		check: func(c *clusterUpgrader, mc metrics.Metrics, logger logr.Logger) (bool, error) {
+			surgeNodes, err := c.surgeNodeCount(c.upgradeConfig)
+			if err != nil {
+				return false, err
+			}
+			return DrainCapacity(mc, c.client, surgeNodes, c.upgradeConfig, logger)
+		},
+	},
+	{
+		name:         "RemovedAPIs",
+		notification: "RemovedAPIsHealthcheckFailed",
+		stages:       []healthCheckStage{healthCheckPreUpgrade},
+		severity:     healthCheckBlock,
+		configuredSeverity: func(cfg *upgraderConfig) healthCheckSeverity {
+			return cfg.HealthCheck.RemovedAPIs.GetSeverity()
+		},
+		failureMessage: "upgrade may delay due to APIs in use that the target release removes",
+		passedMessage:  "No APIs that the target release removes are in use",
+		reportsObjects: true,
+		check: func(c *clusterUpgrader, mc metrics.Metrics, logger logr.Logger) (bool, error) {
+			return RemovedAPIsInUse(mc, c.client, c.cvClient, c.config.HealthCheck.RemovedAPIs, c.upgradeConfig, logger)
+		},
+	},
+}
+
+// healthChecks returns the health checks, in the order they are run, for the given stage of
+// the upgrade. The built-in checks are followed by the checks configured in the ConfigMap.
+func (c *clusterUpgrader) healthChecks(stage healthCheckStage) []healthCheckDefinition {
+	var checks []healthCheckDefinition
+	for _, hc := range builtinHealthChecks {
+		if hc.configuredSeverity != nil {
+			hc.severity = hc.configuredSeverity(c.config)
+		}
+		if hc.runsAt(stage) {
+			checks = append(checks, hc)
+		}
+	}
+	return append(checks, c.configuredHealthChecks(stage)...)
+}
+
+// configuredHealthChecks returns the PromQL health checks configured in the ConfigMap for the
+// given stage of the upgrade
+func (c *clusterUpgrader) configuredHealthChecks(stage healthCheckStage) []healthCheckDefinition {
+	var checks []healthCheckDefinition
+	for _, q := range c.config.HealthCheck.Checks {
+		hc := q.definition()
+		if hc.runsAt(stage) {
+			checks = append(checks, hc)
+		}
+	}
+	return checks
+}
+
+// runsAt returns whether the health check is run at the given stage of the upgrade
+func (hc healthCheckDefinition) runsAt(stage healthCheckStage) bool {
+	for _, s := range hc.stages {
+		if s == stage {
+			return true
+		}
+	}
+	return false
+}
+
+// failure returns how the failed health check is reported in the health check notification
+func (hc healthCheckDefinition) failure(err error) string {
+	if hc.reportsObjects && err != nil {
+		return fmt.Sprintf("%s (%s)", hc.notification, err)
+	}
+	return hc.notification
+}
+
+// healthCheckFailure is the error of a failed health check that names the objects at fault
+type healthCheckFailure struct {
+	// Describes the failure
+	reason string
+	// The objects at fault, such as alerts, operators or nodes
+	objects []string
+	// Further detail of the failure, reported after the objects
+	detail string
+}
+
+func (f *healthCheckFailure) Error() string {
+	msg := fmt.Sprintf("%s: %s", f.reason, strings.Join(f.objects, ", "))
+	if f.detail != "" {
+		msg = fmt.Sprintf("%s; %s", msg, f.detail)
+	}
+	return msg
+}
+
+// failedObjects returns the objects named by the error of a failed health check
+func failedObjects(err error) []string {
+	var failure *healthCheckFailure
+	if errors.As(err, &failure) {
+		return failure.objects
+	}
+	return nil
+}
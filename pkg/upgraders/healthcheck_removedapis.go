@@ -0,0 +1,234 @@
+package upgraders
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/go-logr/logr"
+	apiserverv1 "github.com/openshift/api/apiserver/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	cv "github.com/openshift/managed-upgrade-operator/pkg/clusterversion"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+)
+
+const (
+	// adminGatesConfigMap lists the gates that an admin must acknowledge before a y-stream upgrade
+	adminGatesConfigMap = "admin-gates"
+	// adminGatesNamespace is the namespace of the admin gates
+	adminGatesNamespace = "openshift-config-managed"
+	// adminAcksConfigMap holds the admin's acknowledgements of the admin gates
+	adminAcksConfigMap = "admin-acks"
+	// adminAcksNamespace is the namespace of the admin acknowledgements
+	adminAcksNamespace = "openshift-config"
+	// kubeMinorOffset is the difference between the minor versions of an OpenShift 4 release
+	// and of the Kubernetes release it ships, such as OpenShift 4.14 shipping Kubernetes 1.27
+	kubeMinorOffset = 13
+)
+
+// adminGateRemovalsPattern matches the admin gates for the API removals of a release, such as
+// ack-4.13-kube-1.27-api-removals-in-4.14, capturing the Kubernetes and OpenShift versions of
+// the release
+var adminGateRemovalsPattern = regexp.MustCompile(`^ack-[0-9]+\.[0-9]+-kube-([0-9]+)\.([0-9]+)-api-removals-in-([0-9]+)\.([0-9]+)$`)
+
+// RemovedAPIsInUse checks, for y-stream upgrades, that no APIs that the target release removes
+// have been requested in the last 24 hours, other than by the ignored users. The failure names
+// each API with the users that requested it, and whether the admin gate for the API removals
+// has been acknowledged.
+func RemovedAPIsInUse(metricsClient metrics.Metrics, c client.Client, cvClient cv.ClusterVersion, cfg removedAPIsConfig, ug *upgradev1alpha1.UpgradeConfig, logger logr.Logger) (bool, error) {
+	clusterVersion, err := cvClient.GetClusterVersion()
+	if err != nil {
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.RemovedAPIsQueryFailed)
+		return false, err
+	}
+	currentVersion, err := cv.GetCurrentVersion(clusterVersion)
+	if err != nil {
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.RemovedAPIsQueryFailed)
+		return false, err
+	}
+	desiredVersion := ug.Spec.Desired.Version
+	minorUpgrade, err := cv.GetMinorUpgrade(currentVersion, desiredVersion)
+	if err != nil {
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.RemovedAPIsQueryFailed)
+		return false, err
+	}
+	if minorUpgrade != "y" {
+		logger.Info("Skipping the removed API check for an upgrade that is not y-stream")
+		metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.RemovedAPIsQueryFailed)
+		metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.RemovedAPIsInUse)
+		return true, nil
+	}
+	current, err := semver.Parse(currentVersion)
+	if err != nil {
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.RemovedAPIsQueryFailed)
+		return false, err
+	}
+	desired, err := semver.Parse(desiredVersion)
+	if err != nil {
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.RemovedAPIsQueryFailed)
+		return false, err
+	}
+
+	requestCounts := &apiserverv1.APIRequestCountList{}
+	err = c.List(context.TODO(), requestCounts)
+	if err != nil {
+		logger.Info("Unable to fetch APIRequestCount list")
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.RemovedAPIsQueryFailed)
+		return false, err
+	}
+
+	gates, err := adminGates(c)
+	if err != nil {
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.RemovedAPIsQueryFailed)
+		return false, err
+	}
+	// APIRequestCounts report the Kubernetes release that removes each API, whose minor version
+	// moves in step with the OpenShift one
+	desiredKube := kubeVersionOf(desired, gates)
+	currentKube := desiredKube
+	currentKube.Minor -= desired.Minor - current.Minor
+
+	var inUse []string
+	for _, rc := range requestCounts.Items {
+		if !isRemovedBetween(rc.Status.RemovedInRelease, currentKube, desiredKube) {
+			continue
+		}
+		users := removedAPIUsers(rc, cfg.IgnoredUsers)
+		if len(users) > 0 {
+			inUse = append(inUse, fmt.Sprintf("%s (users: %s)", rc.Name, strings.Join(users, ", ")))
+		}
+	}
+
+	if len(inUse) == 0 {
+		logger.Info("Prehealth check for removed APIs passed")
+		metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.RemovedAPIsQueryFailed)
+		metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.RemovedAPIsInUse)
+		return true, nil
+	}
+
+	acks, err := adminAcksForRemovals(c, gates, desired)
+	if err != nil {
+		metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.RemovedAPIsQueryFailed)
+		return false, err
+	}
+	metricsClient.UpdateMetricHealthcheckSucceeded(ug.Name, metrics.RemovedAPIsQueryFailed)
+	metricsClient.UpdateMetricHealthcheckFailed(ug.Name, metrics.RemovedAPIsInUse)
+	return false, &healthCheckFailure{
+		reason:  fmt.Sprintf("APIs removed in %d.%d (Kubernetes %d.%d) are in use", desired.Major, desired.Minor, desiredKube.Major, desiredKube.Minor),
+		objects: inUse,
+		detail:  strings.Join(acks, ", "),
+	}
+}
+
+// kubeVersionOf returns the Kubernetes version shipped by the OpenShift release. It is read from
+// the admin gate for the release's API removals when there is one, and otherwise follows from
+// the OpenShift minor version.
+func kubeVersionOf(release semver.Version, gates map[string]string) semver.Version {
+	for gate := range gates {
+		m := adminGateRemovalsPattern.FindStringSubmatch(gate)
+		if m == nil || m[3] != strconv.FormatUint(release.Major, 10) || m[4] != strconv.FormatUint(release.Minor, 10) {
+			continue
+		}
+		major, err := strconv.ParseUint(m[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		minor, err := strconv.ParseUint(m[2], 10, 64)
+		if err != nil {
+			continue
+		}
+		return semver.Version{Major: major, Minor: minor}
+	}
+	return semver.Version{Major: 1, Minor: release.Minor + kubeMinorOffset}
+}
+
+// isRemovedBetween returns whether the Kubernetes release that removes an API follows the
+// current Kubernetes release, and is no later than the desired one
+func isRemovedBetween(removedInRelease string, current, desired semver.Version) bool {
+	if removedInRelease == "" {
+		return false
+	}
+	removed, err := semver.ParseTolerant(removedInRelease)
+	if err != nil {
+		return false
+	}
+	return removed.Major == desired.Major && removed.Minor > current.Minor && removed.Minor <= desired.Minor
+}
+
+// removedAPIUsers returns the users, other than the ignored ones, that requested the API in the
+// last 24 hours
+func removedAPIUsers(rc apiserverv1.APIRequestCount, ignoredUsers []string) []string {
+	seen := map[string]bool{}
+	var users []string
+	for _, hour := range rc.Status.Last24h {
+		for _, node := range hour.ByNode {
+			for _, user := range node.ByUser {
+				if user.RequestCount == 0 || seen[user.UserName] || isIgnoredUser(user.UserName, ignoredUsers) {
+					continue
+				}
+				seen[user.UserName] = true
+				users = append(users, user.UserName)
+			}
+		}
+	}
+	sort.Strings(users)
+	return users
+}
+
+func isIgnoredUser(user string, ignoredUsers []string) bool {
+	for _, pattern := range ignoredUsers {
+		if regexp.MustCompile(pattern).MatchString(user) {
+			return true
+		}
+	}
+	return false
+}
+
+// adminGates returns the admin gates of the cluster, or none if the cluster has no admin gates
+func adminGates(c client.Client) (map[string]string, error) {
+	gates := &corev1.ConfigMap{}
+	err := c.Get(context.TODO(), client.ObjectKey{Namespace: adminGatesNamespace, Name: adminGatesConfigMap}, gates)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return gates.Data, nil
+}
+
+// adminAcksForRemovals returns whether each admin gate for the API removals of the desired
+// release has been acknowledged
+func adminAcksForRemovals(c client.Client, gates map[string]string, desired semver.Version) ([]string, error) {
+	if len(gates) == 0 {
+		return nil, nil
+	}
+	acks := &corev1.ConfigMap{}
+	err := c.Get(context.TODO(), client.ObjectKey{Namespace: adminAcksNamespace, Name: adminAcksConfigMap}, acks)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	suffix := fmt.Sprintf("api-removals-in-%d.%d", desired.Major, desired.Minor)
+	var result []string
+	for gate := range gates {
+		if !strings.HasSuffix(gate, suffix) {
+			continue
+		}
+		if acks.Data[gate] == "true" {
+			result = append(result, fmt.Sprintf("admin-ack %s is acknowledged", gate))
+		} else {
+			result = append(result, fmt.Sprintf("admin-ack %s is not acknowledged", gate))
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
@@ -0,0 +1,174 @@
+package upgraders
+
+import (
+	"github.com/blang/semver/v4"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	apiserverv1 "github.com/openshift/api/apiserver/v1"
+	configv1 "github.com/openshift/api/config/v1"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	cvMocks "github.com/openshift/managed-upgrade-operator/pkg/clusterversion/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+	mockMetrics "github.com/openshift/managed-upgrade-operator/pkg/metrics/mocks"
+	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
+)
+
+var _ = Describe("HealthCheck removed APIs", func() {
+	var (
+		logger            logr.Logger
+		mockCtrl          *gomock.Controller
+		mockMetricsClient *mockMetrics.MockMetrics
+		mockCVClient      *cvMocks.MockClusterVersion
+		upgradeConfig     *upgradev1alpha1.UpgradeConfig
+		objs              []client.Object
+		cfg               removedAPIsConfig
+
+		requestCount = func(name, removedInRelease string, users ...string) *apiserverv1.APIRequestCount {
+			byUser := []apiserverv1.PerUserAPIRequestCount{}
+			for _, u := range users {
+				byUser = append(byUser, apiserverv1.PerUserAPIRequestCount{UserName: u, RequestCount: 3})
+			}
+			return &apiserverv1.APIRequestCount{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Status: apiserverv1.APIRequestCountStatus{
+					RemovedInRelease: removedInRelease,
+					Last24h:          []apiserverv1.PerResourceAPIRequestLog{{ByNode: []apiserverv1.PerNodeAPIRequestLog{{ByUser: byUser}}}},
+				},
+			}
+		}
+		runCheck = func() (bool, error) {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(apiserverv1.Install(scheme)).To(Succeed())
+			kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+			mockCVClient.EXPECT().GetClusterVersion().Return(&configv1.ClusterVersion{
+				Status: configv1.ClusterVersionStatus{
+					History: []configv1.UpdateHistory{{State: configv1.CompletedUpdate, Version: "4.15.10"}},
+				},
+			}, nil)
+			return RemovedAPIsInUse(mockMetricsClient, kubeClient, mockCVClient, cfg, upgradeConfig, logger)
+		}
+	)
+
+	BeforeEach(func() {
+		logger = logf.Log.WithName("removed APIs test logger")
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockMetricsClient = mockMetrics.NewMockMetrics(mockCtrl)
+		mockCVClient = cvMocks.NewMockClusterVersion(mockCtrl)
+		upgradeConfig = testStructs.NewUpgradeConfigBuilder().GetUpgradeConfig()
+		upgradeConfig.Spec.Desired.Version = "4.16.2"
+		objs = []client.Object{
+			requestCount("flowschemas.v1beta1.flowcontrol.apiserver.k8s.io", "1.26", "alice"),
+			requestCount("flowschemas.v1beta2.flowcontrol.apiserver.k8s.io", "1.29", "system:serviceaccount:kube-system:generic-garbage-collector"),
+			requestCount("flowschemas.v1beta3.flowcontrol.apiserver.k8s.io", "1.32", "alice"),
+			requestCount("ingresses.v1.networking.k8s.io", "", "alice"),
+		}
+		cfg = removedAPIsConfig{IgnoredUsers: []string{"^system:serviceaccount:kube-system:"}}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("When only ignored users request the removed APIs", func() {
+		It("Prehealth check will pass", func() {
+			mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsQueryFailed)
+			mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsInUse)
+			result, err := runCheck()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+	})
+
+	Context("When removed APIs are in use", func() {
+		BeforeEach(func() {
+			objs = append(objs, requestCount("prioritylevelconfigurations.v1beta2.flowcontrol.apiserver.k8s.io", "1.29", "bob", "alice", "bob"))
+			mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsQueryFailed)
+			mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, metrics.RemovedAPIsInUse)
+		})
+
+		It("Prehealth check will fail and name the APIs and their users", func() {
+			result, err := runCheck()
+			Expect(err).To(MatchError("APIs removed in 4.16 (Kubernetes 1.29) are in use: prioritylevelconfigurations.v1beta2.flowcontrol.apiserver.k8s.io (users: alice, bob)"))
+			Expect(result).To(BeFalse())
+		})
+		It("Prehealth check will report whether the admin gate is acknowledged", func() {
+			objs = append(objs,
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: adminGatesNamespace, Name: adminGatesConfigMap},
+					Data:       map[string]string{"ack-4.15-kube-1.29-api-removals-in-4.16": "Kubernetes 1.29 removes APIs"},
+				},
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: adminAcksNamespace, Name: adminAcksConfigMap},
+					Data:       map[string]string{"ack-4.15-kube-1.29-api-removals-in-4.16": "true"},
+				},
+			)
+			result, err := runCheck()
+			Expect(err).To(MatchError(ContainSubstring("admin-ack ack-4.15-kube-1.29-api-removals-in-4.16 is acknowledged")))
+			Expect(result).To(BeFalse())
+		})
+	})
+
+	Context("When the upgrade is not y-stream", func() {
+		It("Prehealth check will pass without looking for removed APIs", func() {
+			upgradeConfig.Spec.Desired.Version = "4.15.11"
+			objs = append(objs, requestCount("prioritylevelconfigurations.v1beta2.flowcontrol.apiserver.k8s.io", "1.29", "bob"))
+			mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsQueryFailed)
+			mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsInUse)
+			result, err := runCheck()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+	})
+
+	Context("When mapping the target release to its Kubernetes version", func() {
+		It("follows from the OpenShift minor version", func() {
+			Expect(kubeVersionOf(semver.MustParse("4.16.2"), nil)).To(Equal(semver.MustParse("1.29.0")))
+		})
+		It("reads the Kubernetes version from the admin gate for the release's API removals", func() {
+			gates := map[string]string{
+				"ack-4.8-kube-1.22-api-removals-in-4.9":   "Kubernetes 1.22 removes APIs",
+				"ack-4.15-kube-1.30-api-removals-in-4.16": "Kubernetes 1.30 removes APIs",
+			}
+			Expect(kubeVersionOf(semver.MustParse("4.16.2"), gates)).To(Equal(semver.MustParse("1.30.0")))
+		})
+	})
+
+	Context("When the check is configured to warn", func() {
+		It("does not block the upgrade", func() {
+			upgrader := &clusterUpgrader{config: &upgraderConfig{HealthCheck: healthCheck{RemovedAPIs: removedAPIsConfig{Severity: "Warn"}}}}
+			var severities []healthCheckSeverity
+			for _, hc := range upgrader.healthChecks(healthCheckPreUpgrade) {
+				if hc.name == "RemovedAPIs" {
+					severities = append(severities, hc.severity)
+				}
+			}
+			Expect(severities).To(Equal([]healthCheckSeverity{healthCheckWarn}))
+		})
+	})
+
+	Context("When validating the configuration", func() {
+		It("blocks the upgrade by default", func() {
+			Expect(cfg.IsValid()).To(Succeed())
+			Expect(cfg.GetSeverity()).To(Equal(healthCheckBlock))
+		})
+		It("rejects an unknown severity", func() {
+			cfg.Severity = "Page"
+			Expect(cfg.IsValid()).To(MatchError(ContainSubstring("severity")))
+		})
+		It("rejects an invalid ignored user pattern", func() {
+			cfg.IgnoredUsers = []string{"system:("}
+			Expect(cfg.IsValid()).To(HaveOccurred())
+		})
+	})
+})
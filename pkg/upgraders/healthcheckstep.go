@@ -4,11 +4,12 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
-	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
 	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
 )
 
@@ -24,39 +25,8 @@ func (c *clusterUpgrader) PreUpgradeHealthCheck(ctx context.Context, logger logr
 	}
 
 	healthCheckFailed := []string{}
-
-	ok, err := CriticalAlerts(c.metrics, c.config, c.upgradeConfig, logger)
-	if err != nil || !ok {
-		logger.Info("upgrade may delay due to firing critical alerts")
-		healthCheckFailed = append(healthCheckFailed, "CriticalAlertsHealthcheckFailed")
-	}
-
-	ok, err = ClusterOperators(c.metrics, c.cvClient, c.upgradeConfig, logger)
-	if err != nil || !ok {
-		logger.Info("upgrade may delay due to cluster operators not ready")
-		healthCheckFailed = append(healthCheckFailed, "ClusterOperatorsHealthcheckFailed")
-	}
-
-	if c.upgradeConfig.Spec.CapacityReservation {
-		ok, err := c.scaler.CanScale(c.client, logger)
-		if !ok || err != nil {
-			c.metrics.UpdateMetricHealthcheckFailed(c.upgradeConfig.Name, metrics.DefaultWorkerMachinepoolNotFound)
-			healthCheckFailed = append(healthCheckFailed, "CapacityReservationHealthcheckFailed")
-		} else {
-			c.metrics.UpdateMetricHealthcheckSucceeded(c.upgradeConfig.Name, metrics.DefaultWorkerMachinepoolNotFound)
-		}
-	}
-
-	ok, err = ManuallyCordonedNodes(c.metrics, c.machinery, c.client, c.upgradeConfig, logger)
-	if err != nil || !ok {
-		logger.Info(fmt.Sprintf("upgrade may delay due to there are manually cordoned nodes: %s", err))
-		healthCheckFailed = append(healthCheckFailed, "NodeUnschedulableHealthcheckFailed")
-	}
-
-	ok, err = NodeUnschedulableTaints(c.metrics, c.machinery, c.client, c.upgradeConfig, logger)
-	if err != nil || !ok {
-		logger.Info(fmt.Sprintf("upgrade delayed due to there are unschedulable taints on nodes: %s", err))
-		healthCheckFailed = append(healthCheckFailed, "NodeUnschedulableTaintHealthcheckFailed")
+	for _, f := range c.runHealthChecks(healthCheckPreUpgrade, logger) {
+		healthCheckFailed = append(healthCheckFailed, f.definition.failure(f.err))
 	}
 
 	if len(healthCheckFailed) > 0 {
@@ -72,16 +42,119 @@ func (c *clusterUpgrader) PreUpgradeHealthCheck(ctx context.Context, logger logr
 	return true, nil
 }
 
-// PostUpgradeHealthCheck performs cluster healthy check
+// PostUpgradeHealthCheck performs cluster healthy check. When a soak period is configured, the
+// cluster must have stayed healthy for the whole period for the check to pass.
 func (c *clusterUpgrader) PostUpgradeHealthCheck(ctx context.Context, logger logr.Logger) (bool, error) {
-	ok, err := CriticalAlerts(c.metrics, c.config, c.upgradeConfig, logger)
-	if err != nil || !ok {
-		return false, err
+	failures := c.runHealthChecks(healthCheckPostUpgrade, logger)
+	soakPeriod := c.config.HealthCheck.GetSoakPeriodDuration()
+	if soakPeriod > 0 {
+		return c.soak(failures, soakPeriod, time.Now(), logger)
+	}
+	if len(failures) > 0 {
+		return false, failures[0].err
+	}
+	return true, nil
+}
+
+// soak records the outcome of a post-upgrade health check in the soak progress of the upgrade,
+// and returns whether the cluster has now been healthy for the whole soak period. A failed
+// health check restarts the soak period, and is recorded as a regression if the cluster had
+// been healthy.
+func (c *clusterUpgrader) soak(failures []failedHealthCheck, soakPeriod time.Duration, now time.Time, logger logr.Logger) (bool, error) {
+	history := c.upgradeConfig.Status.History.GetHistory(c.upgradeConfig.Spec.Desired.Version)
+	if history == nil {
+		return false, fmt.Errorf("no upgrade history for version %s", c.upgradeConfig.Spec.Desired.Version)
+	}
+	if history.Soak == nil {
+		history.Soak = &upgradev1alpha1.SoakStatus{}
 	}
+	soak := history.Soak
 
-	ok, err = ClusterOperators(c.metrics, c.cvClient, c.upgradeConfig, logger)
-	if err != nil || !ok {
-		return false, err
+	if len(failures) > 0 {
+		if soak.HealthySince != nil {
+			failed := []string{}
+			for _, f := range failures {
+				failed = append(failed, f.definition.name)
+			}
+			soak.Regressions++
+			soak.LastRegressionTime = &metav1.Time{Time: now}
+			soak.LastRegression = strings.Join(failed, ",")
+			soak.HealthySince = nil
+			logger.Info(fmt.Sprintf("Cluster became unhealthy during the post-upgrade soak period, restarting it: %s", soak.LastRegression))
+			c.upgradeConfig.Status.History.SetHistory(*history)
+		}
+		return false, failures[0].err
 	}
+
+	if soak.HealthySince == nil {
+		soak.HealthySince = &metav1.Time{Time: now}
+		c.upgradeConfig.Status.History.SetHistory(*history)
+	}
+	healthyFor := now.Sub(soak.HealthySince.Time)
+	if healthyFor < soakPeriod {
+		logger.Info(fmt.Sprintf("Cluster has been healthy for %s of the %s post-upgrade soak period", healthyFor.Round(time.Second), soakPeriod))
+		return false, nil
+	}
+	soak.CompleteTime = &metav1.Time{Time: now}
+	c.upgradeConfig.Status.History.SetHistory(*history)
+	logger.Info(fmt.Sprintf("Cluster stayed healthy for the %s post-upgrade soak period", soakPeriod))
 	return true, nil
 }
+
+// failedHealthCheck is a health check that failed and blocks the upgrade
+type failedHealthCheck struct {
+	definition healthCheckDefinition
+	err        error
+}
+
+// runHealthChecks runs every health check of the given stage, records their outcome as the
+// stage's health check report in the UpgradeConfig's status, and returns the failed checks
+// that block the upgrade
+func (c *clusterUpgrader) runHealthChecks(stage healthCheckStage, logger logr.Logger) []failedHealthCheck {
+	report, failures := c.checkHealth(stage, logger)
+	c.upgradeConfig.Status.HealthCheckReports.SetReport(report)
+	return failures
+}
+
+// checkHealth runs every health check of the given stage, and returns their outcome as a health
+// check report along with the failed checks that block the upgrade
+func (c *clusterUpgrader) checkHealth(stage healthCheckStage, logger logr.Logger) (upgradev1alpha1.HealthCheckReport, []failedHealthCheck) {
+	report := upgradev1alpha1.HealthCheckReport{
+		Stage:     upgradev1alpha1.HealthCheckStage(stage),
+		Version:   c.upgradeConfig.Spec.Desired.Version,
+		StartTime: metav1.Now(),
+		Passed:    true,
+	}
+	var failures []failedHealthCheck
+	for _, hc := range c.healthChecks(stage) {
+		ok, err := hc.check(c, c.metrics, logger)
+		result := upgradev1alpha1.HealthCheckResult{
+			Name:      hc.name,
+			Severity:  string(hc.severity),
+			Passed:    ok && err == nil,
+			CheckTime: metav1.Now(),
+		}
+		report.Checks = append(report.Checks, result)
+		if result.Passed {
+			continue
+		}
+		report.Checks[len(report.Checks)-1].Message = hc.failureMessage
+		if err != nil {
+			report.Checks[len(report.Checks)-1].Message = err.Error()
+			report.Checks[len(report.Checks)-1].FailedObjects = failedObjects(err)
+		}
+		if hc.severity == healthCheckWarn {
+			logger.Info(fmt.Sprintf("Health check %s failed, but does not block the upgrade: %v", hc.name, err))
+			continue
+		}
+		if err != nil {
+			logger.Info(fmt.Sprintf("%s: %s", hc.failureMessage, err))
+		} else {
+			logger.Info(hc.failureMessage)
+		}
+		report.Passed = false
+		failures = append(failures, failedHealthCheck{definition: hc, err: err})
+	}
+	report.CompleteTime = metav1.Now()
+	return report, failures
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
 	"github.com/go-logr/logr"
+	configv1 "github.com/openshift/api/config/v1"
 	"go.uber.org/mock/gomock"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -51,6 +53,9 @@ var _ = Describe("HealthCheck Step", func() {
 		// upgrader to be used during tests
 		config   *upgraderConfig
 		upgrader *clusterUpgrader
+
+		// cluster version of a z-stream upgrade
+		clusterVersion *configv1.ClusterVersion
 	)
 
 	BeforeEach(func() {
@@ -59,6 +64,11 @@ var _ = Describe("HealthCheck Step", func() {
 			Namespace: "test-namespace",
 		}
 		upgradeConfig = testStructs.NewUpgradeConfigBuilder().WithNamespacedName(upgradeConfigName).GetUpgradeConfig()
+		clusterVersion = &configv1.ClusterVersion{
+			Status: configv1.ClusterVersionStatus{
+				History: []configv1.UpdateHistory{{State: configv1.CompletedUpdate, Version: upgradeConfig.Spec.Desired.Version}},
+			},
+		}
 		mockCtrl = gomock.NewController(GinkgoT())
 		mockKubeClient = mocks.NewMockClient(mockCtrl)
 		mockMaintClient = mockMaintenance.NewMockMaintenance(mockCtrl)
@@ -131,6 +141,16 @@ var _ = Describe("HealthCheck Step", func() {
 					mockMachineryClient.EXPECT().HasPidPressure(gomock.Any()).Return(false),
 					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterNodeQueryFailed),
 					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterNodesTaintedUnschedulable),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetsBlocking),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DrainCapacityQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DrainCapacityInsufficient),
+					mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsInUse),
 				)
 				result, err := upgrader.PreUpgradeHealthCheck(context.TODO(), logger)
 				Expect(err).NotTo(HaveOccurred())
@@ -162,6 +182,16 @@ var _ = Describe("HealthCheck Step", func() {
 					mockMachineryClient.EXPECT().HasPidPressure(gomock.Any()).Return(false),
 					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterNodeQueryFailed),
 					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterNodesTaintedUnschedulable),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetsBlocking),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DrainCapacityQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DrainCapacityInsufficient),
+					mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsInUse),
 				)
 				result, err := upgrader.PreUpgradeHealthCheck(context.TODO(), logger)
 				Expect(err).To(Not(HaveOccurred()))
@@ -192,6 +222,16 @@ var _ = Describe("HealthCheck Step", func() {
 					mockMachineryClient.EXPECT().HasPidPressure(gomock.Any()).Return(false),
 					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterNodeQueryFailed),
 					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterNodesTaintedUnschedulable),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetsBlocking),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DrainCapacityQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DrainCapacityInsufficient),
+					mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsInUse),
 				)
 				result, err := upgrader.PreUpgradeHealthCheck(context.TODO(), logger)
 				Expect(err).To(Not(HaveOccurred()))
@@ -240,6 +280,16 @@ var _ = Describe("HealthCheck Step", func() {
 					mockMachineryClient.EXPECT().HasPidPressure(gomock.Any()).Return(false),
 					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterNodeQueryFailed),
 					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterNodesTaintedUnschedulable),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetsBlocking),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DrainCapacityQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DrainCapacityInsufficient),
+					mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsInUse),
 				)
 				result, err := upgrader.PreUpgradeHealthCheck(context.TODO(), logger)
 				Expect(err).NotTo(HaveOccurred())
@@ -287,6 +337,16 @@ var _ = Describe("HealthCheck Step", func() {
 					mockMachineryClient.EXPECT().HasPidPressure(gomock.Any()).Return(false),
 					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterNodeQueryFailed),
 					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterNodesTaintedUnschedulable),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetsBlocking),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DrainCapacityQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DrainCapacityInsufficient),
+					mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsInUse),
 				)
 				result, err := upgrader.PreUpgradeHealthCheck(context.TODO(), logger)
 				Expect(err).NotTo(HaveOccurred())
@@ -334,6 +394,16 @@ var _ = Describe("HealthCheck Step", func() {
 					mockMachineryClient.EXPECT().HasPidPressure(gomock.Any()).Return(false),
 					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterNodeQueryFailed),
 					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterNodesTaintedUnschedulable),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetsBlocking),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DrainCapacityQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DrainCapacityInsufficient),
+					mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsInUse),
 				)
 				result, err := upgrader.PreUpgradeHealthCheck(context.TODO(), logger)
 				Expect(err).NotTo(HaveOccurred())
@@ -398,6 +468,16 @@ var _ = Describe("HealthCheck Step", func() {
 					mockMachineryClient.EXPECT().HasPidPressure(gomock.Any()).Return(false),
 					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterNodeQueryFailed),
 					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterNodesTaintedUnschedulable),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetsBlocking),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DrainCapacityQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DrainCapacityInsufficient),
+					mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsInUse),
 					mockEMClient.EXPECT().NotifyResult(gomock.Any(), gomock.Any()).Return(nil),
 				)
 				result, err := upgrader.PreUpgradeHealthCheck(context.TODO(), logger)
@@ -408,11 +488,40 @@ var _ = Describe("HealthCheck Step", func() {
 				gomock.InOrder(
 					mockMetricsClient.EXPECT().Query(gomock.Any()).Return(alertsResponse, nil),
 					mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, gomock.Any()),
+					mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterOperatorsStatusFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterOperatorsDegraded),
 				)
 				result, err := upgrader.PostUpgradeHealthCheck(context.TODO(), logger)
 				Expect(err).To(HaveOccurred())
 				Expect(result).To(BeFalse())
 			})
+			It("will report the firing alerts in the post-upgrade health check report", func() {
+				alertsResponse.Data.Result[0].Metric["alertname"] = "KubeAPIDown"
+				alertsResponse.Data.Result[1].Metric["alertname"] = "etcdNoLeader"
+				gomock.InOrder(
+					mockMetricsClient.EXPECT().Query(gomock.Any()).Return(alertsResponse, nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, gomock.Any()),
+					mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterOperatorsStatusFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterOperatorsDegraded),
+				)
+				_, _ = upgrader.PostUpgradeHealthCheck(context.TODO(), logger)
+				report := upgradeConfig.Status.HealthCheckReports.GetReport(upgradev1alpha1.HealthCheckStagePost)
+				Expect(report).NotTo(BeNil())
+				Expect(report.Version).To(Equal(upgradeConfig.Spec.Desired.Version))
+				Expect(report.Passed).To(BeFalse())
+				Expect(report.Checks).To(HaveLen(2))
+				Expect(report.Checks[0].Name).To(Equal("CriticalAlerts"))
+				Expect(report.Checks[0].Passed).To(BeFalse())
+				Expect(report.Checks[0].Severity).To(Equal("Block"))
+				Expect(report.Checks[0].FailedObjects).To(Equal([]string{"KubeAPIDown", "etcdNoLeader"}))
+				Expect(report.Checks[0].Message).To(Equal("critical alert(s) firing: KubeAPIDown, etcdNoLeader"))
+				Expect(report.Checks[1].Name).To(Equal("ClusterOperators"))
+				Expect(report.Checks[1].Passed).To(BeTrue())
+				Expect(report.Checks[1].FailedObjects).To(BeEmpty())
+				Expect(upgradeConfig.Status.HealthCheckReports.GetReport(upgradev1alpha1.HealthCheckStagePre)).To(BeNil())
+			})
 		})
 
 		Context("When operators are degraded", func() {
@@ -441,6 +550,16 @@ var _ = Describe("HealthCheck Step", func() {
 					mockMachineryClient.EXPECT().HasPidPressure(gomock.Any()).Return(false),
 					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterNodeQueryFailed),
 					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterNodesTaintedUnschedulable),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetsBlocking),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DrainCapacityQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DrainCapacityInsufficient),
+					mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsInUse),
 					mockEMClient.EXPECT().NotifyResult(gomock.Any(), gomock.Any()).Return(nil),
 				)
 				result, err := upgrader.PreUpgradeHealthCheck(context.TODO(), logger)
@@ -497,11 +616,33 @@ var _ = Describe("HealthCheck Step", func() {
 					mockMachineryClient.EXPECT().HasPidPressure(gomock.Any()).Return(false),
 					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterNodeQueryFailed),
 					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterNodesTaintedUnschedulable),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetsBlocking),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DrainCapacityQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DrainCapacityInsufficient),
+					mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsInUse),
 					mockEMClient.EXPECT().NotifyResult(gomock.Any(), gomock.Any()).Return(nil),
 				)
 				result, err := upgrader.PreUpgradeHealthCheck(context.TODO(), logger)
 				Expect(err).To(BeNil())
 				Expect(result).To(BeFalse())
+				report := upgradeConfig.Status.HealthCheckReports.GetReport(upgradev1alpha1.HealthCheckStagePre)
+				Expect(report).NotTo(BeNil())
+				Expect(report.Passed).To(BeFalse())
+				Expect(report.Checks).To(HaveLen(8))
+				for _, check := range report.Checks {
+					if check.Name == "ManuallyCordonedNodes" {
+						Expect(check.Passed).To(BeFalse())
+						Expect(check.FailedObjects).To(Equal([]string{"testNode"}))
+					} else {
+						Expect(check.Passed).To(BeTrue())
+					}
+				}
 			})
 		})
 
@@ -548,6 +689,16 @@ var _ = Describe("HealthCheck Step", func() {
 					mockMachineryClient.EXPECT().HasDiskPressure(gomock.Any()).Return(false),
 					mockMachineryClient.EXPECT().HasPidPressure(gomock.Any()).Return(false),
 					mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, gomock.Any()),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetsBlocking),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DrainCapacityQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DrainCapacityInsufficient),
+					mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsInUse),
 					mockEMClient.EXPECT().NotifyResult(gomock.Any(), gomock.Any()).Return(nil),
 				)
 				result, err := upgrader.PreUpgradeHealthCheck(context.TODO(), logger)
@@ -592,6 +743,16 @@ var _ = Describe("HealthCheck Step", func() {
 					mockMachineryClient.EXPECT().HasDiskPressure(gomock.Any()).Return(true),
 					mockMachineryClient.EXPECT().HasPidPressure(gomock.Any()).Return(false),
 					mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, gomock.Any()),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetsBlocking),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DrainCapacityQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DrainCapacityInsufficient),
+					mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsInUse),
 					mockEMClient.EXPECT().NotifyResult(gomock.Any(), gomock.Any()).Return(nil),
 				)
 				result, err := upgrader.PreUpgradeHealthCheck(context.TODO(), logger)
@@ -636,6 +797,16 @@ var _ = Describe("HealthCheck Step", func() {
 					mockMachineryClient.EXPECT().HasDiskPressure(gomock.Any()).Return(false),
 					mockMachineryClient.EXPECT().HasPidPressure(gomock.Any()).Return(true),
 					mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, gomock.Any()),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetsBlocking),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DrainCapacityQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DrainCapacityInsufficient),
+					mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsInUse),
 					mockEMClient.EXPECT().NotifyResult(gomock.Any(), gomock.Any()).Return(nil),
 				)
 				result, err := upgrader.PreUpgradeHealthCheck(context.TODO(), logger)
@@ -685,6 +856,16 @@ var _ = Describe("HealthCheck Step", func() {
 					mockMachineryClient.EXPECT().HasDiskPressure(gomock.Any()).Return(false),
 					mockMachineryClient.EXPECT().HasPidPressure(gomock.Any()).Return(true),
 					mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, gomock.Any()),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetsBlocking),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DrainCapacityQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DrainCapacityInsufficient),
+					mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsInUse),
 					mockEMClient.EXPECT().NotifyResult(gomock.Any(), gomock.Any()).Return(nil),
 				)
 				result, err := upgrader.PreUpgradeHealthCheck(context.TODO(), logger)
@@ -750,6 +931,16 @@ var _ = Describe("HealthCheck Step", func() {
 					mockMachineryClient.EXPECT().HasDiskPressure(gomock.Any()).Return(true),
 					mockMachineryClient.EXPECT().HasPidPressure(gomock.Any()).Return(true),
 					mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(upgradeConfig.Name, gomock.Any()),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.PodDisruptionBudgetsBlocking),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+					mockKubeClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DrainCapacityQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.DrainCapacityInsufficient),
+					mockCVClient.EXPECT().GetClusterVersion().Return(clusterVersion, nil),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsQueryFailed),
+					mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.RemovedAPIsInUse),
 					mockEMClient.EXPECT().NotifyResult(gomock.Any(), gomock.Any()).Return(nil),
 				)
 				result, err := upgrader.PreUpgradeHealthCheck(context.TODO(), logger)
@@ -782,4 +973,84 @@ var _ = Describe("HealthCheck Step", func() {
 			})
 		})
 	})
+
+	Context("When a post-upgrade soak period is configured", func() {
+		var (
+			start    time.Time
+			failures []failedHealthCheck
+			soakOf   = func() *upgradev1alpha1.SoakStatus {
+				return upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version).Soak
+			}
+		)
+
+		BeforeEach(func() {
+			upgradeConfig.Status.History = upgradev1alpha1.UpgradeHistories{{Version: upgradeConfig.Spec.Desired.Version, Phase: upgradev1alpha1.UpgradePhaseUpgrading}}
+			config.HealthCheck.SoakPeriod = 30
+			start = time.Now()
+			failures = []failedHealthCheck{{
+				definition: builtinHealthChecks[0],
+				err:        &healthCheckFailure{reason: "critical alert(s) firing", objects: []string{"KubeAPIDown"}},
+			}}
+		})
+
+		It("will not pass on the first healthy sample", func() {
+			gomock.InOrder(
+				mockMetricsClient.EXPECT().Query(gomock.Any()).Return(&metrics.AlertResponse{}, nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.MetricsQueryFailed),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.CriticalAlertsFiring),
+				mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{}}, nil),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterOperatorsStatusFailed),
+				mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(upgradeConfig.Name, metrics.ClusterOperatorsDegraded),
+			)
+			result, err := upgrader.PostUpgradeHealthCheck(context.TODO(), logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeFalse())
+			Expect(soakOf().HealthySince).NotTo(BeNil())
+		})
+		It("will pass once the cluster has been healthy for the whole period", func() {
+			result, err := upgrader.soak(nil, 30*time.Minute, start, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeFalse())
+			result, err = upgrader.soak(nil, 30*time.Minute, start.Add(29*time.Minute), logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeFalse())
+			result, err = upgrader.soak(nil, 30*time.Minute, start.Add(30*time.Minute), logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+			Expect(soakOf().CompleteTime.Time).To(Equal(start.Add(30 * time.Minute)))
+			Expect(soakOf().Regressions).To(Equal(0))
+		})
+		It("will restart the period and record a regression when the cluster becomes unhealthy", func() {
+			_, _ = upgrader.soak(nil, 30*time.Minute, start, logger)
+			result, err := upgrader.soak(failures, 30*time.Minute, start.Add(20*time.Minute), logger)
+			Expect(err).To(MatchError("critical alert(s) firing: KubeAPIDown"))
+			Expect(result).To(BeFalse())
+			Expect(soakOf().HealthySince).To(BeNil())
+			Expect(soakOf().Regressions).To(Equal(1))
+			Expect(soakOf().LastRegressionTime.Time).To(Equal(start.Add(20 * time.Minute)))
+			Expect(soakOf().LastRegression).To(Equal("CriticalAlerts"))
+
+			// Still unhealthy, which is the same regression
+			_, _ = upgrader.soak(failures, 30*time.Minute, start.Add(21*time.Minute), logger)
+			Expect(soakOf().Regressions).To(Equal(1))
+
+			_, _ = upgrader.soak(nil, 30*time.Minute, start.Add(25*time.Minute), logger)
+			result, err = upgrader.soak(nil, 30*time.Minute, start.Add(50*time.Minute), logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeFalse())
+			result, err = upgrader.soak(nil, 30*time.Minute, start.Add(55*time.Minute), logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+		It("will not record a regression before the cluster first became healthy", func() {
+			result, err := upgrader.soak(failures, 30*time.Minute, start, logger)
+			Expect(err).To(HaveOccurred())
+			Expect(result).To(BeFalse())
+			Expect(soakOf()).To(BeNil())
+		})
+		It("will reject a negative soak period", func() {
+			config.HealthCheck.SoakPeriod = -1
+			Expect(config.HealthCheck.IsValid()).To(MatchError(ContainSubstring("soakPeriod")))
+		})
+	})
 })
@@ -0,0 +1,197 @@
+package upgraders
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+)
+
+const (
+	// hookNameLabel identifies the hook a Job was created for
+	hookNameLabel = "upgrade.managed.openshift.io/hook"
+	// hookPointLabel identifies the hook point a Job was created for
+	hookPointLabel = "upgrade.managed.openshift.io/hook-point"
+	// hookVersionLabel identifies the upgrade version a Job was created for
+	hookVersionLabel = "upgrade.managed.openshift.io/version"
+
+	// hookWarnedReason is the reason recorded for a failed hook that does not block the upgrade
+	hookWarnedReason = "HookFailedWarning"
+)
+
+// hookJobPrefixes abbreviate each hook point in the names of its hooks' Jobs
+var hookJobPrefixes = map[upgradev1alpha1.UpgradeConditionType]string{
+	upgradev1alpha1.PreUpgradeHooks:           "pre",
+	upgradev1alpha1.ControlPlaneUpgradedHooks: "cp",
+	upgradev1alpha1.PostUpgradeHooks:          "post",
+}
+
+// PreUpgradeHooks runs the hooks configured to run before the upgrade is commenced
+func (c *clusterUpgrader) PreUpgradeHooks(ctx context.Context, logger logr.Logger) (bool, error) {
+	upgradeCommenced, err := c.cvClient.HasUpgradeCommenced(c.upgradeConfig)
+	if err != nil {
+		return false, err
+	}
+	if upgradeCommenced {
+		logger.Info(fmt.Sprintf("Skipping upgrade step %s", upgradev1alpha1.PreUpgradeHooks))
+		return true, nil
+	}
+
+	return c.runHooks(ctx, logger, upgradev1alpha1.PreUpgradeHooks, c.config.Hooks.PreUpgrade)
+}
+
+// ControlPlaneUpgradedHooks runs the hooks configured to run once the control plane is upgraded
+func (c *clusterUpgrader) ControlPlaneUpgradedHooks(ctx context.Context, logger logr.Logger) (bool, error) {
+	return c.runHooks(ctx, logger, upgradev1alpha1.ControlPlaneUpgradedHooks, c.config.Hooks.ControlPlaneUpgraded)
+}
+
+// PostUpgradeHooks runs the hooks configured to run once all workers are upgraded
+func (c *clusterUpgrader) PostUpgradeHooks(ctx context.Context, logger logr.Logger) (bool, error) {
+	return c.runHooks(ctx, logger, upgradev1alpha1.PostUpgradeHooks, c.config.Hooks.WorkersUpgraded)
+}
+
+// runHooks launches the Job for each of the hooks at a hook point and waits for them to finish.
+// The outcome of each hook is recorded as an UpgradeCondition so that finished hooks are not
+// run again. A failed hook returns an error unless its failure policy is to only warn.
+func (c *clusterUpgrader) runHooks(ctx context.Context, logger logr.Logger, point upgradev1alpha1.UpgradeConditionType, hooks []upgradeHook) (bool, error) {
+	version := c.upgradeConfig.Spec.Desired.Version
+	done := true
+	for _, hook := range hooks {
+		conditionType := hookConditionType(point, hook)
+		history := c.upgradeConfig.Status.History.GetHistory(version)
+		if history != nil {
+			condition := history.Conditions.GetCondition(conditionType)
+			if condition != nil && (condition.IsTrue() || condition.Reason == hookWarnedReason) {
+				continue
+			}
+		}
+
+		job := &batchv1.Job{}
+		err := c.client.Get(ctx, client.ObjectKey{Namespace: hook.Namespace, Name: hookJobName(point, hook, version)}, job)
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				return false, err
+			}
+			job = newHookJob(point, hook, version)
+			logger.Info(fmt.Sprintf("Creating Job %s/%s for hook %s", job.Namespace, job.Name, hook.Name))
+			if err := c.client.Create(ctx, job); err != nil {
+				return false, fmt.Errorf("failed to create Job for hook %s: %v", hook.Name, err)
+			}
+			c.setHookCondition(conditionType, corev1.ConditionFalse, "HookRunning",
+				fmt.Sprintf("Hook Job %s/%s is running", job.Namespace, job.Name))
+			done = false
+			continue
+		}
+
+		finished, failed, message := hookJobResult(job)
+		switch {
+		case !finished:
+			logger.Info(fmt.Sprintf("Hook %s has not finished", hook.Name))
+			done = false
+		case !failed:
+			logger.Info(fmt.Sprintf("Hook %s succeeded", hook.Name))
+			c.setHookCondition(conditionType, corev1.ConditionTrue, "HookSucceeded",
+				fmt.Sprintf("Hook Job %s/%s succeeded", job.Namespace, job.Name))
+		case hook.BlocksUpgrade():
+			message = fmt.Sprintf("hook Job %s/%s failed: %s", job.Namespace, job.Name, message)
+			c.setHookCondition(conditionType, corev1.ConditionFalse, "HookFailed", message)
+			return false, fmt.Errorf("%s", message)
+		default:
+			message = fmt.Sprintf("Hook Job %s/%s failed: %s", job.Namespace, job.Name, message)
+			logger.Info(fmt.Sprintf("%s, continuing as the hook's failure policy is %s", message, hookFailureWarn))
+			c.setHookCondition(conditionType, corev1.ConditionFalse, hookWarnedReason, message)
+		}
+	}
+	return done, nil
+}
+
+// setHookCondition records the outcome of a hook in the upgrade history
+func (c *clusterUpgrader) setHookCondition(conditionType upgradev1alpha1.UpgradeConditionType, status corev1.ConditionStatus, reason, message string) {
+	history := c.upgradeConfig.Status.History.GetHistory(c.upgradeConfig.Spec.Desired.Version)
+	if history == nil {
+		return
+	}
+	condition := history.Conditions.GetCondition(conditionType)
+	if condition == nil {
+		condition = &upgradev1alpha1.UpgradeCondition{
+			Type:      conditionType,
+			StartTime: &metav1.Time{Time: time.Now()},
+		}
+	}
+	condition.Status = status
+	condition.Reason = reason
+	condition.Message = message
+	if status == corev1.ConditionTrue && condition.CompleteTime == nil {
+		condition.CompleteTime = &metav1.Time{Time: time.Now()}
+	}
+	history.Conditions.SetCondition(*condition)
+	c.upgradeConfig.Status.History.SetHistory(*history)
+}
+
+// hookConditionType returns the type of the UpgradeCondition recording a hook's outcome
+func hookConditionType(point upgradev1alpha1.UpgradeConditionType, hook upgradeHook) upgradev1alpha1.UpgradeConditionType {
+	return upgradev1alpha1.UpgradeConditionType(fmt.Sprintf("%s/%s", point, hook.Name))
+}
+
+// hookJobName returns the name of a hook's Job, which is unique to the hook point and upgrade version.
+// The Job's name is also the value of its pods' job-name label, so a name longer than a label value,
+// such as one for a nightly version, is truncated and suffixed with a hash of the full name.
+func hookJobName(point upgradev1alpha1.UpgradeConditionType, hook upgradeHook, version string) string {
+	name := fmt.Sprintf("muo-%s-%s-%s", hookJobPrefixes[point], hook.Name, strings.NewReplacer(".", "-", "+", "-").Replace(version))
+	if len(name) <= validation.LabelValueMaxLength {
+		return name
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	suffix := fmt.Sprintf("-%08x", h.Sum32())
+	return strings.TrimRight(name[:validation.LabelValueMaxLength-len(suffix)], "-") + suffix
+}
+
+// newHookJob returns the Job to be created to run a hook
+func newHookJob(point upgradev1alpha1.UpgradeConditionType, hook upgradeHook, version string) *batchv1.Job {
+	template := *hook.Template.DeepCopy()
+	if template.Spec.RestartPolicy == "" {
+		template.Spec.RestartPolicy = corev1.RestartPolicyNever
+	}
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      hookJobName(point, hook, version),
+			Namespace: hook.Namespace,
+			Labels: map[string]string{
+				hookNameLabel:    hook.Name,
+				hookPointLabel:   hookJobPrefixes[point],
+				hookVersionLabel: version,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			Template: template,
+		},
+	}
+}
+
+// hookJobResult returns whether a hook's Job has finished and, if so, whether it failed and why
+func hookJobResult(job *batchv1.Job) (finished bool, failed bool, message string) {
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			return true, false, ""
+		case batchv1.JobFailed:
+			return true, true, c.Message
+		}
+	}
+	return false, false, ""
+}
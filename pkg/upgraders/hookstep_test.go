@@ -0,0 +1,200 @@
+package upgraders
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"gopkg.in/yaml.v2"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	cvMocks "github.com/openshift/managed-upgrade-operator/pkg/clusterversion/mocks"
+	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
+)
+
+var _ = Describe("HookStep", func() {
+	var (
+		logger        logr.Logger
+		mockCtrl      *gomock.Controller
+		mockCVClient  *cvMocks.MockClusterVersion
+		kubeClient    client.Client
+		upgradeConfig *upgradev1alpha1.UpgradeConfig
+		upgrader      *clusterUpgrader
+		hook          upgradeHook
+
+		getJob = func() *batchv1.Job {
+			job := &batchv1.Job{}
+			key := client.ObjectKey{Namespace: hook.Namespace, Name: hookJobName(upgradev1alpha1.PostUpgradeHooks, hook, upgradeConfig.Spec.Desired.Version)}
+			Expect(kubeClient.Get(context.TODO(), key, job)).To(Succeed())
+			return job
+		}
+		finishJob = func(conditionType batchv1.JobConditionType) {
+			job := getJob()
+			job.Status.Conditions = []batchv1.JobCondition{{Type: conditionType, Status: corev1.ConditionTrue, Message: "job says no"}}
+			Expect(kubeClient.Status().Update(context.TODO(), job)).To(Succeed())
+		}
+		hookCondition = func() *upgradev1alpha1.UpgradeCondition {
+			history := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
+			return history.Conditions.GetCondition(hookConditionType(upgradev1alpha1.PostUpgradeHooks, hook))
+		}
+	)
+
+	BeforeEach(func() {
+		logger = logf.Log.WithName("hook step test logger")
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockCVClient = cvMocks.NewMockClusterVersion(mockCtrl)
+		kubeClient = fake.NewClientBuilder().WithStatusSubresource(&batchv1.Job{}).Build()
+		upgradeConfig = testStructs.NewUpgradeConfigBuilder().
+			WithNamespacedName(types.NamespacedName{Name: "test-upgradeconfig", Namespace: "test-namespace"}).
+			WithPhase(upgradev1alpha1.UpgradePhaseUpgrading).GetUpgradeConfig()
+		hook = upgradeHook{
+			Name:      "smoke-test",
+			Namespace: "test-hooks",
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "test", Image: "test"}}},
+			},
+		}
+		upgrader = &clusterUpgrader{
+			client:        kubeClient,
+			cvClient:      mockCVClient,
+			upgradeConfig: upgradeConfig,
+			config:        &upgraderConfig{Hooks: upgradeHooks{WorkersUpgraded: []upgradeHook{hook}}},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("When no hooks are configured", func() {
+		It("completes without creating any Job", func() {
+			upgrader.config.Hooks = upgradeHooks{}
+			result, err := upgrader.PostUpgradeHooks(context.TODO(), logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+			jobs := &batchv1.JobList{}
+			Expect(kubeClient.List(context.TODO(), jobs)).To(Succeed())
+			Expect(jobs.Items).To(BeEmpty())
+		})
+	})
+
+	Context("When a hook has not been run", func() {
+		It("creates its Job and waits for it", func() {
+			result, err := upgrader.PostUpgradeHooks(context.TODO(), logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeFalse())
+			job := getJob()
+			Expect(job.Labels).To(HaveKeyWithValue(hookNameLabel, hook.Name))
+			Expect(job.Spec.Template.Spec.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
+			Expect(hookCondition().IsFalse()).To(BeTrue())
+		})
+	})
+
+	Context("When a hook's Job succeeds", func() {
+		It("records the hook as completed", func() {
+			_, err := upgrader.PostUpgradeHooks(context.TODO(), logger)
+			Expect(err).NotTo(HaveOccurred())
+			finishJob(batchv1.JobComplete)
+			result, err := upgrader.PostUpgradeHooks(context.TODO(), logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+			Expect(hookCondition().IsTrue()).To(BeTrue())
+		})
+	})
+
+	Context("When a blocking hook's Job fails", func() {
+		It("returns an error", func() {
+			_, err := upgrader.PostUpgradeHooks(context.TODO(), logger)
+			Expect(err).NotTo(HaveOccurred())
+			finishJob(batchv1.JobFailed)
+			result, err := upgrader.PostUpgradeHooks(context.TODO(), logger)
+			Expect(err).To(MatchError(ContainSubstring("job says no")))
+			Expect(result).To(BeFalse())
+			Expect(hookCondition().Reason).To(Equal("HookFailed"))
+		})
+	})
+
+	Context("When a warning hook's Job fails", func() {
+		It("records a warning and completes", func() {
+			hook.FailurePolicy = hookFailureWarn
+			upgrader.config.Hooks.WorkersUpgraded = []upgradeHook{hook}
+			_, err := upgrader.PostUpgradeHooks(context.TODO(), logger)
+			Expect(err).NotTo(HaveOccurred())
+			finishJob(batchv1.JobFailed)
+			result, err := upgrader.PostUpgradeHooks(context.TODO(), logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+			Expect(hookCondition().Reason).To(Equal(hookWarnedReason))
+		})
+	})
+
+	Context("When the upgrade has already commenced", func() {
+		It("skips the pre-upgrade hooks", func() {
+			upgrader.config.Hooks = upgradeHooks{PreUpgrade: []upgradeHook{hook}}
+			mockCVClient.EXPECT().HasUpgradeCommenced(gomock.Any()).Return(true, nil)
+			result, err := upgrader.PreUpgradeHooks(context.TODO(), logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+		})
+	})
+
+	Context("When hooks are configured", func() {
+		It("decodes the pod template using its Kubernetes field names", func() {
+			hooks := upgradeHooks{}
+			Expect(yaml.Unmarshal([]byte(`
+workersUpgraded:
+- name: smoke-test
+  namespace: test-hooks
+  failurePolicy: Warn
+  template:
+    spec:
+      serviceAccountName: smoke-test
+      containers:
+      - name: test
+        image: quay.io/example/smoke-test:latest
+`), &hooks)).To(Succeed())
+			Expect(hooks.IsValid()).To(Succeed())
+			Expect(hooks.WorkersUpgraded).To(HaveLen(1))
+			Expect(hooks.WorkersUpgraded[0].BlocksUpgrade()).To(BeFalse())
+			Expect(hooks.WorkersUpgraded[0].Template.Spec.ServiceAccountName).To(Equal("smoke-test"))
+			Expect(hooks.WorkersUpgraded[0].Template.Spec.Containers[0].Image).To(Equal("quay.io/example/smoke-test:latest"))
+		})
+		It("rejects hooks without a namespace", func() {
+			hook.Namespace = ""
+			hooks := upgradeHooks{PreUpgrade: []upgradeHook{hook}}
+			Expect(hooks.IsValid()).To(MatchError(ContainSubstring("has no namespace")))
+		})
+		It("rejects unknown failure policies", func() {
+			hook.FailurePolicy = "Ignore"
+			hooks := upgradeHooks{PreUpgrade: []upgradeHook{hook}}
+			Expect(hooks.IsValid()).To(MatchError(ContainSubstring("failurePolicy")))
+		})
+		It("rejects duplicate hooks at a hook point", func() {
+			hooks := upgradeHooks{PreUpgrade: []upgradeHook{hook, hook}}
+			Expect(hooks.IsValid()).To(MatchError(ContainSubstring("duplicate hook")))
+		})
+		It("names the Jobs of the longest hook names and versions within a label value", func() {
+			hook.Name = strings.Repeat("h", maxHookNameLength)
+			hooks := upgradeHooks{ControlPlaneUpgraded: []upgradeHook{hook}}
+			Expect(hooks.IsValid()).To(Succeed())
+			nightly := hookJobName(upgradev1alpha1.ControlPlaneUpgradedHooks, hook, "4.16.0-0.nightly-2024-05-01-123456")
+			Expect(nightly).To(HaveLen(validation.LabelValueMaxLength))
+			Expect(validation.IsDNS1123Label(nightly)).To(BeEmpty())
+			Expect(validation.IsValidLabelValue(nightly)).To(BeEmpty())
+			Expect(nightly).NotTo(Equal(hookJobName(upgradev1alpha1.ControlPlaneUpgradedHooks, hook, "4.16.0-0.nightly-2024-05-01-123457")))
+		})
+		It("keeps the Job names of short versions readable", func() {
+			Expect(hookJobName(upgradev1alpha1.PostUpgradeHooks, hook, "4.15.12")).To(Equal("muo-post-smoke-test-4-15-12"))
+		})
+	})
+})
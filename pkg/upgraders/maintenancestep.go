@@ -48,20 +48,7 @@ func (c *clusterUpgrader) CreateWorkerMaintWindow(ctx context.Context, logger lo
 		return true, nil
 	}
 
-	// We use the maximum of the PDB drain timeout and node drain timeout to compute a 'worst case' wait time
-	pdbForceDrainTimeout := time.Duration(c.upgradeConfig.Spec.PDBForceDrainTimeout) * time.Minute
-	nodeDrainTimeout := c.config.NodeDrain.GetTimeOutDuration()
-	waitTimePeriod := time.Duration(pendingWorkerCount) * pdbForceDrainTimeout
-	if pdbForceDrainTimeout < nodeDrainTimeout {
-		waitTimePeriod = time.Duration(pendingWorkerCount) * nodeDrainTimeout
-	}
-
-	// Action time is the expected time taken to upgrade a worker node
-	maintenanceDurationPerNode := c.config.NodeDrain.GetExpectedDrainDuration()
-	actionTimePeriod := time.Duration(pendingWorkerCount) * maintenanceDurationPerNode
-
-	// Our worker maintenance window is a combination of 'wait time' and 'action time'
-	totalWorkerMaintenanceDuration := waitTimePeriod + actionTimePeriod
+	totalWorkerMaintenanceDuration := c.workerMaintenanceDuration(pendingWorkerCount)
 
 	endTime := time.Now().Add(totalWorkerMaintenanceDuration)
 	logger.Info(fmt.Sprintf("Creating worker node maintenace for %d remaining nodes if no previous silence, ending at %v", pendingWorkerCount, endTime))
@@ -82,3 +69,22 @@ func (c *clusterUpgrader) RemoveMaintWindow(ctx context.Context, logger logr.Log
 
 	return true, nil
 }
+
+// workerMaintenanceDuration returns the expected worst case time taken to upgrade the given
+// number of worker nodes
+func (c *clusterUpgrader) workerMaintenanceDuration(pendingWorkerCount int32) time.Duration {
+	// We use the maximum of the PDB drain timeout and node drain timeout to compute a 'worst case' wait time
+	pdbForceDrainTimeout := time.Duration(c.upgradeConfig.Spec.PDBForceDrainTimeout) * time.Minute
+	nodeDrainTimeout := c.config.NodeDrain.GetTimeOutDuration()
+	waitTimePeriod := time.Duration(pendingWorkerCount) * pdbForceDrainTimeout
+	if pdbForceDrainTimeout < nodeDrainTimeout {
+		waitTimePeriod = time.Duration(pendingWorkerCount) * nodeDrainTimeout
+	}
+
+	// Action time is the expected time taken to upgrade a worker node
+	maintenanceDurationPerNode := c.config.NodeDrain.GetExpectedDrainDuration()
+	actionTimePeriod := time.Duration(pendingWorkerCount) * maintenanceDurationPerNode
+
+	// Our worker maintenance window is a combination of 'wait time' and 'action time'
+	return waitTimePeriod + actionTimePeriod
+}
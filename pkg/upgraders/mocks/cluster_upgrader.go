@@ -41,6 +41,21 @@ func (m *MockClusterUpgrader) EXPECT() *MockClusterUpgraderMockRecorder {
 	return m.recorder
 }
 
+// CancelUpgrade mocks base method.
+func (m *MockClusterUpgrader) CancelUpgrade(arg0 context.Context, arg1 *v1alpha1.UpgradeConfig, arg2 logr.Logger) (v1alpha1.UpgradePhase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelUpgrade", arg0, arg1, arg2)
+	ret0, _ := ret[0].(v1alpha1.UpgradePhase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelUpgrade indicates an expected call of CancelUpgrade.
+func (mr *MockClusterUpgraderMockRecorder) CancelUpgrade(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelUpgrade", reflect.TypeOf((*MockClusterUpgrader)(nil).CancelUpgrade), arg0, arg1, arg2)
+}
+
 // HealthCheck mocks base method.
 func (m *MockClusterUpgrader) HealthCheck(arg0 context.Context, arg1 *v1alpha1.UpgradeConfig, arg2 logr.Logger) (bool, error) {
 	m.ctrl.T.Helper()
@@ -56,6 +71,21 @@ func (mr *MockClusterUpgraderMockRecorder) HealthCheck(arg0, arg1, arg2 any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HealthCheck", reflect.TypeOf((*MockClusterUpgrader)(nil).HealthCheck), arg0, arg1, arg2)
 }
 
+// Rehearse mocks base method.
+func (m *MockClusterUpgrader) Rehearse(arg0 context.Context, arg1 *v1alpha1.UpgradeConfig, arg2 logr.Logger) ([]v1alpha1.RehearsalCheck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rehearse", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]v1alpha1.RehearsalCheck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rehearse indicates an expected call of Rehearse.
+func (mr *MockClusterUpgraderMockRecorder) Rehearse(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rehearse", reflect.TypeOf((*MockClusterUpgrader)(nil).Rehearse), arg0, arg1, arg2)
+}
+
 // UpgradeCluster mocks base method.
 func (m *MockClusterUpgrader) UpgradeCluster(arg0 context.Context, arg1 *v1alpha1.UpgradeConfig, arg2 logr.Logger) (v1alpha1.UpgradePhase, error) {
 	m.ctrl.T.Helper()
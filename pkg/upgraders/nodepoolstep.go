@@ -0,0 +1,26 @@
+package upgraders
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+)
+
+// CommenceNodePoolsUpgrade will update the NodePools of a hosted control plane cluster to the release
+// of its upgraded control plane, to trigger the upgrade of its workers
+func (c *clusterUpgrader) CommenceNodePoolsUpgrade(ctx context.Context, logger logr.Logger) (bool, error) {
+	if c.hostedCluster == nil {
+		return false, fmt.Errorf("upgrade step %s is only supported on clusters with a hosted control plane", upgradev1alpha1.CommenceNodePoolsUpgrade)
+	}
+
+	isComplete, err := c.hostedCluster.EnsureNodePoolsRelease()
+	if err != nil {
+		logger.Info("NodePools have not been updated to the desired release, will retry on next reconcile")
+		return false, err
+	}
+
+	return isComplete, nil
+}
@@ -19,7 +19,6 @@ import (
 	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
 	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
 	"github.com/openshift/managed-upgrade-operator/pkg/scaler"
-	"github.com/openshift/managed-upgrade-operator/pkg/upgradesteps"
 )
 
 // osdUpgrader is a cluster upgrader suitable for OpenShift Dedicated clusters.
@@ -28,6 +27,31 @@ type osdUpgrader struct {
 	*clusterUpgrader
 }
 
+// osdUpgradeSteps are the default steps, in order, used by the osdUpgrader
+var osdUpgradeSteps = []upgradev1alpha1.UpgradeConditionType{
+	upgradev1alpha1.SendStartedNotification,
+	upgradev1alpha1.UpgradeDelayedCheck,
+	upgradev1alpha1.IsClusterUpgradable,
+	upgradev1alpha1.UpgradePreHealthCheck,
+	upgradev1alpha1.ExtDepAvailabilityCheck,
+	upgradev1alpha1.UpgradeScaleUpExtraNodes,
+	upgradev1alpha1.ControlPlaneMaintWindow,
+	upgradev1alpha1.PreUpgradeHooks,
+	upgradev1alpha1.CommenceUpgrade,
+	upgradev1alpha1.ControlPlaneUpgraded,
+	upgradev1alpha1.ControlPlaneUpgradedHooks,
+	upgradev1alpha1.RemoveControlPlaneMaintWindow,
+	upgradev1alpha1.WorkersMaintWindow,
+	upgradev1alpha1.CanaryWorkersUpgraded,
+	upgradev1alpha1.AllWorkerNodesUpgraded,
+	upgradev1alpha1.PostUpgradeHooks,
+	upgradev1alpha1.RemoveExtraScaledNodes,
+	upgradev1alpha1.RemoveMaintWindow,
+	upgradev1alpha1.PostClusterHealthCheck,
+	upgradev1alpha1.PostUpgradeProcedures,
+	upgradev1alpha1.SendCompletedNotification,
+}
+
 // NewOSDUpgrader creates a new instance of an osdUpgrader
 func NewOSDUpgrader(c client.Client, cfm configmanager.ConfigManager, mc metrics.Metrics, notifier eventmanager.EventManager) (*osdUpgrader, error) {
 	cfg := &upgraderConfig{}
@@ -61,24 +85,9 @@ func NewOSDUpgrader(c client.Client, cfm configmanager.ConfigManager, mc metrics
 		},
 	}
 
-	steps := []upgradesteps.UpgradeStep{
-		upgradesteps.Action(string(upgradev1alpha1.SendStartedNotification), ou.SendStartedNotification),
-		upgradesteps.Action(string(upgradev1alpha1.SendStartedNotification), ou.UpgradeDelayedCheck),
-		upgradesteps.Action(string(upgradev1alpha1.IsClusterUpgradable), ou.IsUpgradeable),
-		upgradesteps.Action(string(upgradev1alpha1.UpgradePreHealthCheck), ou.PreUpgradeHealthCheck),
-		upgradesteps.Action(string(upgradev1alpha1.ExtDepAvailabilityCheck), ou.ExternalDependencyAvailabilityCheck),
-		upgradesteps.Action(string(upgradev1alpha1.UpgradeScaleUpExtraNodes), ou.EnsureExtraUpgradeWorkers),
-		upgradesteps.Action(string(upgradev1alpha1.ControlPlaneMaintWindow), ou.CreateControlPlaneMaintWindow),
-		upgradesteps.Action(string(upgradev1alpha1.CommenceUpgrade), ou.CommenceUpgrade),
-		upgradesteps.Action(string(upgradev1alpha1.ControlPlaneUpgraded), ou.ControlPlaneUpgraded),
-		upgradesteps.Action(string(upgradev1alpha1.RemoveControlPlaneMaintWindow), ou.RemoveControlPlaneMaintWindow),
-		upgradesteps.Action(string(upgradev1alpha1.WorkersMaintWindow), ou.CreateWorkerMaintWindow),
-		upgradesteps.Action(string(upgradev1alpha1.AllWorkerNodesUpgraded), ou.AllWorkersUpgraded),
-		upgradesteps.Action(string(upgradev1alpha1.RemoveExtraScaledNodes), ou.RemoveExtraScaledNodes),
-		upgradesteps.Action(string(upgradev1alpha1.RemoveMaintWindow), ou.RemoveMaintWindow),
-		upgradesteps.Action(string(upgradev1alpha1.PostClusterHealthCheck), ou.PostUpgradeHealthCheck),
-		upgradesteps.Action(string(upgradev1alpha1.PostUpgradeProcedures), ou.PostUpgradeProcedures),
-		upgradesteps.Action(string(upgradev1alpha1.SendCompletedNotification), ou.SendCompletedNotification),
+	steps, err := ou.buildSteps(osdUpgradeSteps)
+	if err != nil {
+		return nil, err
 	}
 	ou.steps = steps
 
@@ -95,7 +104,7 @@ func (u *osdUpgrader) UpgradeCluster(ctx context.Context, upgradeConfig *upgrade
 
 	// OSD upgrader enforces a 'failure' policy if the upgrade does not commence within a time period
 	if cancelUpgrade, _ := shouldFailUpgrade(u.cvClient, u.config, u.upgradeConfig); cancelUpgrade {
-		return performUpgradeFailure(u.client, u.metrics, u.scaler, u.notifier, u.upgradeConfig, logger)
+		return u.performUpgradeFailure(logger)
 	}
 
 	return u.runSteps(ctx, logger, u.steps)
@@ -138,9 +147,9 @@ func shouldFailUpgrade(cvClient cv.ClusterVersion, cfg *upgraderConfig, upgradeC
 }
 
 // performUpgradeFailure carries out routines related to moving to an upgrade-failed state
-func performUpgradeFailure(c client.Client, metricsClient metrics.Metrics, s scaler.Scaler, nc eventmanager.EventManager, upgradeConfig *upgradev1alpha1.UpgradeConfig, logger logr.Logger) (upgradev1alpha1.UpgradePhase, error) {
+func (c *clusterUpgrader) performUpgradeFailure(logger logr.Logger) (upgradev1alpha1.UpgradePhase, error) {
 	// Set up return condition
-	h := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
+	h := c.upgradeConfig.Status.History.GetHistory(c.upgradeConfig.Spec.Desired.Version)
 	condition := &upgradev1alpha1.UpgradeCondition{
 		Type:    "FailedUpgrade",
 		Status:  corev1.ConditionFalse,
@@ -148,8 +157,16 @@ func performUpgradeFailure(c client.Client, metricsClient metrics.Metrics, s sca
 		Message: "FailedUpgrade notification sent",
 	}
 
+	// Release the worker pools paused for the upgrade, as a failed upgrade is not reconciled further
+	err := c.resumeWorkerPools()
+	if err != nil {
+		logger.Error(err, "Failed to resume the worker machineconfigpools when upgrade failed")
+		h.Conditions.SetCondition(*condition)
+		return h.Phase, nil
+	}
+
 	// TearDown the extra machineset
-	_, err := s.EnsureScaleDownNodes(c, nil, logger)
+	_, err = c.scaler.EnsureScaleDownNodes(c.client, nil, logger)
 	if err != nil {
 		logger.Error(err, "Failed to scale down the temporary upgrade machine when upgrade failed")
 		h.Conditions.SetCondition(*condition)
@@ -157,7 +174,7 @@ func performUpgradeFailure(c client.Client, metricsClient metrics.Metrics, s sca
 	}
 
 	// Notify of failure
-	err = nc.Notify(notifier.MuoStateFailed)
+	err = c.notifier.Notify(notifier.MuoStateFailed)
 	if err != nil {
 		logger.Error(err, "Failed to notify of upgrade failure")
 		h.Conditions.SetCondition(*condition)
@@ -165,10 +182,10 @@ func performUpgradeFailure(c client.Client, metricsClient metrics.Metrics, s sca
 	}
 
 	// flag window breached metric
-	metricsClient.UpdateMetricUpgradeWindowBreached(upgradeConfig.Name)
+	c.metrics.UpdateMetricUpgradeWindowBreached(c.upgradeConfig.Name)
 
 	// cancel previously triggered metrics
-	metricsClient.ResetFailureMetrics()
+	c.metrics.ResetFailureMetrics()
 
 	// Update condition state to successful
 	condition.Status = corev1.ConditionTrue
@@ -0,0 +1,100 @@
+package upgraders
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	cvMocks "github.com/openshift/managed-upgrade-operator/pkg/clusterversion/mocks"
+	emMocks "github.com/openshift/managed-upgrade-operator/pkg/eventmanager/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
+	mockMachinery "github.com/openshift/managed-upgrade-operator/pkg/machinery/mocks"
+	mockMetrics "github.com/openshift/managed-upgrade-operator/pkg/metrics/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
+	mockScaler "github.com/openshift/managed-upgrade-operator/pkg/scaler/mocks"
+	"github.com/openshift/managed-upgrade-operator/util/mocks"
+	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
+)
+
+var _ = Describe("OSD upgrader", func() {
+	var (
+		logger              logr.Logger
+		mockCtrl            *gomock.Controller
+		mockKubeClient      *mocks.MockClient
+		mockCVClient        *cvMocks.MockClusterVersion
+		mockScalerClient    *mockScaler.MockScaler
+		mockMachineryClient *mockMachinery.MockMachinery
+		mockMetricsClient   *mockMetrics.MockMetrics
+		mockEMClient        *emMocks.MockEventManager
+		upgradeConfig       *upgradev1alpha1.UpgradeConfig
+		upgrader            *osdUpgrader
+	)
+
+	BeforeEach(func() {
+		logger = logf.Log.WithName("osd upgrader test logger")
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKubeClient = mocks.NewMockClient(mockCtrl)
+		mockCVClient = cvMocks.NewMockClusterVersion(mockCtrl)
+		mockScalerClient = mockScaler.NewMockScaler(mockCtrl)
+		mockMachineryClient = mockMachinery.NewMockMachinery(mockCtrl)
+		mockMetricsClient = mockMetrics.NewMockMetrics(mockCtrl)
+		mockEMClient = emMocks.NewMockEventManager(mockCtrl)
+		upgradeConfig = testStructs.NewUpgradeConfigBuilder().WithPhase(upgradev1alpha1.UpgradePhaseUpgrading).GetUpgradeConfig()
+		upgrader = &osdUpgrader{
+			clusterUpgrader: &clusterUpgrader{
+				client:    mockKubeClient,
+				cvClient:  mockCVClient,
+				scaler:    mockScalerClient,
+				machinery: mockMachineryClient,
+				metrics:   mockMetricsClient,
+				notifier:  mockEMClient,
+				config:    buildTestUpgraderConfig(90, 30, 8, 120, 30),
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("When a paused upgrade does not commence within the upgrade window", func() {
+		BeforeEach(func() {
+			upgradeConfig.Spec.Paused = true
+			upgradeConfig.Status.History[0].StartTime = &metav1.Time{Time: time.Now().Add(-3 * time.Hour)}
+		})
+
+		It("fails the upgrade and resumes the paused worker pools", func() {
+			gomock.InOrder(
+				mockCVClient.EXPECT().HasUpgradeCommenced(upgradeConfig).Return(false, nil),
+				mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), machinery.WorkerNodeType).Return(&machinery.UpgradingResult{Pools: []machinery.PoolUpgradingResult{{Name: "worker"}}}, nil),
+				mockMachineryClient.EXPECT().SetPoolPaused(gomock.Any(), "worker", false).Return(nil),
+				mockScalerClient.EXPECT().EnsureScaleDownNodes(gomock.Any(), nil, gomock.Any()).Return(true, nil),
+				mockEMClient.EXPECT().Notify(notifier.MuoStateFailed).Return(nil),
+				mockMetricsClient.EXPECT().UpdateMetricUpgradeWindowBreached(upgradeConfig.Name),
+				mockMetricsClient.EXPECT().ResetFailureMetrics(),
+			)
+			phase, err := upgrader.UpgradeCluster(context.TODO(), upgradeConfig, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(phase).To(Equal(upgradev1alpha1.UpgradePhaseFailed))
+		})
+
+		It("does not fail the upgrade until the worker pools are resumed", func() {
+			gomock.InOrder(
+				mockCVClient.EXPECT().HasUpgradeCommenced(upgradeConfig).Return(false, nil),
+				mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), machinery.WorkerNodeType).Return(nil, fmt.Errorf("fake error")),
+			)
+			mockEMClient.EXPECT().Notify(gomock.Any()).Times(0)
+			phase, err := upgrader.UpgradeCluster(context.TODO(), upgradeConfig, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(phase).To(Equal(upgradev1alpha1.UpgradePhaseUpgrading))
+		})
+	})
+})
@@ -0,0 +1,172 @@
+package upgraders
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/upgradesteps"
+)
+
+// stepStage is the stage of an upgrade that a step belongs to. Steps may only be
+// reordered within their stage, so that for example no step that prepares for the
+// upgrade can run after the upgrade has commenced.
+type stepStage int
+
+const (
+	stagePreUpgrade stepStage = iota
+	stageCommence
+	stageControlPlane
+	stagePreWorkers
+	stageWorkers
+	stagePostUpgrade
+)
+
+// stepDefinition describes an upgrade step implementation that can be included in
+// an upgrader's pipeline
+type stepDefinition struct {
+	// Stage of the upgrade the step belongs to
+	stage stepStage
+	// Required steps can't be disabled or omitted from a pipeline
+	required bool
+	// The step's action, as a method of the upgrader that runs it
+	action func(*clusterUpgrader, context.Context, logr.Logger) (bool, error)
+}
+
+// stepRegistry holds the upgrade step implementations, by name, that pipelines may be built from
+var stepRegistry = map[upgradev1alpha1.UpgradeConditionType]stepDefinition{
+	upgradev1alpha1.SendStartedNotification:       {stage: stagePreUpgrade, action: (*clusterUpgrader).SendStartedNotification},
+	upgradev1alpha1.UpgradeDelayedCheck:           {stage: stagePreUpgrade, action: (*clusterUpgrader).UpgradeDelayedCheck},
+	upgradev1alpha1.IsClusterUpgradable:           {stage: stagePreUpgrade, action: (*clusterUpgrader).IsUpgradeable},
+	upgradev1alpha1.UpgradePreHealthCheck:         {stage: stagePreUpgrade, action: (*clusterUpgrader).PreUpgradeHealthCheck},
+	upgradev1alpha1.ExtDepAvailabilityCheck:       {stage: stagePreUpgrade, action: (*clusterUpgrader).ExternalDependencyAvailabilityCheck},
+	upgradev1alpha1.UpgradeScaleUpExtraNodes:      {stage: stagePreUpgrade, action: (*clusterUpgrader).EnsureExtraUpgradeWorkers},
+	upgradev1alpha1.ControlPlaneMaintWindow:       {stage: stagePreUpgrade, action: (*clusterUpgrader).CreateControlPlaneMaintWindow},
+	upgradev1alpha1.PreUpgradeHooks:               {stage: stagePreUpgrade, action: (*clusterUpgrader).PreUpgradeHooks},
+	upgradev1alpha1.CommenceUpgrade:               {stage: stageCommence, required: true, action: (*clusterUpgrader).CommenceUpgrade},
+	upgradev1alpha1.ControlPlaneUpgraded:          {stage: stageControlPlane, required: true, action: (*clusterUpgrader).ControlPlaneUpgraded},
+	upgradev1alpha1.ControlPlaneUpgradedHooks:     {stage: stagePreWorkers, action: (*clusterUpgrader).ControlPlaneUpgradedHooks},
+	upgradev1alpha1.RemoveControlPlaneMaintWindow: {stage: stagePreWorkers, action: (*clusterUpgrader).RemoveControlPlaneMaintWindow},
+	upgradev1alpha1.CommenceNodePoolsUpgrade:      {stage: stagePreWorkers, action: (*clusterUpgrader).CommenceNodePoolsUpgrade},
+	upgradev1alpha1.WorkersMaintWindow:            {stage: stagePreWorkers, action: (*clusterUpgrader).CreateWorkerMaintWindow},
+	upgradev1alpha1.CanaryWorkersUpgraded:         {stage: stagePreWorkers, action: (*clusterUpgrader).CanaryWorkersUpgraded},
+	upgradev1alpha1.AllWorkerNodesUpgraded:        {stage: stageWorkers, required: true, action: (*clusterUpgrader).AllWorkersUpgraded},
+	upgradev1alpha1.RemoveExtraScaledNodes:        {stage: stagePostUpgrade, action: (*clusterUpgrader).RemoveExtraScaledNodes},
+	upgradev1alpha1.RemoveMaintWindow:             {stage: stagePostUpgrade, action: (*clusterUpgrader).RemoveMaintWindow},
+	upgradev1alpha1.PostClusterHealthCheck:        {stage: stagePostUpgrade, action: (*clusterUpgrader).PostUpgradeHealthCheck},
+	upgradev1alpha1.PostUpgradeHooks:              {stage: stagePostUpgrade, action: (*clusterUpgrader).PostUpgradeHooks},
+	upgradev1alpha1.PostUpgradeProcedures:         {stage: stagePostUpgrade, action: (*clusterUpgrader).PostUpgradeProcedures},
+	upgradev1alpha1.SendCompletedNotification:     {stage: stagePostUpgrade, action: (*clusterUpgrader).SendCompletedNotification},
+}
+
+// validateUpgradeSteps checks that a configured upgrade step pipeline only refers to known
+// steps, includes every required step, and keeps each step within its stage of the upgrade
+func validateUpgradeSteps(steps []upgradeStepConfig) error {
+	if len(steps) == 0 {
+		return nil
+	}
+
+	seen := make(map[upgradev1alpha1.UpgradeConditionType]bool, len(steps))
+	lastStage := stagePreUpgrade
+	var lastStep string
+	for _, s := range steps {
+		name := upgradev1alpha1.UpgradeConditionType(s.Name)
+		def, ok := stepRegistry[name]
+		if !ok {
+			return fmt.Errorf("config upgradeSteps has unknown step %q", s.Name)
+		}
+		if seen[name] {
+			return fmt.Errorf("config upgradeSteps has duplicate step %q", s.Name)
+		}
+		seen[name] = true
+		if s.TimeOut < 0 {
+			return fmt.Errorf("config upgradeSteps step %q timeOut is invalid", s.Name)
+		}
+		if s.MaxAttempts < 0 {
+			return fmt.Errorf("config upgradeSteps step %q maxAttempts is invalid", s.Name)
+		}
+		if s.Backoff < 0 {
+			return fmt.Errorf("config upgradeSteps step %q backoff is invalid", s.Name)
+		}
+		if err := validateExhaustedAction(s, def); err != nil {
+			return err
+		}
+		if def.required && !s.IsEnabled() {
+			return fmt.Errorf("config upgradeSteps step %q can't be disabled", s.Name)
+		}
+		if def.stage < lastStage {
+			return fmt.Errorf("config upgradeSteps step %q can't run after step %q", s.Name, lastStep)
+		}
+		lastStage = def.stage
+		lastStep = s.Name
+	}
+
+	for name, def := range stepRegistry {
+		if def.required && !seen[name] {
+			return fmt.Errorf("config upgradeSteps is missing required step %q", name)
+		}
+	}
+	return nil
+}
+
+// validateExhaustedAction checks that the action taken when a step exhausts its retry budget
+// is one the step supports
+func validateExhaustedAction(s upgradeStepConfig, def stepDefinition) error {
+	switch upgradesteps.ExhaustedAction(s.OnExhausted) {
+	case upgradesteps.ExhaustedActionNone:
+		return nil
+	case upgradesteps.ExhaustedActionNotify:
+	case upgradesteps.ExhaustedActionFail:
+		// An upgrade can only be failed before it has commenced
+		if def.stage != stagePreUpgrade {
+			return fmt.Errorf("config upgradeSteps step %q can't fail the upgrade once it has commenced", s.Name)
+		}
+	case upgradesteps.ExhaustedActionContinue:
+		if def.required {
+			return fmt.Errorf("config upgradeSteps step %q is required so can't be continued past", s.Name)
+		}
+	default:
+		return fmt.Errorf("config upgradeSteps step %q onExhausted must be one of %s, %s or %s", s.Name,
+			upgradesteps.ExhaustedActionFail, upgradesteps.ExhaustedActionNotify, upgradesteps.ExhaustedActionContinue)
+	}
+	if s.TimeOut == 0 && s.MaxAttempts == 0 {
+		return fmt.Errorf("config upgradeSteps step %q onExhausted requires a timeOut or maxAttempts", s.Name)
+	}
+	return nil
+}
+
+// buildSteps returns the upgrader's ordered upgrade steps. The pipeline configured in the
+// ConfigMap is used if there is one, otherwise the upgrader's default steps are used.
+func (c *clusterUpgrader) buildSteps(defaults []upgradev1alpha1.UpgradeConditionType) ([]upgradesteps.UpgradeStep, error) {
+	configured := c.config.UpgradeSteps
+	if len(configured) == 0 {
+		configured = make([]upgradeStepConfig, 0, len(defaults))
+		for _, name := range defaults {
+			configured = append(configured, upgradeStepConfig{Name: string(name)})
+		}
+	}
+
+	err := validateUpgradeSteps(configured)
+	if err != nil {
+		return nil, err
+	}
+
+	steps := make([]upgradesteps.UpgradeStep, 0, len(configured))
+	for _, s := range configured {
+		if !s.IsEnabled() {
+			continue
+		}
+		def := stepRegistry[upgradev1alpha1.UpgradeConditionType(s.Name)]
+		action := def.action
+		var step upgradesteps.UpgradeStep = upgradesteps.Action(s.Name, func(ctx context.Context, logger logr.Logger) (bool, error) {
+			return action(c, ctx, logger)
+		})
+		if policy := s.GetPolicy(); policy != (upgradesteps.StepPolicy{}) {
+			step = upgradesteps.WithPolicy(step, policy)
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
@@ -0,0 +1,181 @@
+package upgraders
+
+import (
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	emMocks "github.com/openshift/managed-upgrade-operator/pkg/eventmanager/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
+	mockMachinery "github.com/openshift/managed-upgrade-operator/pkg/machinery/mocks"
+	mockMetrics "github.com/openshift/managed-upgrade-operator/pkg/metrics/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
+	mockScaler "github.com/openshift/managed-upgrade-operator/pkg/scaler/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/upgradesteps"
+	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
+)
+
+var _ = Describe("Upgrade step pipeline", func() {
+	var (
+		upgrader *clusterUpgrader
+		disabled = false
+
+		stepNames = func(steps []upgradesteps.UpgradeStep) []string {
+			names := make([]string, 0, len(steps))
+			for _, s := range steps {
+				names = append(names, s.String())
+			}
+			return names
+		}
+		requiredSteps = func() []upgradeStepConfig {
+			return []upgradeStepConfig{
+				{Name: string(upgradev1alpha1.CommenceUpgrade)},
+				{Name: string(upgradev1alpha1.ControlPlaneUpgraded)},
+				{Name: string(upgradev1alpha1.AllWorkerNodesUpgraded)},
+			}
+		}
+	)
+
+	BeforeEach(func() {
+		upgrader = &clusterUpgrader{config: &upgraderConfig{}}
+	})
+
+	Context("When no pipeline is configured", func() {
+		It("uses the upgrader's default steps", func() {
+			steps, err := upgrader.buildSteps(osdUpgradeSteps)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(steps).To(HaveLen(len(osdUpgradeSteps)))
+			for i, name := range osdUpgradeSteps {
+				Expect(steps[i].String()).To(Equal(string(name)))
+			}
+		})
+	})
+
+	Context("When a pipeline is configured", func() {
+		It("uses the configured steps in order, skipping disabled steps", func() {
+			upgrader.config.UpgradeSteps = []upgradeStepConfig{
+				{Name: string(upgradev1alpha1.UpgradePreHealthCheck)},
+				{Name: string(upgradev1alpha1.SendStartedNotification), Enabled: &disabled},
+				{Name: string(upgradev1alpha1.IsClusterUpgradable), TimeOut: 10},
+			}
+			upgrader.config.UpgradeSteps = append(upgrader.config.UpgradeSteps, requiredSteps()...)
+			steps, err := upgrader.buildSteps(osdUpgradeSteps)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stepNames(steps)).To(Equal([]string{
+				string(upgradev1alpha1.UpgradePreHealthCheck),
+				string(upgradev1alpha1.IsClusterUpgradable),
+				string(upgradev1alpha1.CommenceUpgrade),
+				string(upgradev1alpha1.ControlPlaneUpgraded),
+				string(upgradev1alpha1.AllWorkerNodesUpgraded),
+			}))
+		})
+	})
+
+	Context("When validating a configured pipeline", func() {
+		It("accepts a pipeline of only the required steps", func() {
+			Expect(validateUpgradeSteps(requiredSteps())).To(Succeed())
+		})
+		It("rejects unknown steps", func() {
+			steps := append([]upgradeStepConfig{{Name: "MakeTheClusterFaster"}}, requiredSteps()...)
+			Expect(validateUpgradeSteps(steps)).To(MatchError(ContainSubstring("unknown step")))
+		})
+		It("rejects duplicate steps", func() {
+			steps := append([]upgradeStepConfig{{Name: string(upgradev1alpha1.UpgradePreHealthCheck)}, {Name: string(upgradev1alpha1.UpgradePreHealthCheck)}}, requiredSteps()...)
+			Expect(validateUpgradeSteps(steps)).To(MatchError(ContainSubstring("duplicate step")))
+		})
+		It("rejects a pipeline missing a required step", func() {
+			Expect(validateUpgradeSteps(requiredSteps()[:2])).To(MatchError(ContainSubstring("missing required step")))
+		})
+		It("rejects disabling a required step", func() {
+			steps := requiredSteps()
+			steps[0].Enabled = &disabled
+			Expect(validateUpgradeSteps(steps)).To(MatchError(ContainSubstring("can't be disabled")))
+		})
+		It("rejects moving a step out of its stage", func() {
+			steps := append(requiredSteps(), upgradeStepConfig{Name: string(upgradev1alpha1.UpgradeScaleUpExtraNodes)})
+			Expect(validateUpgradeSteps(steps)).To(MatchError(ContainSubstring("can't run after")))
+		})
+		It("rejects a negative timeout", func() {
+			steps := append([]upgradeStepConfig{{Name: string(upgradev1alpha1.UpgradePreHealthCheck), TimeOut: -1}}, requiredSteps()...)
+			Expect(validateUpgradeSteps(steps)).To(MatchError(ContainSubstring("timeOut is invalid")))
+		})
+		It("accepts a retry budget and escalation policy", func() {
+			steps := append([]upgradeStepConfig{{Name: string(upgradev1alpha1.ExtDepAvailabilityCheck), MaxAttempts: 5, Backoff: 2, OnExhausted: "Fail"}}, requiredSteps()...)
+			Expect(validateUpgradeSteps(steps)).To(Succeed())
+		})
+		It("rejects an unknown escalation action", func() {
+			steps := append([]upgradeStepConfig{{Name: string(upgradev1alpha1.ExtDepAvailabilityCheck), MaxAttempts: 5, OnExhausted: "Panic"}}, requiredSteps()...)
+			Expect(validateUpgradeSteps(steps)).To(MatchError(ContainSubstring("onExhausted must be one of")))
+		})
+		It("rejects an escalation action without a retry budget", func() {
+			steps := append([]upgradeStepConfig{{Name: string(upgradev1alpha1.ExtDepAvailabilityCheck), OnExhausted: "Notify"}}, requiredSteps()...)
+			Expect(validateUpgradeSteps(steps)).To(MatchError(ContainSubstring("requires a timeOut or maxAttempts")))
+		})
+		It("rejects failing the upgrade once it has commenced", func() {
+			steps := requiredSteps()
+			steps[1].TimeOut = 90
+			steps[1].OnExhausted = "Fail"
+			Expect(validateUpgradeSteps(steps)).To(MatchError(ContainSubstring("can't fail the upgrade")))
+		})
+		It("rejects continuing past a required step", func() {
+			steps := requiredSteps()
+			steps[2].MaxAttempts = 3
+			steps[2].OnExhausted = "Continue"
+			Expect(validateUpgradeSteps(steps)).To(MatchError(ContainSubstring("can't be continued past")))
+		})
+	})
+
+	Context("When a step exhausts its retry budget", func() {
+		var (
+			mockCtrl            *gomock.Controller
+			mockEMClient        *emMocks.MockEventManager
+			mockScalerClient    *mockScaler.MockScaler
+			mockMetricsClient   *mockMetrics.MockMetrics
+			mockMachineryClient *mockMachinery.MockMachinery
+			logger              logr.Logger
+		)
+
+		BeforeEach(func() {
+			mockCtrl = gomock.NewController(GinkgoT())
+			mockEMClient = emMocks.NewMockEventManager(mockCtrl)
+			mockScalerClient = mockScaler.NewMockScaler(mockCtrl)
+			mockMetricsClient = mockMetrics.NewMockMetrics(mockCtrl)
+			mockMachineryClient = mockMachinery.NewMockMachinery(mockCtrl)
+			logger = logf.Log.WithName("pipeline test logger")
+			upgrader.notifier = mockEMClient
+			upgrader.scaler = mockScalerClient
+			upgrader.metrics = mockMetricsClient
+			upgrader.machinery = mockMachineryClient
+			upgrader.upgradeConfig = testStructs.NewUpgradeConfigBuilder().WithPhase(upgradev1alpha1.UpgradePhaseUpgrading).GetUpgradeConfig()
+		})
+
+		AfterEach(func() {
+			mockCtrl.Finish()
+		})
+
+		It("notifies that the step is exhausted when configured to notify", func() {
+			exhausted := &upgradesteps.StepExhaustedError{Step: string(upgradev1alpha1.ExtDepAvailabilityCheck), Action: upgradesteps.ExhaustedActionNotify}
+			mockEMClient.EXPECT().Notify(notifier.MuoStateStepExhausted)
+			phase, err := upgrader.escalateExhaustedStep(exhausted, logger)
+			Expect(err).To(Equal(exhausted))
+			Expect(phase).To(Equal(upgradev1alpha1.UpgradePhaseUpgrading))
+		})
+		It("fails the upgrade when configured to fail", func() {
+			exhausted := &upgradesteps.StepExhaustedError{Step: string(upgradev1alpha1.ExtDepAvailabilityCheck), Action: upgradesteps.ExhaustedActionFail}
+			gomock.InOrder(
+				mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), machinery.WorkerNodeType).Return(&machinery.UpgradingResult{Pools: []machinery.PoolUpgradingResult{{Name: "worker"}}}, nil),
+				mockMachineryClient.EXPECT().SetPoolPaused(gomock.Any(), "worker", false).Return(nil),
+				mockScalerClient.EXPECT().EnsureScaleDownNodes(gomock.Any(), nil, gomock.Any()),
+				mockEMClient.EXPECT().Notify(notifier.MuoStateFailed),
+				mockMetricsClient.EXPECT().UpdateMetricUpgradeWindowBreached(gomock.Any()),
+				mockMetricsClient.EXPECT().ResetFailureMetrics(),
+			)
+			phase, err := upgrader.escalateExhaustedStep(exhausted, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(phase).To(Equal(upgradev1alpha1.UpgradePhaseFailed))
+		})
+	})
+})
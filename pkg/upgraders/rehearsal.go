@@ -0,0 +1,89 @@
+package upgraders
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+)
+
+// readOnlyMetrics is a metrics client that queries metrics without updating the health check
+// metrics, so that a rehearsal neither raises nor clears the alerts of a real upgrade
+type readOnlyMetrics struct {
+	metrics.Metrics
+}
+
+func (readOnlyMetrics) UpdateMetricHealthcheckSucceeded(string, string) {}
+
+func (readOnlyMetrics) UpdateMetricHealthcheckFailed(string, string) {}
+
+// Rehearse runs the read-only checks of the upgrade's steps, without taking any action that
+// would change the cluster or send notifications, and returns the outcome of each check.
+// Only the checks of steps in the upgrader's pipeline are run.
+func (c *clusterUpgrader) Rehearse(ctx context.Context, upgradeConfig *upgradev1alpha1.UpgradeConfig, logger logr.Logger) ([]upgradev1alpha1.RehearsalCheck, error) {
+	c.upgradeConfig = upgradeConfig
+	mc := readOnlyMetrics{c.metrics}
+	checks := []upgradev1alpha1.RehearsalCheck{}
+
+	if c.hasStep(upgradev1alpha1.IsClusterUpgradable) {
+		ok, err := c.IsUpgradeable(ctx, logger)
+		checks = append(checks, rehearsalCheck("ClusterUpgradeable", ok, err, "ClusterVersion reports the cluster as upgradeable"))
+	}
+
+	if c.hasStep(upgradev1alpha1.UpgradePreHealthCheck) {
+		for _, hc := range c.healthChecks(healthCheckPreUpgrade) {
+			ok, err := hc.check(c, mc, logger)
+			check := rehearsalCheck(hc.name, ok, err, hc.passedMessage)
+			// A failed warning check does not hold up the upgrade
+			if hc.severity == healthCheckWarn {
+				check.Passed = true
+			}
+			checks = append(checks, check)
+		}
+	}
+
+	if c.hasStep(upgradev1alpha1.ExtDepAvailabilityCheck) {
+		ok, err := c.ExternalDependencyAvailabilityCheck(ctx, logger)
+		checks = append(checks, rehearsalCheck("ExternalDependencies", ok, err, "External dependencies of the upgrade are available"))
+	}
+
+	// Forecast how long the workers will take to drain and upgrade
+	upgradingResult, err := c.machinery.IsUpgrading(c.client, "worker")
+	if err != nil {
+		checks = append(checks, rehearsalCheck("WorkerDrainForecast", false, err, ""))
+	} else {
+		checks = append(checks, upgradev1alpha1.RehearsalCheck{
+			Name:   "WorkerDrainForecast",
+			Passed: true,
+			Message: fmt.Sprintf("%d worker nodes are expected to be drained and upgraded within %s",
+				upgradingResult.MachineCount, c.workerMaintenanceDuration(upgradingResult.MachineCount)),
+		})
+	}
+
+	return checks, nil
+}
+
+// hasStep returns whether the upgrader's pipeline includes the named step
+func (c *clusterUpgrader) hasStep(name upgradev1alpha1.UpgradeConditionType) bool {
+	for _, s := range c.steps {
+		if s.String() == string(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// rehearsalCheck returns the outcome of a rehearsal check from the result of running it
+func rehearsalCheck(name string, ok bool, err error, passedMessage string) upgradev1alpha1.RehearsalCheck {
+	check := upgradev1alpha1.RehearsalCheck{Name: name, Passed: ok && err == nil, Message: passedMessage}
+	switch {
+	case err != nil:
+		check.Message = err.Error()
+	case !ok:
+		check.Message = fmt.Sprintf("%s check did not pass", name)
+	}
+	return check
+}
@@ -0,0 +1,128 @@
+package upgraders
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+	"go.uber.org/mock/gomock"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/clusterversion"
+	cvMocks "github.com/openshift/managed-upgrade-operator/pkg/clusterversion/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
+	mockMachinery "github.com/openshift/managed-upgrade-operator/pkg/machinery/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+	mockMetrics "github.com/openshift/managed-upgrade-operator/pkg/metrics/mocks"
+	mockScaler "github.com/openshift/managed-upgrade-operator/pkg/scaler/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/upgradesteps"
+	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
+)
+
+var _ = Describe("Upgrade rehearsal", func() {
+	var (
+		logger              logr.Logger
+		mockCtrl            *gomock.Controller
+		mockMetricsClient   *mockMetrics.MockMetrics
+		mockCVClient        *cvMocks.MockClusterVersion
+		mockMachineryClient *mockMachinery.MockMachinery
+		mockScalerClient    *mockScaler.MockScaler
+		upgradeConfig       *upgradev1alpha1.UpgradeConfig
+		upgrader            *clusterUpgrader
+
+		checkNames = func(checks []upgradev1alpha1.RehearsalCheck) []string {
+			names := make([]string, 0, len(checks))
+			for _, c := range checks {
+				names = append(names, c.Name)
+			}
+			return names
+		}
+	)
+
+	BeforeEach(func() {
+		logger = logf.Log.WithName("rehearsal test logger")
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockMetricsClient = mockMetrics.NewMockMetrics(mockCtrl)
+		mockCVClient = cvMocks.NewMockClusterVersion(mockCtrl)
+		mockMachineryClient = mockMachinery.NewMockMachinery(mockCtrl)
+		mockScalerClient = mockScaler.NewMockScaler(mockCtrl)
+		upgradeConfig = testStructs.NewUpgradeConfigBuilder().GetUpgradeConfig()
+		upgradeConfig.Spec.PDBForceDrainTimeout = 30
+		upgrader = &clusterUpgrader{
+			client:    fake.NewClientBuilder().Build(),
+			metrics:   mockMetricsClient,
+			cvClient:  mockCVClient,
+			machinery: mockMachineryClient,
+			scaler:    mockScalerClient,
+			config:    buildTestUpgraderConfig(90, 30, 8, 120, 30),
+		}
+		upgrader.config.NodeDrain.Timeout = 45
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("When the pipeline includes the pre-upgrade health check", func() {
+		BeforeEach(func() {
+			upgrader.steps = []upgradesteps.UpgradeStep{upgradesteps.Action(string(upgradev1alpha1.UpgradePreHealthCheck), nil)}
+		})
+
+		It("reports the outcome of each health check without updating the health check metrics", func() {
+			mockMetricsClient.EXPECT().Query(gomock.Any()).Return(&metrics.AlertResponse{}, nil)
+			mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{Degraded: []string{"dns"}}, nil)
+			mockCVClient.EXPECT().GetClusterVersion().Return(&configv1.ClusterVersion{
+				Status: configv1.ClusterVersionStatus{
+					History: []configv1.UpdateHistory{{State: configv1.CompletedUpdate, Version: upgradeConfig.Spec.Desired.Version}},
+				},
+			}, nil)
+			mockScalerClient.EXPECT().CanScale(gomock.Any(), gomock.Any()).Return(true, nil)
+			mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{MachineCount: 3}, nil)
+			mockMetricsClient.EXPECT().UpdateMetricHealthcheckFailed(gomock.Any(), gomock.Any()).Times(0)
+			mockMetricsClient.EXPECT().UpdateMetricHealthcheckSucceeded(gomock.Any(), gomock.Any()).Times(0)
+
+			checks, err := upgrader.Rehearse(context.TODO(), upgradeConfig, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checkNames(checks)).To(Equal([]string{"CriticalAlerts", "ClusterOperators", "CapacityReservation", "ManuallyCordonedNodes", "NodeUnschedulableTaints", "PodDisruptionBudgets", "DrainCapacity", "RemovedAPIs", "WorkerDrainForecast"}))
+			Expect(checks[0].Passed).To(BeTrue())
+			Expect(checks[0].Message).To(Equal("No critical alerts are firing"))
+			Expect(checks[1].Passed).To(BeFalse())
+			Expect(checks[1].Message).To(Equal("degraded operators: dns"))
+			Expect(checks[8].Passed).To(BeTrue())
+			Expect(checks[8].Message).To(Equal("3 worker nodes are expected to be drained and upgraded within 2h39m0s"))
+		})
+
+		It("reports a failed warning check as passed", func() {
+			upgradeConfig.Spec.CapacityReservation = false
+			upgrader.config.HealthCheck.Checks = []promQLHealthCheck{{Name: "IngressErrorRate", Query: "ingress_error_rate", Comparison: "<", Threshold: 0.01, Severity: string(healthCheckWarn), Stages: []string{string(healthCheckPreUpgrade)}}}
+			mockMetricsClient.EXPECT().Query("ingress_error_rate").Return(&metrics.AlertResponse{}, nil)
+			mockMetricsClient.EXPECT().Query(gomock.Not("ingress_error_rate")).Return(&metrics.AlertResponse{}, nil)
+			mockCVClient.EXPECT().HasDegradedOperators().Return(&clusterversion.HasDegradedOperatorsResult{}, nil)
+			mockCVClient.EXPECT().GetClusterVersion().Return(&configv1.ClusterVersion{
+				Status: configv1.ClusterVersionStatus{
+					History: []configv1.UpdateHistory{{State: configv1.CompletedUpdate, Version: upgradeConfig.Spec.Desired.Version}},
+				},
+			}, nil)
+			mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{MachineCount: 3}, nil)
+
+			checks, err := upgrader.Rehearse(context.TODO(), upgradeConfig, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checks[8].Name).To(Equal("IngressErrorRate"))
+			Expect(checks[8].Passed).To(BeTrue())
+			Expect(checks[8].Message).To(ContainSubstring("IngressErrorRate"))
+		})
+	})
+
+	Context("When the pipeline includes none of the checked steps", func() {
+		It("only forecasts the worker drain", func() {
+			mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), "worker").Return(&machinery.UpgradingResult{MachineCount: 1}, nil)
+			checks, err := upgrader.Rehearse(context.TODO(), upgradeConfig, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checkNames(checks)).To(Equal([]string{"WorkerDrainForecast"}))
+		})
+	})
+})
@@ -0,0 +1,107 @@
+package upgraders
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	ac "github.com/openshift/managed-upgrade-operator/pkg/availabilitychecks"
+	cv "github.com/openshift/managed-upgrade-operator/pkg/clusterversion"
+	"github.com/openshift/managed-upgrade-operator/pkg/configmanager"
+	"github.com/openshift/managed-upgrade-operator/pkg/drain"
+	"github.com/openshift/managed-upgrade-operator/pkg/eventmanager"
+	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
+	"github.com/openshift/managed-upgrade-operator/pkg/maintenance"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+	"github.com/openshift/managed-upgrade-operator/pkg/scaler"
+)
+
+// standardUpgrader is a cluster upgrader suitable for self-managed OpenShift and OKD clusters,
+// which are not provisioned by Hive nor managed through OCM.
+// It inherits from the base clusterUpgrader.
+type standardUpgrader struct {
+	*clusterUpgrader
+}
+
+// standardUpgradeSteps are the default steps, in order, used by the standardUpgrader
+var standardUpgradeSteps = []upgradev1alpha1.UpgradeConditionType{
+	upgradev1alpha1.SendStartedNotification,
+	upgradev1alpha1.UpgradeDelayedCheck,
+	upgradev1alpha1.IsClusterUpgradable,
+	upgradev1alpha1.UpgradePreHealthCheck,
+	upgradev1alpha1.ExtDepAvailabilityCheck,
+	upgradev1alpha1.UpgradeScaleUpExtraNodes,
+	upgradev1alpha1.ControlPlaneMaintWindow,
+	upgradev1alpha1.PreUpgradeHooks,
+	upgradev1alpha1.CommenceUpgrade,
+	upgradev1alpha1.ControlPlaneUpgraded,
+	upgradev1alpha1.ControlPlaneUpgradedHooks,
+	upgradev1alpha1.RemoveControlPlaneMaintWindow,
+	upgradev1alpha1.WorkersMaintWindow,
+	upgradev1alpha1.CanaryWorkersUpgraded,
+	upgradev1alpha1.AllWorkerNodesUpgraded,
+	upgradev1alpha1.PostUpgradeHooks,
+	upgradev1alpha1.RemoveExtraScaledNodes,
+	upgradev1alpha1.RemoveMaintWindow,
+	upgradev1alpha1.PostClusterHealthCheck,
+	upgradev1alpha1.SendCompletedNotification,
+}
+
+// NewStandardUpgrader creates a new instance of a standardUpgrader
+func NewStandardUpgrader(c client.Client, cfm configmanager.ConfigManager, mc metrics.Metrics, notifier eventmanager.EventManager) (*standardUpgrader, error) {
+	cfg := &upgraderConfig{}
+	err := cfm.Into(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := maintenance.NewBuilder().NewClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	acs, err := ac.GetAvailabilityCheckers(&cfg.ExtDependencyAvailabilityCheck)
+	if err != nil {
+		return nil, err
+	}
+
+	su := standardUpgrader{
+		clusterUpgrader: &clusterUpgrader{
+			client:               c,
+			metrics:              mc,
+			cvClient:             cv.NewCVClient(c),
+			notifier:             notifier,
+			config:               cfg,
+			scaler:               scaler.NewMachineRoleScaler(),
+			drainstrategyBuilder: drain.NewBuilder(),
+			maintenance:          m,
+			machinery:            machinery.NewMachinery(),
+			availabilityCheckers: acs,
+		},
+	}
+
+	steps, err := su.buildSteps(standardUpgradeSteps)
+	if err != nil {
+		return nil, err
+	}
+	su.steps = steps
+
+	return &su, nil
+}
+
+// UpgradeCluster performs the upgrade of the cluster and returns an indication of the
+// last-executed upgrade phase and any error associated with the phase execution.
+func (u *standardUpgrader) UpgradeCluster(ctx context.Context, upgradeConfig *upgradev1alpha1.UpgradeConfig, logger logr.Logger) (upgradev1alpha1.UpgradePhase, error) {
+	u.upgradeConfig = upgradeConfig
+	return u.runSteps(ctx, logger, u.steps)
+}
+
+// HealthCheck performs a pre-upgrade healthcheck when an upgrade is scheduled in advance mainly
+// to highlight and notify of issues which could get fixed before the upgrade begins.
+func (u *standardUpgrader) HealthCheck(ctx context.Context, upgradeConfig *upgradev1alpha1.UpgradeConfig, logger logr.Logger) (bool, error) {
+	u.upgradeConfig = upgradeConfig
+	ok, err := u.PreUpgradeHealthCheck(ctx, logger)
+	return ok, err
+}
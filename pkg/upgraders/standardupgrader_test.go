@@ -0,0 +1,170 @@
+package upgraders
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/config"
+	cvMocks "github.com/openshift/managed-upgrade-operator/pkg/clusterversion/mocks"
+	configMocks "github.com/openshift/managed-upgrade-operator/pkg/configmanager/mocks"
+	emMocks "github.com/openshift/managed-upgrade-operator/pkg/eventmanager/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+	mockMetrics "github.com/openshift/managed-upgrade-operator/pkg/metrics/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
+	"github.com/openshift/managed-upgrade-operator/pkg/scaler"
+	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
+)
+
+var _ = Describe("Standard upgrader", func() {
+	var (
+		logger            logr.Logger
+		mockCtrl          *gomock.Controller
+		mockConfigManager *configMocks.MockConfigManager
+		mockMetricsClient *mockMetrics.MockMetrics
+		mockEMClient      *emMocks.MockEventManager
+		mockCVClient      *cvMocks.MockClusterVersion
+		kubeClient        client.Client
+		cfg               *upgraderConfig
+		upgradeConfig     *upgradev1alpha1.UpgradeConfig
+		routesEnv         string
+		routesEnvSet      bool
+
+		stepNames = func(su *standardUpgrader) []upgradev1alpha1.UpgradeConditionType {
+			names := []upgradev1alpha1.UpgradeConditionType{}
+			for _, s := range su.steps {
+				names = append(names, upgradev1alpha1.UpgradeConditionType(s.String()))
+			}
+			return names
+		}
+	)
+
+	BeforeEach(func() {
+		// Using routes skips reading the monitoring CA when building the maintenance client
+		routesEnv, routesEnvSet = os.LookupEnv(config.EnvRoutes)
+		Expect(os.Setenv(config.EnvRoutes, "true")).To(Succeed())
+
+		logger = logf.Log.WithName("standard upgrader test logger")
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockConfigManager = configMocks.NewMockConfigManager(mockCtrl)
+		mockMetricsClient = mockMetrics.NewMockMetrics(mockCtrl)
+		mockEMClient = emMocks.NewMockEventManager(mockCtrl)
+		mockCVClient = cvMocks.NewMockClusterVersion(mockCtrl)
+		kubeClient = fake.NewClientBuilder().WithObjects(
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "alertmanager-main", Namespace: metrics.MonitoringNS},
+				Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "web", Port: 9094}}},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "prometheus-k8s-token-abcde", Namespace: metrics.MonitoringNS},
+				Data:       map[string][]byte{"token": []byte("test-token")},
+			},
+		).Build()
+		cfg = buildTestUpgraderConfig(90, 30, 8, 120, 30)
+		upgradeConfig = testStructs.NewUpgradeConfigBuilder().GetUpgradeConfig()
+		upgradeConfig.Spec.Type = upgradev1alpha1.Standard
+	})
+
+	AfterEach(func() {
+		if routesEnvSet {
+			_ = os.Setenv(config.EnvRoutes, routesEnv)
+		} else {
+			_ = os.Unsetenv(config.EnvRoutes)
+		}
+		mockCtrl.Finish()
+	})
+
+	Context("When building the upgrader", func() {
+		It("is built for the Standard upgrade type", func() {
+			mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, *cfg)
+			cu, err := NewBuilder().NewClient(kubeClient, mockConfigManager, mockMetricsClient, mockEMClient, upgradev1alpha1.Standard)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cu).To(BeAssignableToTypeOf(&standardUpgrader{}))
+		})
+		It("returns an error if the config cannot be read", func() {
+			mockConfigManager.EXPECT().Into(gomock.Any()).Return(fmt.Errorf("fake error"))
+			_, err := NewStandardUpgrader(kubeClient, mockConfigManager, mockMetricsClient, mockEMClient)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When building the upgrade steps", func() {
+		It("uses the standard steps by default", func() {
+			mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, *cfg)
+			su, err := NewStandardUpgrader(kubeClient, mockConfigManager, mockMetricsClient, mockEMClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stepNames(su)).To(Equal(standardUpgradeSteps))
+		})
+		It("does not run the managed environment's post-upgrade procedures", func() {
+			mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, *cfg)
+			su, err := NewStandardUpgrader(kubeClient, mockConfigManager, mockMetricsClient, mockEMClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stepNames(su)).NotTo(ContainElement(upgradev1alpha1.PostUpgradeProcedures))
+		})
+		It("uses the configured steps when they are set", func() {
+			cfg.UpgradeSteps = []upgradeStepConfig{
+				{Name: string(upgradev1alpha1.CommenceUpgrade)},
+				{Name: string(upgradev1alpha1.ControlPlaneUpgraded)},
+				{Name: string(upgradev1alpha1.AllWorkerNodesUpgraded)},
+			}
+			mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, *cfg)
+			su, err := NewStandardUpgrader(kubeClient, mockConfigManager, mockMetricsClient, mockEMClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stepNames(su)).To(Equal([]upgradev1alpha1.UpgradeConditionType{
+				upgradev1alpha1.CommenceUpgrade,
+				upgradev1alpha1.ControlPlaneUpgraded,
+				upgradev1alpha1.AllWorkerNodesUpgraded,
+			}))
+		})
+	})
+
+	Context("When scaling the workers", func() {
+		It("selects the MachineSets by machine role rather than Hive machine pool", func() {
+			mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, *cfg)
+			su, err := NewStandardUpgrader(kubeClient, mockConfigManager, mockMetricsClient, mockEMClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(su.scaler).To(Equal(scaler.NewMachineRoleScaler()))
+			Expect(su.scaler).NotTo(Equal(scaler.NewScaler()))
+		})
+	})
+
+	Context("When sending notifications", func() {
+		var su *standardUpgrader
+
+		BeforeEach(func() {
+			var err error
+			mockConfigManager.EXPECT().Into(gomock.Any()).SetArg(0, *cfg)
+			su, err = NewStandardUpgrader(kubeClient, mockConfigManager, mockMetricsClient, mockEMClient)
+			Expect(err).NotTo(HaveOccurred())
+			su.cvClient = mockCVClient
+			su.upgradeConfig = upgradeConfig
+		})
+
+		It("notifies the upgrade start through the configured notifier", func() {
+			gomock.InOrder(
+				mockCVClient.EXPECT().HasUpgradeCommenced(upgradeConfig).Return(false, nil),
+				mockEMClient.EXPECT().Notify(notifier.MuoStateStarted),
+			)
+			ok, err := su.SendStartedNotification(context.TODO(), logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+		})
+		It("notifies the upgrade completion through the configured notifier", func() {
+			mockEMClient.EXPECT().Notify(notifier.MuoStateCompleted)
+			ok, err := su.SendCompletedNotification(context.TODO(), logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+		})
+	})
+})
@@ -2,6 +2,8 @@ package upgraders
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/go-logr/logr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -11,9 +13,11 @@ import (
 	cv "github.com/openshift/managed-upgrade-operator/pkg/clusterversion"
 	"github.com/openshift/managed-upgrade-operator/pkg/drain"
 	"github.com/openshift/managed-upgrade-operator/pkg/eventmanager"
+	"github.com/openshift/managed-upgrade-operator/pkg/hostedcluster"
 	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
 	"github.com/openshift/managed-upgrade-operator/pkg/maintenance"
 	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
 	"github.com/openshift/managed-upgrade-operator/pkg/scaler"
 	"github.com/openshift/managed-upgrade-operator/pkg/upgradesteps"
 )
@@ -53,17 +57,48 @@ type clusterUpgrader struct {
 	// Client used to observe the state of machines in the cluster
 	machinery machinery.Machinery
 
+	// Client used to upgrade the HostedCluster of a cluster with a hosted control plane.
+	// It is nil for other clusters.
+	hostedCluster hostedcluster.HostedCluster
+
 	// Model of the cluster upgrader's ConfigMap configuration
 	config *upgraderConfig
 }
 
 // runSteps runs the upgrader's upgrade steps and returns the last-executed
-// upgrade phase and any associated error
+// upgrade phase and any associated error.
+// The worker MachineConfigPools are paused or resumed to match the UpgradeConfig,
+// and held paused until it is their turn to upgrade, before any steps are run.
 func (c *clusterUpgrader) runSteps(ctx context.Context, logger logr.Logger, s []upgradesteps.UpgradeStep) (upgradev1alpha1.UpgradePhase, error) {
+	err := c.setWorkerPoolsPaused()
+	if err != nil {
+		logger.Error(err, "failed to set the paused state of the worker machineconfigpools")
+		return upgradev1alpha1.UpgradePhaseUpgrading, err
+	}
+
 	phase, err := upgradesteps.Run(ctx, c.upgradeConfig, logger, s)
+	var exhausted *upgradesteps.StepExhaustedError
+	if errors.As(err, &exhausted) {
+		return c.escalateExhaustedStep(exhausted, logger)
+	}
 	return phase, err
 }
 
+// escalateExhaustedStep takes the action configured for a step that has exhausted its retry budget
+func (c *clusterUpgrader) escalateExhaustedStep(exhausted *upgradesteps.StepExhaustedError, logger logr.Logger) (upgradev1alpha1.UpgradePhase, error) {
+	switch exhausted.Action {
+	case upgradesteps.ExhaustedActionFail:
+		logger.Info(fmt.Sprintf("failing the upgrade as %s", exhausted.Error()))
+		return c.performUpgradeFailure(logger)
+	case upgradesteps.ExhaustedActionNotify:
+		err := c.notifier.Notify(notifier.MuoStateStepExhausted)
+		if err != nil {
+			logger.Error(err, fmt.Sprintf("failed to notify that %s", exhausted.Error()))
+		}
+	}
+	return upgradev1alpha1.UpgradePhaseUpgrading, exhausted
+}
+
 // UpgradeCluster performs the upgrade of the cluster and returns an indication of the
 // last-executed upgrade phase and any error associated with the phase execution.
 func (c *clusterUpgrader) UpgradeCluster(ctx context.Context, upgradeConfig *upgradev1alpha1.UpgradeConfig, logger logr.Logger) (upgradev1alpha1.UpgradePhase, error) {
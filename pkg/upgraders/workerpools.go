@@ -0,0 +1,70 @@
+package upgraders
+
+import (
+	"fmt"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
+)
+
+// setWorkerPoolsPaused pauses or unpauses each worker MachineConfigPool. Every pool is paused
+// while the upgrade is paused. Otherwise, a pool is held paused until it is its turn to upgrade.
+func (c *clusterUpgrader) setWorkerPoolsPaused() error {
+	result, err := c.machinery.IsUpgrading(c.client, machinery.WorkerNodeType)
+	if err != nil {
+		return err
+	}
+	for _, pool := range result.Pools {
+		paused := c.upgradeConfig.Spec.Paused || c.isHoldingPoolForCanary(pool.Name) || c.isHoldingPoolForOrder(pool.Name, result.Pools)
+		err := c.machinery.SetPoolPaused(c.client, pool.Name, paused)
+		if err != nil {
+			return fmt.Errorf("failed to set the paused state of the %s machineconfigpool: %v", pool.Name, err)
+		}
+	}
+	return nil
+}
+
+// resumeWorkerPools unpauses each worker MachineConfigPool that was paused for the upgrade
+func (c *clusterUpgrader) resumeWorkerPools() error {
+	result, err := c.machinery.IsUpgrading(c.client, machinery.WorkerNodeType)
+	if err != nil {
+		return err
+	}
+	for _, pool := range result.Pools {
+		err := c.machinery.SetPoolPaused(c.client, pool.Name, false)
+		if err != nil {
+			return fmt.Errorf("failed to resume the %s machineconfigpool: %v", pool.Name, err)
+		}
+	}
+	return nil
+}
+
+// isHoldingPoolForOrder returns whether the named worker pool is held paused while the pools
+// ahead of it in the configured upgrade order are upgraded. Pools are only released once the
+// control plane has been upgraded, so that no pool starts upgrading ahead of its turn.
+func (c *clusterUpgrader) isHoldingPoolForOrder(name string, pools []machinery.PoolUpgradingResult) bool {
+	if c.config.Canary.IsEnabled() && name == c.config.Canary.GetPoolName() {
+		return false
+	}
+	position := c.config.WorkerPools.GetPosition(name)
+	var poolsAhead []machinery.PoolUpgradingResult
+	for _, pool := range pools {
+		if pool.Name != name && c.config.WorkerPools.GetPosition(pool.Name) < position {
+			poolsAhead = append(poolsAhead, pool)
+		}
+	}
+	if len(poolsAhead) == 0 {
+		return false
+	}
+
+	history := c.upgradeConfig.Status.History.GetHistory(c.upgradeConfig.Spec.Desired.Version)
+	if history == nil || !history.Conditions.IsTrueFor(upgradev1alpha1.ControlPlaneUpgraded) {
+		return true
+	}
+	for _, pool := range poolsAhead {
+		if pool.IsUpgrading {
+			return true
+		}
+	}
+	return false
+}
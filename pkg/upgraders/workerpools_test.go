@@ -0,0 +1,151 @@
+package upgraders
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	emMocks "github.com/openshift/managed-upgrade-operator/pkg/eventmanager/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/machinery"
+	mockMachinery "github.com/openshift/managed-upgrade-operator/pkg/machinery/mocks"
+	mockMetrics "github.com/openshift/managed-upgrade-operator/pkg/metrics/mocks"
+	"github.com/openshift/managed-upgrade-operator/pkg/notifier"
+	mockScaler "github.com/openshift/managed-upgrade-operator/pkg/scaler/mocks"
+	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
+)
+
+var _ = Describe("Worker pools", func() {
+	var (
+		logger              logr.Logger
+		mockCtrl            *gomock.Controller
+		mockMachineryClient *mockMachinery.MockMachinery
+		mockScalerClient    *mockScaler.MockScaler
+		mockMetricsClient   *mockMetrics.MockMetrics
+		mockEMClient        *emMocks.MockEventManager
+		upgradeConfig       *upgradev1alpha1.UpgradeConfig
+		upgrader            *clusterUpgrader
+
+		controlPlaneUpgraded = func() {
+			upgradeConfig.Status.History = upgradev1alpha1.UpgradeHistories{{
+				Version: upgradeConfig.Spec.Desired.Version,
+				Conditions: upgradev1alpha1.Conditions{
+					{Type: upgradev1alpha1.ControlPlaneUpgraded, Status: corev1.ConditionTrue},
+				},
+			}}
+		}
+	)
+
+	BeforeEach(func() {
+		logger = logf.Log.WithName("worker pools test logger")
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockMachineryClient = mockMachinery.NewMockMachinery(mockCtrl)
+		mockScalerClient = mockScaler.NewMockScaler(mockCtrl)
+		mockMetricsClient = mockMetrics.NewMockMetrics(mockCtrl)
+		mockEMClient = emMocks.NewMockEventManager(mockCtrl)
+		upgradeConfig = testStructs.NewUpgradeConfigBuilder().WithPhase(upgradev1alpha1.UpgradePhaseUpgrading).GetUpgradeConfig()
+		upgrader = &clusterUpgrader{
+			machinery:     mockMachineryClient,
+			scaler:        mockScalerClient,
+			metrics:       mockMetricsClient,
+			notifier:      mockEMClient,
+			config:        &upgraderConfig{WorkerPools: workerPoolsConfig{Order: []string{"infra", "worker"}}},
+			upgradeConfig: upgradeConfig,
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Context("When no pool order is configured", func() {
+		It("upgrades every pool together", func() {
+			upgrader.config.WorkerPools = workerPoolsConfig{}
+			pools := []machinery.PoolUpgradingResult{{Name: "infra", IsUpgrading: true}, {Name: "worker", IsUpgrading: true}}
+			Expect(upgrader.isHoldingPoolForOrder("infra", pools)).To(BeFalse())
+			Expect(upgrader.isHoldingPoolForOrder("worker", pools)).To(BeFalse())
+		})
+	})
+
+	Context("When a pool order is configured", func() {
+		var pools []machinery.PoolUpgradingResult
+
+		BeforeEach(func() {
+			pools = []machinery.PoolUpgradingResult{
+				{Name: "gpu", IsUpgrading: true},
+				{Name: "infra", IsUpgrading: true},
+				{Name: "worker", IsUpgrading: true},
+			}
+		})
+
+		It("holds all but the first pool until the control plane is upgraded", func() {
+			pools[1].IsUpgrading = false
+			Expect(upgrader.isHoldingPoolForOrder("infra", pools)).To(BeFalse())
+			Expect(upgrader.isHoldingPoolForOrder("worker", pools)).To(BeTrue())
+			Expect(upgrader.isHoldingPoolForOrder("gpu", pools)).To(BeTrue())
+		})
+		It("holds the pools behind a pool that is upgrading", func() {
+			controlPlaneUpgraded()
+			Expect(upgrader.isHoldingPoolForOrder("infra", pools)).To(BeFalse())
+			Expect(upgrader.isHoldingPoolForOrder("worker", pools)).To(BeTrue())
+			Expect(upgrader.isHoldingPoolForOrder("gpu", pools)).To(BeTrue())
+		})
+		It("releases the next pool once the pools ahead are upgraded", func() {
+			controlPlaneUpgraded()
+			pools[1].IsUpgrading = false
+			Expect(upgrader.isHoldingPoolForOrder("worker", pools)).To(BeFalse())
+			Expect(upgrader.isHoldingPoolForOrder("gpu", pools)).To(BeTrue())
+		})
+		It("does not hold a pool for a listed pool that doesn't exist", func() {
+			pools = []machinery.PoolUpgradingResult{{Name: "worker", IsUpgrading: true}}
+			Expect(upgrader.isHoldingPoolForOrder("worker", pools)).To(BeFalse())
+		})
+		It("pauses the held pools before running the upgrade steps", func() {
+			gomock.InOrder(
+				mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), machinery.WorkerNodeType).Return(&machinery.UpgradingResult{Pools: pools}, nil),
+				mockMachineryClient.EXPECT().SetPoolPaused(gomock.Any(), "gpu", true).Return(nil),
+				mockMachineryClient.EXPECT().SetPoolPaused(gomock.Any(), "infra", false).Return(nil),
+				mockMachineryClient.EXPECT().SetPoolPaused(gomock.Any(), "worker", true).Return(nil),
+			)
+			_, err := upgrader.runSteps(context.TODO(), logger, nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("resumes the held pools when the upgrade fails", func() {
+			gomock.InOrder(
+				mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), machinery.WorkerNodeType).Return(&machinery.UpgradingResult{Pools: pools}, nil),
+				mockMachineryClient.EXPECT().SetPoolPaused(gomock.Any(), "gpu", true).Return(nil),
+				mockMachineryClient.EXPECT().SetPoolPaused(gomock.Any(), "infra", false).Return(nil),
+				mockMachineryClient.EXPECT().SetPoolPaused(gomock.Any(), "worker", true).Return(nil),
+				mockMachineryClient.EXPECT().IsUpgrading(gomock.Any(), machinery.WorkerNodeType).Return(&machinery.UpgradingResult{Pools: pools}, nil),
+				mockMachineryClient.EXPECT().SetPoolPaused(gomock.Any(), "gpu", false).Return(nil),
+				mockMachineryClient.EXPECT().SetPoolPaused(gomock.Any(), "infra", false).Return(nil),
+				mockMachineryClient.EXPECT().SetPoolPaused(gomock.Any(), "worker", false).Return(nil),
+				mockScalerClient.EXPECT().EnsureScaleDownNodes(gomock.Any(), nil, gomock.Any()).Return(true, nil),
+				mockEMClient.EXPECT().Notify(notifier.MuoStateFailed).Return(nil),
+				mockMetricsClient.EXPECT().UpdateMetricUpgradeWindowBreached(upgradeConfig.Name),
+				mockMetricsClient.EXPECT().ResetFailureMetrics(),
+			)
+			_, err := upgrader.runSteps(context.TODO(), logger, nil)
+			Expect(err).NotTo(HaveOccurred())
+			phase, err := upgrader.performUpgradeFailure(logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(phase).To(Equal(upgradev1alpha1.UpgradePhaseFailed))
+		})
+	})
+
+	Context("When validating the pool order", func() {
+		It("rejects the master pool", func() {
+			cfg := workerPoolsConfig{Order: []string{"infra", "master"}}
+			Expect(cfg.IsValid()).To(MatchError(ContainSubstring("can't include")))
+		})
+		It("rejects a duplicate pool", func() {
+			cfg := workerPoolsConfig{Order: []string{"infra", "infra"}}
+			Expect(cfg.IsValid()).To(MatchError(ContainSubstring("duplicate")))
+		})
+	})
+})
@@ -2,6 +2,7 @@ package upgradesteps
 
 import (
 	"context"
+
 	"github.com/go-logr/logr"
 )
 
@@ -20,7 +21,7 @@ func Action(n string, f actionFunction) actionStep {
 // performed.
 type actionStep struct {
 	name string
-	f actionFunction
+	f    actionFunction
 }
 
 // run executes the actionStep's actionFunction in the supplied context
@@ -33,3 +34,14 @@ func (s actionStep) String() string {
 	return s.name
 }
 
+// WithPolicy returns a step that runs the given step subject to the retry
+// budget and escalation of the StepPolicy `p`.
+func WithPolicy(step UpgradeStep, p StepPolicy) UpgradeStep {
+	return policyStep{UpgradeStep: step, policy: p}
+}
+
+// policyStep is an UpgradeStep that is retried according to a StepPolicy
+type policyStep struct {
+	UpgradeStep
+	policy StepPolicy
+}
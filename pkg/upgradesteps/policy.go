@@ -0,0 +1,91 @@
+package upgradesteps
+
+import (
+	"fmt"
+	"time"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+)
+
+// ExhaustedAction is the action taken when a step exhausts its retry budget
+type ExhaustedAction string
+
+const (
+	// ExhaustedActionNone keeps retrying the step, reporting it as errored
+	ExhaustedActionNone ExhaustedAction = ""
+	// ExhaustedActionFail fails the upgrade
+	ExhaustedActionFail ExhaustedAction = "Fail"
+	// ExhaustedActionNotify notifies that the step is exhausted and keeps retrying it
+	ExhaustedActionNotify ExhaustedAction = "Notify"
+	// ExhaustedActionContinue gives up on the step and continues with the following steps
+	ExhaustedActionContinue ExhaustedAction = "Continue"
+)
+
+// StepPolicy limits how long, and how many times, a step is retried before its
+// retry budget is exhausted
+type StepPolicy struct {
+	// Timeout is the duration, from first being run, within which the step must complete.
+	// Zero means no limit.
+	Timeout time.Duration
+	// MaxAttempts is the number of times the step may fail. Zero means no limit.
+	MaxAttempts int
+	// Backoff is the delay before retrying a failed step, doubling after each failure.
+	// Zero retries the step on every run.
+	Backoff time.Duration
+	// OnExhausted is the action taken when the retry budget is exhausted
+	OnExhausted ExhaustedAction
+}
+
+// maxBackoff caps the delay between attempts of a failing step
+const maxBackoff = time.Hour
+
+// StepExhaustedError is returned by Run when a step has exhausted its retry budget
+// and its policy escalates to the caller, who is expected to take the Action.
+type StepExhaustedError struct {
+	Step   string
+	Action ExhaustedAction
+	Reason string
+}
+
+func (e *StepExhaustedError) Error() string {
+	return fmt.Sprintf("%s has exhausted its retry budget: %s", e.Step, e.Reason)
+}
+
+// policyOf returns the StepPolicy of a step, if it has one
+func policyOf(step UpgradeStep) (StepPolicy, bool) {
+	ps, ok := step.(policyStep)
+	return ps.policy, ok
+}
+
+// backoffRemaining returns how long is left until a failed step with a backoff
+// policy may be retried
+func backoffRemaining(step UpgradeStep, c *upgradev1alpha1.UpgradeCondition) time.Duration {
+	p, ok := policyOf(step)
+	if !ok || p.Backoff <= 0 || c == nil || c.Attempts == 0 || c.LastAttemptTime == nil {
+		return 0
+	}
+	backoff := p.Backoff
+	for i := 1; i < c.Attempts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return time.Until(c.LastAttemptTime.Add(backoff))
+}
+
+// exhaustedReason returns why a step that has not completed has exhausted its retry
+// budget, or an empty string if it has not
+func exhaustedReason(step UpgradeStep, c *upgradev1alpha1.UpgradeCondition, failed bool) string {
+	p, ok := policyOf(step)
+	if !ok || c == nil {
+		return ""
+	}
+	if failed && p.MaxAttempts > 0 && c.Attempts >= p.MaxAttempts {
+		return fmt.Sprintf("failed %d of %d attempts, last error: %s", c.Attempts, p.MaxAttempts, c.LastError)
+	}
+	if p.Timeout > 0 && c.StartTime != nil && time.Since(c.StartTime.Time) > p.Timeout {
+		return fmt.Sprintf("not completed within its timeout of %s", p.Timeout)
+	}
+	return ""
+}
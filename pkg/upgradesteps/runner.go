@@ -21,29 +21,103 @@ type UpgradeStep interface {
 // Run executes the provided steps in order until one fails or all steps
 // are completed. The function returns an indication of the last-completed
 // UpgradePhase any associated error.
+// If the UpgradeConfig is paused, no steps are executed and the first
+// incomplete step is marked as paused.
+// The top-level status conditions of the UpgradeConfig are updated to
+// summarise the outcome of the run.
 func Run(ctx context.Context, upgradeConfig *upgradev1alpha1.UpgradeConfig, logger logr.Logger, steps []UpgradeStep) (upgradev1alpha1.UpgradePhase, error) {
+	if upgradeConfig.Spec.Paused {
+		history := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
+		for _, step := range steps {
+			if !history.Conditions.IsTrueFor(upgradev1alpha1.UpgradeConditionType(step.String())) {
+				logger.Info(fmt.Sprintf("upgrade is paused, skipping %s and following steps", step.String()))
+				setConditionPaused(step, upgradeConfig)
+				upgradeConfig.SetStatusConditions("UpgradePaused",
+					fmt.Sprintf("Upgrade is paused before %s", step.String()),
+					upgradev1alpha1.UpgradeConfigBlocked)
+				break
+			}
+		}
+		return upgradev1alpha1.UpgradePhaseUpgrading, nil
+	}
+
 	for _, step := range steps {
+		history := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
+		if c := history.Conditions.GetCondition(upgradev1alpha1.UpgradeConditionType(step.String())); c != nil && isAbandoned(step, c) {
+			logger.Info(fmt.Sprintf("skipping step %s as it exhausted its retry budget", step))
+			continue
+		}
+
 		logger.Info(fmt.Sprintf("running step %s", step))
 		setConditionStart(step, upgradeConfig)
+		if wait := backoffRemaining(step, getCondition(step, upgradeConfig)); wait > 0 {
+			msg := fmt.Sprintf("%s has failed and will be retried in %s", step.String(), wait.Round(time.Second))
+			logger.Info(msg)
+			setConditionInProgress(step, msg, upgradeConfig)
+			upgradeConfig.SetStatusConditions(step.String(), msg,
+				upgradev1alpha1.UpgradeConfigProgressing, upgradev1alpha1.UpgradeConfigDegraded)
+			return upgradev1alpha1.UpgradePhaseUpgrading, nil
+		}
+
 		result, err := step.run(ctx, logger)
+		if err != nil {
+			setConditionAttemptFailed(step, err, upgradeConfig)
+		}
+		if err != nil || !result {
+			if reason := exhaustedReason(step, getCondition(step, upgradeConfig), err != nil); reason != "" {
+				policy, _ := policyOf(step)
+				switch policy.OnExhausted {
+				case ExhaustedActionContinue:
+					logger.Info(fmt.Sprintf("%s has exhausted its retry budget (%s), continuing with the following steps", step.String(), reason))
+					setConditionAbandoned(step, reason, upgradeConfig)
+					continue
+				case ExhaustedActionFail, ExhaustedActionNotify:
+					err = &StepExhaustedError{Step: step.String(), Action: policy.OnExhausted, Reason: reason}
+				default:
+					if err == nil {
+						err = fmt.Errorf("%s has %s", step.String(), reason)
+					}
+				}
+			}
+		}
 
 		if err != nil {
 			logger.Error(err, fmt.Sprintf("error when %s", step.String()))
 			setConditionInProgress(step, err.Error(), upgradeConfig)
+			upgradeConfig.SetStatusConditions(step.String(), err.Error(),
+				upgradev1alpha1.UpgradeConfigProgressing, upgradev1alpha1.UpgradeConfigDegraded)
 			return upgradev1alpha1.UpgradePhaseUpgrading, err
 		}
 
 		if !result {
 			logger.Info(fmt.Sprintf("%s not done, skip following steps", step.String()))
 			setConditionInProgress(step, fmt.Sprintf("%s still in progress", step.String()), upgradeConfig)
+			upgradeConfig.SetStatusConditions(step.String(), fmt.Sprintf("%s still in progress", step.String()),
+				upgradev1alpha1.UpgradeConfigProgressing)
 			return upgradev1alpha1.UpgradePhaseUpgrading, nil
 		}
 
 		setConditionComplete(step, upgradeConfig)
 	}
+
+	upgradeConfig.SetStatusConditions("UpgradeCompleted",
+		fmt.Sprintf("Cluster has been upgraded to version %s", upgradeConfig.Spec.Desired.Version),
+		upgradev1alpha1.UpgradeConfigReady)
 	return upgradev1alpha1.UpgradePhaseUpgraded, nil
 }
 
+// getCondition returns the UpgradeCondition describing a step, if any
+func getCondition(step UpgradeStep, upgradeConfig *upgradev1alpha1.UpgradeConfig) *upgradev1alpha1.UpgradeCondition {
+	history := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
+	return history.Conditions.GetCondition(upgradev1alpha1.UpgradeConditionType(step.String()))
+}
+
+// isAbandoned returns whether the condition records that the step was given up on
+// after exhausting its retry budget
+func isAbandoned(step UpgradeStep, c *upgradev1alpha1.UpgradeCondition) bool {
+	return c.IsTrue() && c.Reason == fmt.Sprintf("%s abandoned", step.String())
+}
+
 // newUpgradeCondition is a helper function for creating and returning
 // an UpgradeCondition
 func newUpgradeCondition(reason, msg string, conditionType upgradev1alpha1.UpgradeConditionType, s corev1.ConditionStatus) *upgradev1alpha1.UpgradeCondition {
@@ -70,7 +144,32 @@ func setConditionStart(step UpgradeStep, upgradeConfig *upgradev1alpha1.UpgradeC
 		condition.StartTime = &metav1.Time{Time: time.Now()}
 		history.Conditions.SetCondition(*condition)
 		upgradeConfig.Status.History.SetHistory(*history)
+		return
+	}
+
+	// A step that was paused before it was ever run has no start time yet
+	if c.StartTime == nil {
+		c.Reason = fmt.Sprintf("%s not done", step.String())
+		c.Message = fmt.Sprintf("%s has started", step.String())
+		c.StartTime = &metav1.Time{Time: time.Now()}
+		history.Conditions.SetCondition(*c)
+		upgradeConfig.Status.History.SetHistory(*history)
+	}
+}
+
+// setConditionPaused adds or updates an UpgradeCondition in the UpgradeConfig indicating
+// that a given step is not being executed because the upgrade is paused.
+func setConditionPaused(step UpgradeStep, upgradeConfig *upgradev1alpha1.UpgradeConfig) {
+	history := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
+	c := history.Conditions.GetCondition(upgradev1alpha1.UpgradeConditionType(step.String()))
+	if c == nil {
+		c = newUpgradeCondition("", "", upgradev1alpha1.UpgradeConditionType(step.String()), corev1.ConditionFalse)
 	}
+	c.Reason = fmt.Sprintf("%s paused", step.String())
+	c.Message = fmt.Sprintf("%s is paused until the upgrade is resumed", step.String())
+	c.Status = corev1.ConditionFalse
+	history.Conditions.SetCondition(*c)
+	upgradeConfig.Status.History.SetHistory(*history)
 }
 
 // setConditionInProgress adds or updates an UpgradeCondition in the UpgradeConfig indicating
@@ -105,3 +204,32 @@ func setConditionComplete(step UpgradeStep, upgradeConfig *upgradev1alpha1.Upgra
 		upgradeConfig.Status.History.SetHistory(*history)
 	}
 }
+
+// setConditionAttemptFailed records a failed attempt of a step in its UpgradeCondition.
+func setConditionAttemptFailed(step UpgradeStep, err error, upgradeConfig *upgradev1alpha1.UpgradeConfig) {
+	history := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
+	c := history.Conditions.GetCondition(upgradev1alpha1.UpgradeConditionType(step.String()))
+	if c != nil {
+		c.Attempts++
+		c.LastError = err.Error()
+		c.LastAttemptTime = &metav1.Time{Time: time.Now()}
+		history.Conditions.SetCondition(*c)
+		upgradeConfig.Status.History.SetHistory(*history)
+	}
+}
+
+// setConditionAbandoned updates an UpgradeCondition in the UpgradeConfig indicating
+// that a given step was given up on after exhausting its retry budget, so that the
+// following steps can be run.
+func setConditionAbandoned(step UpgradeStep, reason string, upgradeConfig *upgradev1alpha1.UpgradeConfig) {
+	history := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
+	c := history.Conditions.GetCondition(upgradev1alpha1.UpgradeConditionType(step.String()))
+	if c != nil {
+		c.Reason = fmt.Sprintf("%s abandoned", step.String())
+		c.Message = fmt.Sprintf("%s was abandoned as it has %s", step.String(), reason)
+		c.Status = corev1.ConditionTrue
+		c.CompleteTime = &metav1.Time{Time: time.Now()}
+		history.Conditions.SetCondition(*c)
+		upgradeConfig.Status.History.SetHistory(*history)
+	}
+}
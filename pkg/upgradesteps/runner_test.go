@@ -2,9 +2,14 @@ package upgradesteps
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
+
 	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
 	. "github.com/onsi/ginkgo"
@@ -71,6 +76,16 @@ var _ = Describe("HealthCheckStep", func() {
 				Expect(condition.CompleteTime).ToNot(BeNil())
 			}
 		})
+		It("should mark the upgrade as ready", func() {
+			upgradeConfig.Generation = 2
+			_, err := Run(context.TODO(), upgradeConfig, logger, steps)
+			Expect(err).To(BeNil())
+			Expect(meta.IsStatusConditionTrue(upgradeConfig.Status.Conditions, upgradev1alpha1.UpgradeConfigReady)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(upgradeConfig.Status.Conditions, upgradev1alpha1.UpgradeConfigProgressing)).To(BeTrue())
+			ready := meta.FindStatusCondition(upgradeConfig.Status.Conditions, upgradev1alpha1.UpgradeConfigReady)
+			Expect(ready.Reason).To(Equal("UpgradeCompleted"))
+			Expect(ready.ObservedGeneration).To(Equal(int64(2)))
+		})
 	})
 
 	Context("When a step is unsuccessful", func() {
@@ -107,6 +122,16 @@ var _ = Describe("HealthCheckStep", func() {
 			Expect(unsuccessfulStepCondition.StartTime).ToNot(BeNil())
 			Expect(unsuccessfulStepCondition.CompleteTime).To(BeNil())
 		})
+
+		It("should mark the upgrade as progressing at the unsuccessful step", func() {
+			_, err := Run(context.TODO(), upgradeConfig, logger, steps)
+			Expect(err).To(BeNil())
+			Expect(meta.IsStatusConditionTrue(upgradeConfig.Status.Conditions, upgradev1alpha1.UpgradeConfigProgressing)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(upgradeConfig.Status.Conditions, upgradev1alpha1.UpgradeConfigReady)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(upgradeConfig.Status.Conditions, upgradev1alpha1.UpgradeConfigDegraded)).To(BeTrue())
+			progressing := meta.FindStatusCondition(upgradeConfig.Status.Conditions, upgradev1alpha1.UpgradeConfigProgressing)
+			Expect(progressing.Reason).To(Equal(unsuccessfulStepName))
+		})
 	})
 
 	Context("When a step has errored", func() {
@@ -145,5 +170,178 @@ var _ = Describe("HealthCheckStep", func() {
 			Expect(erroredStepCondition.StartTime).ToNot(BeNil())
 			Expect(erroredStepCondition.CompleteTime).To(BeNil())
 		})
+
+		It("should mark the upgrade as degraded", func() {
+			_, err := Run(context.TODO(), upgradeConfig, logger, steps)
+			Expect(err).To(HaveOccurred())
+			Expect(meta.IsStatusConditionTrue(upgradeConfig.Status.Conditions, upgradev1alpha1.UpgradeConfigDegraded)).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(upgradeConfig.Status.Conditions, upgradev1alpha1.UpgradeConfigProgressing)).To(BeTrue())
+			degraded := meta.FindStatusCondition(upgradeConfig.Status.Conditions, upgradev1alpha1.UpgradeConfigDegraded)
+			Expect(degraded.Reason).To(Equal(erroredStepName))
+			Expect(degraded.Message).To(Equal(err.Error()))
+		})
+	})
+
+	Context("When a step has a timeout", func() {
+		timedStepName := "step with a timeout"
+		steps := []UpgradeStep{
+			Action("step 1", successfulStep),
+			WithPolicy(Action(timedStepName, unsuccessfulStep), StepPolicy{Timeout: time.Hour}),
+		}
+		setStepStartTime := func(start time.Time) {
+			history := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
+			history.Conditions.SetCondition(upgradev1alpha1.UpgradeCondition{
+				Type:      upgradev1alpha1.UpgradeConditionType(timedStepName),
+				Status:    corev1.ConditionFalse,
+				StartTime: &metav1.Time{Time: start},
+			})
+			upgradeConfig.Status.History.SetHistory(*history)
+		}
+
+		It("should indicate the step is still in progress within its timeout", func() {
+			setStepStartTime(time.Now().Add(-30 * time.Minute))
+			phase, err := Run(context.TODO(), upgradeConfig, logger, steps)
+			Expect(err).To(BeNil())
+			Expect(phase).To(Equal(upgradev1alpha1.UpgradePhaseUpgrading))
+		})
+		It("should indicate an error once the timeout has passed", func() {
+			setStepStartTime(time.Now().Add(-2 * time.Hour))
+			phase, err := Run(context.TODO(), upgradeConfig, logger, steps)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("has not completed within its timeout"))
+			Expect(phase).To(Equal(upgradev1alpha1.UpgradePhaseUpgrading))
+			Expect(meta.IsStatusConditionTrue(upgradeConfig.Status.Conditions, upgradev1alpha1.UpgradeConfigDegraded)).To(BeTrue())
+		})
+	})
+
+	Context("When a step has a retry budget", func() {
+		policyStepName := "step with a retry budget"
+		var stepRuns int
+		countedErroredStep := func(ctx context.Context, logger logr.Logger) (bool, error) {
+			stepRuns++
+			return false, fmt.Errorf("a bad time")
+		}
+		stepsWithPolicy := func(p StepPolicy) []UpgradeStep {
+			return []UpgradeStep{
+				WithPolicy(Action(policyStepName, countedErroredStep), p),
+				Action("final step", successfulStep),
+			}
+		}
+		policyCondition := func() *upgradev1alpha1.UpgradeCondition {
+			history := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
+			return history.Conditions.GetCondition(upgradev1alpha1.UpgradeConditionType(policyStepName))
+		}
+
+		BeforeEach(func() {
+			stepRuns = 0
+		})
+
+		It("should record each failed attempt and its error", func() {
+			steps := stepsWithPolicy(StepPolicy{MaxAttempts: 3})
+			for i := 0; i < 2; i++ {
+				_, err := Run(context.TODO(), upgradeConfig, logger, steps)
+				Expect(err).To(MatchError("a bad time"))
+			}
+			Expect(policyCondition().Attempts).To(Equal(2))
+			Expect(policyCondition().LastError).To(Equal("a bad time"))
+			Expect(policyCondition().LastAttemptTime).NotTo(BeNil())
+		})
+		It("should not retry a failed step until its backoff has passed", func() {
+			steps := stepsWithPolicy(StepPolicy{Backoff: time.Hour})
+			_, err := Run(context.TODO(), upgradeConfig, logger, steps)
+			Expect(err).To(HaveOccurred())
+			phase, err := Run(context.TODO(), upgradeConfig, logger, steps)
+			Expect(err).To(BeNil())
+			Expect(phase).To(Equal(upgradev1alpha1.UpgradePhaseUpgrading))
+			Expect(stepRuns).To(Equal(1))
+			Expect(policyCondition().Message).To(ContainSubstring("will be retried in"))
+		})
+		It("should escalate to the caller once its attempts are exhausted", func() {
+			steps := stepsWithPolicy(StepPolicy{MaxAttempts: 2, OnExhausted: ExhaustedActionFail})
+			_, err := Run(context.TODO(), upgradeConfig, logger, steps)
+			Expect(err).To(MatchError("a bad time"))
+			_, err = Run(context.TODO(), upgradeConfig, logger, steps)
+			exhausted := &StepExhaustedError{}
+			Expect(errors.As(err, &exhausted)).To(BeTrue())
+			Expect(exhausted.Step).To(Equal(policyStepName))
+			Expect(exhausted.Action).To(Equal(ExhaustedActionFail))
+		})
+		It("should continue with the following steps once exhausted if configured to", func() {
+			steps := stepsWithPolicy(StepPolicy{MaxAttempts: 1, OnExhausted: ExhaustedActionContinue})
+			phase, err := Run(context.TODO(), upgradeConfig, logger, steps)
+			Expect(err).To(BeNil())
+			Expect(phase).To(Equal(upgradev1alpha1.UpgradePhaseUpgraded))
+			Expect(policyCondition().IsTrue()).To(BeTrue())
+			Expect(policyCondition().Reason).To(Equal(fmt.Sprintf("%s abandoned", policyStepName)))
+
+			_, err = Run(context.TODO(), upgradeConfig, logger, steps)
+			Expect(err).To(BeNil())
+			Expect(stepRuns).To(Equal(1))
+		})
+	})
+
+	Context("When the upgrade is paused", func() {
+		completedStepName := "step 1"
+		pausedStepName := "step 2"
+		notRunStepName := "step 3"
+		var stepRan bool
+		steps := []UpgradeStep{
+			Action(completedStepName, successfulStep),
+			Action(pausedStepName, func(ctx context.Context, logger logr.Logger) (bool, error) {
+				stepRan = true
+				return true, nil
+			}),
+			Action(notRunStepName, successfulStep),
+		}
+
+		BeforeEach(func() {
+			stepRan = false
+			upgradeConfig.Spec.Paused = true
+			history := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
+			history.Conditions.SetCondition(upgradev1alpha1.UpgradeCondition{
+				Type:   upgradev1alpha1.UpgradeConditionType(completedStepName),
+				Status: corev1.ConditionTrue,
+			})
+			upgradeConfig.Status.History.SetHistory(*history)
+		})
+
+		It("should not run any steps and indicate the upgrade is still ongoing", func() {
+			phase, err := Run(context.TODO(), upgradeConfig, logger, steps)
+			Expect(err).To(BeNil())
+			Expect(phase).To(Equal(upgradev1alpha1.UpgradePhaseUpgrading))
+			Expect(stepRan).To(BeFalse())
+		})
+
+		It("should mark the first incomplete step as paused", func() {
+			_, err := Run(context.TODO(), upgradeConfig, logger, steps)
+			Expect(err).To(BeNil())
+			history := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
+			pausedStepCondition := history.Conditions.GetCondition(upgradev1alpha1.UpgradeConditionType(pausedStepName))
+			Expect(pausedStepCondition).ToNot(BeNil())
+			Expect(pausedStepCondition.Status).To(Equal(corev1.ConditionFalse))
+			Expect(pausedStepCondition.Reason).To(Equal(fmt.Sprintf("%s paused", pausedStepName)))
+			Expect(history.Conditions.GetCondition(upgradev1alpha1.UpgradeConditionType(notRunStepName))).To(BeNil())
+		})
+
+		It("should mark the upgrade as blocked", func() {
+			_, err := Run(context.TODO(), upgradeConfig, logger, steps)
+			Expect(err).To(BeNil())
+			Expect(meta.IsStatusConditionTrue(upgradeConfig.Status.Conditions, upgradev1alpha1.UpgradeConfigBlocked)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(upgradeConfig.Status.Conditions, upgradev1alpha1.UpgradeConfigProgressing)).To(BeTrue())
+		})
+
+		It("should resume from the paused step once unpaused", func() {
+			_, err := Run(context.TODO(), upgradeConfig, logger, steps)
+			Expect(err).To(BeNil())
+			upgradeConfig.Spec.Paused = false
+			phase, err := Run(context.TODO(), upgradeConfig, logger, steps)
+			Expect(err).To(BeNil())
+			Expect(phase).To(Equal(upgradev1alpha1.UpgradePhaseUpgraded))
+			Expect(stepRan).To(BeTrue())
+			history := upgradeConfig.Status.History.GetHistory(upgradeConfig.Spec.Desired.Version)
+			resumedStepCondition := history.Conditions.GetCondition(upgradev1alpha1.UpgradeConditionType(pausedStepName))
+			Expect(resumedStepCondition.Status).To(Equal(corev1.ConditionTrue))
+			Expect(resumedStepCondition.StartTime).ToNot(BeNil())
+		})
 	})
 })
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/url"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/openshift/cluster-version-operator/pkg/cincinnati"
@@ -18,12 +19,15 @@ import (
 	"github.com/blang/semver/v4"
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
+	"github.com/hashicorp/go-multierror"
 
 	configv1 "github.com/openshift/api/config/v1"
 	"github.com/openshift/library-go/pkg/image/dockerv1client"
 	imagereference "github.com/openshift/library-go/pkg/image/reference"
 	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
 	cv "github.com/openshift/managed-upgrade-operator/pkg/clusterversion"
+	"github.com/openshift/managed-upgrade-operator/pkg/scheduler"
+	"github.com/openshift/managed-upgrade-operator/pkg/upgradeconfigmanager"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -31,6 +35,13 @@ const (
 	defaultUpstreamServer = "https://api.openshift.com/api/upgrades_info/v1/graph"
 )
 
+var (
+	// validUpgradeTypes are the upgrade types that have a cluster upgrader implementation
+	validUpgradeTypes = []upgradev1alpha1.UpgradeType{upgradev1alpha1.OSD, upgradev1alpha1.ARO, upgradev1alpha1.HCP, upgradev1alpha1.Standard}
+
+	errMissingDesiredUpdate = fmt.Errorf("Not able to validate the upgrade config, either image or (channel + version) needs to be provided")
+)
+
 // NewBuilder returns a validationBuilder object that implements the ValidationBuilder interface.
 func NewBuilder() ValidationBuilder {
 	return &validationBuilder{}
@@ -82,7 +93,7 @@ func (v *validator) IsValidUpgradeConfig(c client.Client, uC *upgradev1alpha1.Up
 
 	// Validate upgradeAt as RFC3339
 	upgradeAt := uC.Spec.UpgradeAt
-	_, err := time.Parse(time.RFC3339, upgradeAt)
+	err := validateUpgradeAt(upgradeAt)
 	if err != nil {
 		return ValidatorResult{
 			IsValid:           false,
@@ -91,6 +102,16 @@ func (v *validator) IsValidUpgradeConfig(c client.Client, uC *upgradev1alpha1.Up
 		}, err
 	}
 
+	// Validate the maintenance windows the upgrade may start in
+	err = scheduler.ValidateMaintenanceWindows(uC.Spec.MaintenanceWindows)
+	if err != nil {
+		return ValidatorResult{
+			IsValid:           false,
+			IsAvailableUpdate: false,
+			Message:           err.Error(),
+		}, err
+	}
+
 	ucImage := uC.Spec.Desired.Image
 	ucVersion := uC.Spec.Desired.Version
 	ucChannel := uC.Spec.Desired.Channel
@@ -133,7 +154,7 @@ func (v *validator) IsValidUpgradeConfig(c client.Client, uC *upgradev1alpha1.Up
 		return ValidatorResult{
 			IsValid:           false,
 			IsAvailableUpdate: false,
-			Message:           "Not able to validate the upgrade config, either image or (channel + version) needs to be provided",
+			Message:           errMissingDesiredUpdate.Error(),
 		}, nil
 	}
 
@@ -197,6 +218,78 @@ func (v *validator) IsValidUpgradeConfig(c client.Client, uC *upgradev1alpha1.Up
 	return validationPassed, nil
 }
 
+// ValidateUpgradeConfigSpec performs the syntactic checks of an UpgradeConfig that don't
+// require access to the cluster or to a release image registry, returning an error
+// describing every check that failed.
+func ValidateUpgradeConfigSpec(uC *upgradev1alpha1.UpgradeConfig) error {
+	var errs *multierror.Error
+
+	if uC.Name != upgradeconfigmanager.UPGRADECONFIG_CR_NAME {
+		errs = multierror.Append(errs, fmt.Errorf("metadata.name must be %s", upgradeconfigmanager.UPGRADECONFIG_CR_NAME))
+	}
+
+	err := validateUpgradeAt(uC.Spec.UpgradeAt)
+	if err != nil {
+		errs = multierror.Append(errs, fmt.Errorf("spec.upgradeAt %q must be a RFC3339 timestamp", uC.Spec.UpgradeAt))
+	}
+
+	err = scheduler.ValidateMaintenanceWindows(uC.Spec.MaintenanceWindows)
+	if err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	desired := uC.Spec.Desired
+	if desired.Image != "" {
+		err = imageValidation(desired.Image)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	} else if desired.Version == "" && desired.Channel == "" {
+		errs = multierror.Append(errs, errMissingDesiredUpdate)
+	}
+	if desired.Version != "" {
+		_, err = semver.Parse(desired.Version)
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("spec.desired.version %s is not a valid semantic version: %v", desired.Version, err))
+		}
+	}
+
+	if !isValidUpgradeType(uC.Spec.Type) {
+		errs = multierror.Append(errs, fmt.Errorf("spec.type %s is not a supported upgrade type", uC.Spec.Type))
+	}
+
+	if uC.Spec.PDBForceDrainTimeout < 0 {
+		errs = multierror.Append(errs, fmt.Errorf("spec.PDBForceDrainTimeout %d must not be negative", uC.Spec.PDBForceDrainTimeout))
+	}
+
+	if errs != nil {
+		errs.ErrorFormat = func(es []error) string {
+			msgs := make([]string, len(es))
+			for i, e := range es {
+				msgs[i] = e.Error()
+			}
+			return strings.Join(msgs, "; ")
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// validateUpgradeAt checks that the upgradeAt time is a RFC3339 timestamp
+func validateUpgradeAt(upgradeAt string) error {
+	_, err := time.Parse(time.RFC3339, upgradeAt)
+	return err
+}
+
+// isValidUpgradeType returns true if the upgrade type has a cluster upgrader implementation
+func isValidUpgradeType(upgradeType upgradev1alpha1.UpgradeType) bool {
+	for _, t := range validUpgradeTypes {
+		if upgradeType == t {
+			return true
+		}
+	}
+	return false
+}
+
 // compareVersions accepts desiredVersion and currentVersion strings as versions, converts
 // them to semver and then compares them. Returns an indication of whether the desired
 // version constitutes a downgrade, no-op or upgrade, or an error if no valid comparison can occur
@@ -335,4 +335,51 @@ var _ = Describe("Validation of UpgradeConfig CR", func() {
 			Expect(result.IsValid).Should(BeFalse())
 		})
 	})
+
+	Context("Validating the UpgradeConfig spec syntax", func() {
+		BeforeEach(func() {
+			testUpgradeConfig.Name = "managed-upgrade-config"
+			testUpgradeConfig.Spec.Desired.Version = "4.14.1"
+		})
+		Context("When the UpgradeConfig is valid", func() {
+			It("should not return an error", func() {
+				err := ValidateUpgradeConfigSpec(testUpgradeConfig)
+				Expect(err).Should(BeNil())
+			})
+		})
+		Context("When the UpgradeConfig has several invalid fields", func() {
+			It("should report all of them", func() {
+				testUpgradeConfig.Spec.UpgradeAt = "sometime tomorrow morning would be great thanks"
+				testUpgradeConfig.Spec.Type = "Unknown"
+				testUpgradeConfig.Spec.PDBForceDrainTimeout = -10
+
+				err := ValidateUpgradeConfigSpec(testUpgradeConfig)
+				Expect(err).ShouldNot(BeNil())
+				Expect(err.Error()).Should(ContainSubstring("spec.upgradeAt"))
+				Expect(err.Error()).Should(ContainSubstring("spec.type"))
+				Expect(err.Error()).Should(ContainSubstring("spec.PDBForceDrainTimeout"))
+			})
+		})
+		Context("When the UpgradeConfig has an invalid maintenance window", func() {
+			It("should return an error", func() {
+				testUpgradeConfig.Spec.MaintenanceWindows = []upgradev1alpha1.MaintenanceWindow{
+					{Days: []upgradev1alpha1.Weekday{"Tuesday"}, StartTime: "02:00", EndTime: "06:00", TimeZone: "Europe/Atlantis"},
+				}
+
+				err := ValidateUpgradeConfigSpec(testUpgradeConfig)
+				Expect(err).ShouldNot(BeNil())
+				Expect(err.Error()).Should(ContainSubstring("spec.maintenanceWindows[0]"))
+			})
+		})
+		Context("When the UpgradeConfig has a valid image and no version", func() {
+			It("should not return an error", func() {
+				testUpgradeConfig.Spec.Desired.Version = ""
+				testUpgradeConfig.Spec.Desired.Channel = ""
+				testUpgradeConfig.Spec.Desired.Image = "quay.io/openshift-release-dev/ocp-release@sha256:783a2c963f35ccab38e82e6a8c7fa954c3a4551e07d2f43c06098828dd986ed4"
+
+				err := ValidateUpgradeConfigSpec(testUpgradeConfig)
+				Expect(err).Should(BeNil())
+			})
+		})
+	})
 })
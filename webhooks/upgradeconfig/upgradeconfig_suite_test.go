@@ -0,0 +1,13 @@
+package upgradeconfig
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestUpgradeConfigWebhook(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "UpgradeConfig Webhook Suite")
+}
@@ -0,0 +1,64 @@
+package upgradeconfig
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	"github.com/openshift/managed-upgrade-operator/pkg/validation"
+)
+
+var log = logf.Log.WithName("webhook_upgradeconfig")
+
+// blank assignment to verify that UpgradeConfigValidator implements admission.CustomValidator
+var _ admission.CustomValidator = &UpgradeConfigValidator{}
+
+// UpgradeConfigValidator validates UpgradeConfig objects at admission time
+type UpgradeConfigValidator struct{}
+
+// ValidateCreate rejects an UpgradeConfig that fails the syntactic validation checks
+func (v *UpgradeConfigValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, validateUpgradeConfig(obj)
+}
+
+// ValidateUpdate rejects an update resulting in an UpgradeConfig that fails the syntactic validation checks
+func (v *UpgradeConfigValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	uc, ok := newObj.(*upgradev1alpha1.UpgradeConfig)
+	if ok && !uc.DeletionTimestamp.IsZero() {
+		// Don't block the removal of an UpgradeConfig that is being deleted
+		return nil, nil
+	}
+	return nil, validateUpgradeConfig(newObj)
+}
+
+// ValidateDelete allows every UpgradeConfig to be deleted
+func (v *UpgradeConfigValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateUpgradeConfig(obj runtime.Object) error {
+	uc, ok := obj.(*upgradev1alpha1.UpgradeConfig)
+	if !ok {
+		return fmt.Errorf("expected an UpgradeConfig but got %T", obj)
+	}
+
+	err := validation.ValidateUpgradeConfigSpec(uc)
+	if err != nil {
+		log.Info("Rejecting invalid UpgradeConfig", "Namespace", uc.Namespace, "Name", uc.Name, "reason", err.Error())
+		return fmt.Errorf("invalid UpgradeConfig: %v", err)
+	}
+	return nil
+}
+
+// SetupWithManager registers the validating webhook with the Manager's webhook server.
+func (v *UpgradeConfigValidator) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&upgradev1alpha1.UpgradeConfig{}).
+		WithValidator(v).
+		Complete()
+}
@@ -0,0 +1,94 @@
+package upgradeconfig
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	upgradev1alpha1 "github.com/openshift/managed-upgrade-operator/api/v1alpha1"
+	testStructs "github.com/openshift/managed-upgrade-operator/util/mocks/structs"
+)
+
+var _ = Describe("UpgradeConfigValidator", func() {
+	var (
+		validator     *UpgradeConfigValidator
+		upgradeConfig *upgradev1alpha1.UpgradeConfig
+	)
+
+	BeforeEach(func() {
+		validator = &UpgradeConfigValidator{}
+		upgradeConfig = testStructs.NewUpgradeConfigBuilder().WithNamespacedName(types.NamespacedName{
+			Name:      "managed-upgrade-config",
+			Namespace: "test-namespace",
+		}).GetUpgradeConfig()
+		upgradeConfig.Spec.Desired.Version = "4.14.1"
+	})
+
+	Context("When the UpgradeConfig is valid", func() {
+		It("admits it on create and update", func() {
+			_, err := validator.ValidateCreate(context.TODO(), upgradeConfig)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = validator.ValidateUpdate(context.TODO(), upgradeConfig, upgradeConfig)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When the UpgradeConfig is invalid", func() {
+		It("rejects a non-RFC3339 upgradeAt", func() {
+			upgradeConfig.Spec.UpgradeAt = "tomorrow at noon"
+			_, err := validator.ValidateCreate(context.TODO(), upgradeConfig)
+			Expect(err).To(MatchError(ContainSubstring("spec.upgradeAt")))
+		})
+		It("rejects a desired version that isn't semver", func() {
+			upgradeConfig.Spec.Desired.Version = "4.14"
+			_, err := validator.ValidateCreate(context.TODO(), upgradeConfig)
+			Expect(err).To(MatchError(ContainSubstring("spec.desired.version")))
+		})
+		It("rejects a missing image, version and channel", func() {
+			upgradeConfig.Spec.Desired = upgradev1alpha1.Update{}
+			_, err := validator.ValidateCreate(context.TODO(), upgradeConfig)
+			Expect(err).To(MatchError(ContainSubstring("either image or (channel + version) needs to be provided")))
+		})
+		It("rejects an unknown upgrade type", func() {
+			upgradeConfig.Spec.Type = "Unknown"
+			_, err := validator.ValidateCreate(context.TODO(), upgradeConfig)
+			Expect(err).To(MatchError(ContainSubstring("spec.type")))
+		})
+		It("rejects a negative PDBForceDrainTimeout", func() {
+			upgradeConfig.Spec.PDBForceDrainTimeout = -1
+			_, err := validator.ValidateCreate(context.TODO(), upgradeConfig)
+			Expect(err).To(MatchError(ContainSubstring("spec.PDBForceDrainTimeout")))
+		})
+		It("rejects an unexpected name", func() {
+			upgradeConfig.Name = "my-upgrade"
+			_, err := validator.ValidateCreate(context.TODO(), upgradeConfig)
+			Expect(err).To(MatchError(ContainSubstring("metadata.name")))
+		})
+		It("rejects an update resulting in an invalid UpgradeConfig", func() {
+			updated := upgradeConfig.DeepCopy()
+			updated.Spec.UpgradeAt = ""
+			_, err := validator.ValidateUpdate(context.TODO(), upgradeConfig, updated)
+			Expect(err).To(HaveOccurred())
+		})
+		It("admits an update to an UpgradeConfig that is being deleted", func() {
+			updated := upgradeConfig.DeepCopy()
+			updated.Spec.UpgradeAt = ""
+			updated.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			_, err := validator.ValidateUpdate(context.TODO(), upgradeConfig, updated)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When deleting an UpgradeConfig", func() {
+		It("admits it even if it is invalid", func() {
+			upgradeConfig.Spec.UpgradeAt = ""
+			_, err := validator.ValidateDelete(context.TODO(), upgradeConfig)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})